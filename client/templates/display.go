@@ -0,0 +1,66 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+)
+
+// DisplayOptions controls how amounts are rendered. Stored amounts are never
+// affected; expenses stay negative and income positive in the database.
+type DisplayOptions struct {
+	AbsoluteExpenses bool // Show expenses as positive magnitudes without a minus sign
+	RoundDollars     bool // Round amounts to the nearest whole dollar
+}
+
+type displayOptionsKey struct{}
+
+// WithDisplayOptions returns a context carrying the display options for templates.
+func WithDisplayOptions(ctx context.Context, opts DisplayOptions) context.Context {
+	return context.WithValue(ctx, displayOptionsKey{}, opts)
+}
+
+// DisplayOptionsFrom returns the display options stored in ctx, or the defaults.
+func DisplayOptionsFrom(ctx context.Context) DisplayOptions {
+	opts, _ := ctx.Value(displayOptionsKey{}).(DisplayOptions)
+	return opts
+}
+
+// FormatDisplayAmount formats a signed amount for display. Income is shown
+// with a plus sign, expenses with a minus sign unless AbsoluteExpenses is set
+// and transfers, which are neither, without a sign.
+func FormatDisplayAmount(opts DisplayOptions, cents int64, categoryType string) string {
+	if categoryType == "income" {
+		return "+" + FormatDisplayMoney(opts, cents)
+	}
+	if categoryType == "transfer" || opts.AbsoluteExpenses {
+		return FormatDisplayMoney(opts, cents)
+	}
+	return "-" + FormatDisplayMoney(opts, cents)
+}
+
+// FormatDisplayMoney formats an unsigned amount, rounded to the nearest
+// dollar (halves away from zero) when RoundDollars is set.
+func FormatDisplayMoney(opts DisplayOptions, cents int64) string {
+	if !opts.RoundDollars {
+		return formatMoney(cents)
+	}
+	if cents < 0 {
+		cents = -cents
+	}
+	return fmt.Sprintf("$%d", (cents+50)/100)
+}
+
+func displayAmount(ctx context.Context, cents int64, categoryType string) string {
+	return FormatDisplayAmount(DisplayOptionsFrom(ctx), cents, categoryType)
+}
+
+func displayMoney(ctx context.Context, cents int64) string {
+	return FormatDisplayMoney(DisplayOptionsFrom(ctx), cents)
+}
+
+func displayMoneyWithSign(ctx context.Context, cents int64) string {
+	if cents >= 0 {
+		return "+" + displayMoney(ctx, cents)
+	}
+	return "-" + displayMoney(ctx, cents)
+}
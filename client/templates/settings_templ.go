@@ -21,7 +21,7 @@ type BackupStatus struct {
 	LastBackupAt string
 }
 
-func Settings(mappings []CategoryMapping, backup BackupStatus) templ.Component {
+func Settings(mappings []CategoryMapping, backup BackupStatus, wipePhrase string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -42,7 +42,7 @@ func Settings(mappings []CategoryMapping, backup BackupStatus) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = Layout("Settings", SettingsView(mappings, backup)).Render(ctx, templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = Layout("Settings", SettingsView(mappings, backup, wipePhrase)).Render(ctx, templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -50,7 +50,7 @@ func Settings(mappings []CategoryMapping, backup BackupStatus) templ.Component {
 	})
 }
 
-func SettingsView(mappings []CategoryMapping, backup BackupStatus) templ.Component {
+func SettingsView(mappings []CategoryMapping, backup BackupStatus, wipePhrase string) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -187,7 +187,20 @@ func SettingsView(mappings []CategoryMapping, backup BackupStatus) templ.Compone
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</div><!-- Actions --><div class=\"flex flex-wrap gap-3\"><a href=\"/api/backup/download\" class=\"inline-block px-4 py-2 bg-purple-600 text-white text-sm font-medium rounded-lg hover:bg-purple-700 transition\">Download Backup</a> <label class=\"inline-block px-4 py-2 bg-gray-100 text-gray-700 text-sm font-medium rounded-lg hover:bg-gray-200 transition cursor-pointer\">Restore from Backup <input type=\"file\" name=\"backup\" accept=\".db\" class=\"hidden\" hx-post=\"/api/backup/restore\" hx-target=\"#restore-result\" hx-swap=\"innerHTML\" hx-encoding=\"multipart/form-data\"></label></div><div id=\"restore-result\"></div></div><!-- Wipe Data --><div class=\"bg-white rounded-xl p-6 shadow-sm border border-red-100 space-y-3\"><h3 class=\"font-bold text-red-700\">Danger Zone</h3><p class=\"text-sm text-gray-500\">Permanently delete all transactions. This cannot be undone.</p><button id=\"wipe-btn\" class=\"px-4 py-2 bg-red-600 text-white text-sm font-medium rounded-lg hover:bg-red-700 transition\" onclick=\"document.getElementById('wipe-confirm').classList.remove('hidden')\">Wipe All Data</button><div id=\"wipe-confirm\" class=\"hidden mt-4 p-4 bg-red-50 rounded-lg border border-red-200 space-y-3\"><p class=\"text-sm text-red-700 font-medium\">Are you sure? All transactions will be permanently deleted.</p><div class=\"flex gap-3\"><button hx-delete=\"/api/data\" hx-target=\"#wipe-result\" hx-swap=\"innerHTML\" class=\"px-4 py-2 bg-red-700 text-white text-sm font-medium rounded-lg hover:bg-red-800 transition\">Yes, delete everything</button> <button class=\"px-4 py-2 bg-gray-200 text-gray-700 text-sm font-medium rounded-lg hover:bg-gray-300 transition\" onclick=\"document.getElementById('wipe-confirm').classList.add('hidden')\">Cancel</button></div></div><div id=\"wipe-result\"></div></div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</div><!-- Actions --><div class=\"flex flex-wrap gap-3\"><a href=\"/api/backup/download\" class=\"inline-block px-4 py-2 bg-purple-600 text-white text-sm font-medium rounded-lg hover:bg-purple-700 transition\">Download Backup</a> <label class=\"inline-block px-4 py-2 bg-gray-100 text-gray-700 text-sm font-medium rounded-lg hover:bg-gray-200 transition cursor-pointer\">Restore from Backup <input type=\"file\" name=\"backup\" accept=\".db\" class=\"hidden\" hx-post=\"/api/backup/restore\" hx-target=\"#restore-result\" hx-swap=\"innerHTML\" hx-encoding=\"multipart/form-data\"></label></div><div id=\"restore-result\"></div></div><!-- Wipe Data --><div class=\"bg-white rounded-xl p-6 shadow-sm border border-red-100 space-y-3\"><h3 class=\"font-bold text-red-700\">Danger Zone</h3><p class=\"text-sm text-gray-500\">Permanently delete all transactions. This cannot be undone.</p><button id=\"wipe-btn\" class=\"px-4 py-2 bg-red-600 text-white text-sm font-medium rounded-lg hover:bg-red-700 transition\" onclick=\"document.getElementById('wipe-confirm').classList.remove('hidden')\">Wipe All Data</button><div id=\"wipe-confirm\" class=\"hidden mt-4 p-4 bg-red-50 rounded-lg border border-red-200 space-y-3\"><p class=\"text-sm text-red-700 font-medium\">Are you sure? All transactions will be permanently deleted.</p><label class=\"block text-sm text-red-700\">Type <code class=\"bg-white px-1.5 py-0.5 rounded text-xs font-mono\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var8 string
+		templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(wipePhrase)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 151, Col: 85}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</code> to confirm <input id=\"wipe-confirm-input\" name=\"confirm\" type=\"text\" autocomplete=\"off\" class=\"mt-1 block w-full px-3 py-2 border border-red-200 rounded-lg text-sm\"></label><div class=\"flex gap-3\"><button hx-delete=\"/api/data\" hx-include=\"#wipe-confirm-input\" hx-target=\"#wipe-result\" hx-swap=\"innerHTML\" class=\"px-4 py-2 bg-red-700 text-white text-sm font-medium rounded-lg hover:bg-red-800 transition\">Yes, delete everything</button> <button class=\"px-4 py-2 bg-gray-200 text-gray-700 text-sm font-medium rounded-lg hover:bg-gray-300 transition\" onclick=\"document.getElementById('wipe-confirm').classList.add('hidden')\">Cancel</button></div></div><div id=\"wipe-result\"></div></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -202,6 +215,13 @@ func keywordCount(n int) string {
 	return strings.Join([]string{itoa(n), "keywords"}, " ")
 }
 
+func transactionCount(n int64) string {
+	if n == 1 {
+		return "1 transaction"
+	}
+	return strings.Join([]string{itoa(int(n)), "transactions"}, " ")
+}
+
 func itoa(n int) string {
 	s := ""
 	if n == 0 {
@@ -214,7 +234,7 @@ func itoa(n int) string {
 	return s
 }
 
-func WipeSuccess() templ.Component {
+func WipeSuccess(deleted int64) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -230,12 +250,25 @@ func WipeSuccess() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var8 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var8 == nil {
-			templ_7745c5c3_Var8 = templ.NopComponent
+		templ_7745c5c3_Var9 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var9 == nil {
+			templ_7745c5c3_Var9 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "<div class=\"p-4 rounded-xl bg-green-50 border border-green-100 text-green-700 flex items-center gap-3 mt-4\"><div class=\"bg-white p-2 rounded-full shadow-sm text-xl\">&#x2705;</div><div><div class=\"font-bold\">All data has been deleted</div><div class=\"text-xs opacity-75\">Your transaction history has been wiped.</div></div></div><script>\n\t\tvar confirm = document.getElementById('wipe-confirm');\n\t\tif (confirm) confirm.classList.add('hidden');\n\t</script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "<div class=\"p-4 rounded-xl bg-green-50 border border-green-100 text-green-700 flex items-center gap-3 mt-4\"><div class=\"bg-white p-2 rounded-full shadow-sm text-xl\">&#x2705;</div><div><div class=\"font-bold\">All data has been deleted</div><div class=\"text-xs opacity-75\">Deleted ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var10 string
+		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(transactionCount(deleted))
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 214, Col: 70}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, ". Your transaction history has been wiped.</div></div></div><script>\n\t\tvar confirm = document.getElementById('wipe-confirm');\n\t\tif (confirm) confirm.classList.add('hidden');\n\t</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -259,25 +292,67 @@ func WipeError(msg string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var9 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var9 == nil {
-			templ_7745c5c3_Var9 = templ.NopComponent
+		templ_7745c5c3_Var11 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var11 == nil {
+			templ_7745c5c3_Var11 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "<div class=\"p-4 rounded-xl bg-red-50 border border-red-100 text-red-700 mt-4\">Failed to wipe data: ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "<div class=\"p-4 rounded-xl bg-red-50 border border-red-100 text-red-700 mt-4\">Failed to wipe data: ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var10 string
-		templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(msg)
+		var templ_7745c5c3_Var12 string
+		templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(msg)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 207, Col: 27}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 225, Col: 27}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "</div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
+func WipeConfirmRequired(phrase string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var13 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var13 == nil {
+			templ_7745c5c3_Var13 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "<div class=\"p-4 rounded-xl bg-red-50 border border-red-100 text-red-700 mt-4\">Nothing was deleted. Type <code class=\"font-mono font-bold\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "</div>")
+		var templ_7745c5c3_Var14 string
+		templ_7745c5c3_Var14, templ_7745c5c3_Err = templ.JoinStringErrs(phrase)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 231, Col: 70}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var14))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "</code> exactly to confirm.</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -301,12 +376,12 @@ func BackupRestoreSuccess() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var11 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var11 == nil {
-			templ_7745c5c3_Var11 = templ.NopComponent
+		templ_7745c5c3_Var15 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var15 == nil {
+			templ_7745c5c3_Var15 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "<div class=\"p-4 rounded-xl bg-green-50 border border-green-100 text-green-700 flex items-center gap-3 mt-4\"><div class=\"bg-white p-2 rounded-full shadow-sm text-xl\">&#x2705;</div><div><div class=\"font-bold\">Backup restored successfully</div><div class=\"text-xs opacity-75\">Your database has been replaced with the uploaded backup. Refresh the page to see updated data.</div></div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "<div class=\"p-4 rounded-xl bg-green-50 border border-green-100 text-green-700 flex items-center gap-3 mt-4\"><div class=\"bg-white p-2 rounded-full shadow-sm text-xl\">&#x2705;</div><div><div class=\"font-bold\">Backup restored successfully</div><div class=\"text-xs opacity-75\">Your database has been replaced with the uploaded backup. Refresh the page to see updated data.</div></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -330,25 +405,25 @@ func BackupRestoreError(msg string) templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var12 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var12 == nil {
-			templ_7745c5c3_Var12 = templ.NopComponent
+		templ_7745c5c3_Var16 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var16 == nil {
+			templ_7745c5c3_Var16 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "<div class=\"p-4 rounded-xl bg-red-50 border border-red-100 text-red-700 mt-4\">Restore failed: ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "<div class=\"p-4 rounded-xl bg-red-50 border border-red-100 text-red-700 mt-4\">Restore failed: ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		var templ_7745c5c3_Var13 string
-		templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(msg)
+		var templ_7745c5c3_Var17 string
+		templ_7745c5c3_Var17, templ_7745c5c3_Err = templ.JoinStringErrs(msg)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 223, Col: 22}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `client/templates/settings.templ`, Line: 247, Col: 22}
 		}
-		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var17))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "</div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -3,7 +3,7 @@ module github.com/calexandrepcjr/cheapskate-finance-tracker
 go 1.24
 
 require (
-	github.com/a-h/templ v0.3.977 // indirect
-	github.com/go-chi/chi/v5 v5.2.4 // indirect
-	github.com/mattn/go-sqlite3 v1.14.33 // indirect
+	github.com/a-h/templ v0.3.977
+	github.com/go-chi/chi/v5 v5.2.4
+	github.com/mattn/go-sqlite3 v1.14.33
 )
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ExitBreakingChange is the exit code of validate-commit --detect-breaking
+// when a valid message announces a breaking change, so release tooling can
+// tell it apart from success (0) and rejection (1).
+const ExitBreakingChange = 3
+
+// breakingSubjectPattern matches a "!" after the type or scope: feat!: or feat(api)!:
+var breakingSubjectPattern = regexp.MustCompile(`^[a-z]+(\([a-z0-9_-]+\))?!: `)
+
+// footerPattern matches git trailer style footers such as "Refs: #12" or
+// "BREAKING CHANGE: drops v1"
+var footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z][A-Za-z-]*)(: | #)`)
+
+// BreakingChangeError reports a breaking change whose message has no body
+type BreakingChangeError struct {
+	FirstLine string
+}
+
+func (e *BreakingChangeError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString("\n")
+	sb.WriteString("==========================================\n")
+	sb.WriteString("COMMIT REJECTED: Breaking change without a body!\n")
+	sb.WriteString("==========================================\n")
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("Your commit message:\n  \"%s\"\n\n", e.FirstLine))
+	sb.WriteString("Breaking changes must explain what breaks and how to migrate\n")
+	sb.WriteString("in the body, separated from the subject by a blank line:\n\n")
+	sb.WriteString("  feat(api)!: drop the v1 export format\n\n")
+	sb.WriteString("  Clients must request /api/storage/export?format=v2 instead.\n")
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// IsBreakingChange reports whether a commit message announces a breaking
+// change, either with "!" before the colon or a BREAKING CHANGE footer
+func IsBreakingChange(message string) bool {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	if breakingSubjectPattern.MatchString(strings.TrimSpace(lines[0])) {
+		return true
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE: ") || strings.HasPrefix(line, "BREAKING-CHANGE: ") {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCommitBody reports whether a commit message has body text after the
+// subject, not counting footers
+func HasCommitBody(message string) bool {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	for _, line := range lines[1:] {
+		line = strings.TrimSpace(line)
+		if line != "" && !footerPattern.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBreakingBody rejects breaking changes that lack a body
+func ValidateBreakingBody(message string) error {
+	if !IsBreakingChange(message) || HasCommitBody(message) {
+		return nil
+	}
+	firstLine := strings.TrimSpace(strings.Split(strings.TrimSpace(message), "\n")[0])
+	return &BreakingChangeError{FirstLine: firstLine}
+}
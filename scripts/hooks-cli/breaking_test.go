@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsBreakingChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{name: "bang after type", message: "feat!: drop v1 export", want: true},
+		{name: "bang after scope", message: "fix(api)!: rename amount field", want: true},
+		{name: "footer", message: "feat: new export\n\nUses v2 layout.\n\nBREAKING CHANGE: v1 clients must upgrade", want: true},
+		{name: "hyphenated footer", message: "feat: new export\n\nBREAKING-CHANGE: v1 removed", want: true},
+		{name: "regular commit", message: "feat: add export", want: false},
+		{name: "mention in subject only", message: "docs: explain BREAKING CHANGE: footers", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBreakingChange(tt.message); got != tt.want {
+				t.Errorf("IsBreakingChange(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBreakingBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{name: "bang with body", message: "feat!: drop v1 export\n\nClients must switch to v2.", wantErr: false},
+		{name: "bang without body", message: "feat!: drop v1 export", wantErr: true},
+		{name: "footer with body", message: "feat: new export\n\nUses the v2 layout.\n\nBREAKING CHANGE: v1 removed", wantErr: false},
+		{name: "footer without body", message: "feat: new export\n\nBREAKING CHANGE: v1 removed", wantErr: true},
+		{name: "only other footers", message: "feat!: drop v1\n\nRefs: #12", wantErr: true},
+		{name: "not breaking", message: "feat: add export", wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBreakingBody(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBreakingBody(%q) error = %v, wantErr %v", tt.message, err, tt.wantErr)
+			}
+			var breakingErr *BreakingChangeError
+			if err != nil && !errors.As(err, &breakingErr) {
+				t.Errorf("ValidateBreakingBody() error type = %T, want *BreakingChangeError", err)
+			}
+		})
+	}
+}
+
+func TestRunValidation_DetectBreaking(t *testing.T) {
+	opts := validateOptions{types: ValidCommitTypes, detectBreaking: true}
+
+	tests := []struct {
+		name     string
+		message  string
+		opts     validateOptions
+		wantCode int
+	}{
+		{name: "breaking detected", message: "feat!: drop v1 export", opts: opts, wantCode: ExitBreakingChange},
+		{name: "footer detected", message: "feat: v2 export\n\nBREAKING CHANGE: v1 removed", opts: opts, wantCode: ExitBreakingChange},
+		{name: "not breaking", message: "feat: add export", opts: opts, wantCode: 0},
+		{name: "invalid message", message: "drop v1 export", opts: opts, wantCode: 1},
+		{name: "detection off", message: "feat!: drop v1 export", opts: validateOptions{types: ValidCommitTypes}, wantCode: 0},
+		{
+			name:     "missing required body",
+			message:  "feat!: drop v1 export",
+			opts:     validateOptions{types: ValidCommitTypes, detectBreaking: true, requireBreakingBody: true},
+			wantCode: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runValidation(tt.message, tt.opts); got != tt.wantCode {
+				t.Errorf("runValidation(%q) = %d, want %d", tt.message, got, tt.wantCode)
+			}
+		})
+	}
+}
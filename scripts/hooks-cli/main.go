@@ -3,13 +3,21 @@
 //
 // Usage:
 //
-//	hooks-cli validate-commit <message>    Validate a commit message
-//	hooks-cli validate-commit-file <file>  Validate commit message from file
-//	hooks-cli setup-hooks                  Install git hooks
-//	hooks-cli run-tests                    Run test suite
+//	hooks-cli validate-commit [options] <message>    Validate a commit message
+//	hooks-cli validate-commit-file [options] <file>  Validate commit message from file
+//	hooks-cli setup-hooks                            Install git hooks
+//	hooks-cli run-tests                              Run test suite
+//	hooks-cli serve [--port 9000]                    Serve POST /validate over HTTP
+//
+// The validation commands accept --types to change the allowed commit types,
+// e.g. --types +wip,-revert. Without it a .commit-types file in the working
+// directory is used when present. --require-breaking-body rejects breaking
+// changes (feat!: or a BREAKING CHANGE footer) that have no body, and
+// --detect-breaking exits with ExitBreakingChange for valid breaking changes.
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 )
@@ -24,30 +32,27 @@ func main() {
 
 	switch command {
 	case "validate-commit":
-		if len(os.Args) < 3 {
+		opts, args := parseValidateFlags("validate-commit", os.Args[2:])
+		if len(args) < 1 {
 			fmt.Fprintln(os.Stderr, "Error: commit message required")
-			fmt.Fprintln(os.Stderr, "Usage: hooks-cli validate-commit <message>")
-			os.Exit(1)
-		}
-		message := os.Args[2]
-		if err := ValidateCommitMessage(message); err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, "Usage: hooks-cli validate-commit [options] <message>")
 			os.Exit(1)
 		}
-		fmt.Println("Commit message format validated: conventional commit")
+		os.Exit(runValidation(args[0], opts))
 
 	case "validate-commit-file":
-		if len(os.Args) < 3 {
+		opts, args := parseValidateFlags("validate-commit-file", os.Args[2:])
+		if len(args) < 1 {
 			fmt.Fprintln(os.Stderr, "Error: commit message file required")
-			fmt.Fprintln(os.Stderr, "Usage: hooks-cli validate-commit-file <file>")
+			fmt.Fprintln(os.Stderr, "Usage: hooks-cli validate-commit-file [options] <file>")
 			os.Exit(1)
 		}
-		filePath := os.Args[2]
-		if err := ValidateCommitMessageFile(filePath); err != nil {
+		message, err := ReadCommitMessageFile(args[0])
+		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
-		fmt.Println("Commit message format validated: conventional commit")
+		os.Exit(runValidation(message, opts))
 
 	case "setup-hooks":
 		if err := SetupHooks(); err != nil {
@@ -61,6 +66,12 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "serve":
+		if err := Serve(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error serving: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "help", "-h", "--help":
 		printUsage()
 
@@ -71,6 +82,51 @@ func main() {
 	}
 }
 
+// validateOptions holds the flags shared by the validation commands
+type validateOptions struct {
+	types               []string
+	requireBreakingBody bool
+	detectBreaking      bool
+}
+
+// parseValidateFlags parses the validation command flags and returns them
+// along with the remaining arguments.
+func parseValidateFlags(command string, args []string) (validateOptions, []string) {
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	typesFlag := fs.String("types", "", "Comma-separated allowed types (+type adds, -type removes)")
+	requireBody := fs.Bool("require-breaking-body", false, "Reject breaking changes without a body")
+	detect := fs.Bool("detect-breaking", false, fmt.Sprintf("Exit with code %d when a valid message is a breaking change", ExitBreakingChange))
+	fs.Parse(args)
+
+	types, err := ResolveCommitTypes(*typesFlag, DefaultCommitTypesFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return validateOptions{types: types, requireBreakingBody: *requireBody, detectBreaking: *detect}, fs.Args()
+}
+
+// runValidation validates message, reports the outcome and returns the exit code.
+func runValidation(message string, opts validateOptions) int {
+	if err := ValidateCommitMessageWithTypes(message, opts.types); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if opts.requireBreakingBody {
+		if err := ValidateBreakingBody(message); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	fmt.Println("Commit message format validated: conventional commit")
+
+	if opts.detectBreaking && IsBreakingChange(message) {
+		fmt.Println("Breaking change detected")
+		return ExitBreakingChange
+	}
+	return 0
+}
+
 func printUsage() {
 	fmt.Println(`hooks-cli - Git hooks management tool for Cheapskate Finance Tracker
 
@@ -82,11 +138,26 @@ Commands:
   validate-commit-file <file>  Validate commit message from a file (used by git hooks)
   setup-hooks                  Install git hooks (pre-commit and commit-msg)
   run-tests                    Run the test suite
+  serve [--port 9000]          Validate commit messages sent to POST /validate
   help                         Show this help message
 
+Options for validate-commit, validate-commit-file and serve:
+  --types <list>               Comma-separated allowed types; +type adds to and
+                               -type removes from the defaults. Falls back to
+                               a .commit-types file (one entry per line)
+
+Options for validate-commit and validate-commit-file:
+  --require-breaking-body      Reject breaking changes (feat!: or a BREAKING
+                               CHANGE footer) that have no body
+  --detect-breaking            Exit with code 3 when a valid message is a
+                               breaking change
+
 Examples:
   hooks-cli validate-commit "feat: add new feature"
+  hooks-cli validate-commit --types +wip,-revert "wip: half done"
+  hooks-cli validate-commit --detect-breaking "feat!: drop v1 export"
   hooks-cli validate-commit-file .git/COMMIT_EDITMSG
   hooks-cli setup-hooks
-  hooks-cli run-tests`)
+  hooks-cli run-tests
+  hooks-cli serve --port 9000`)
 }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// maxCommitMessageBytes caps the request body accepted by POST /validate.
+const maxCommitMessageBytes = 64 << 10
+
+// NewValidateHandler returns the HTTP handler used by the serve command.
+// POST /validate reads a commit message from the request body and answers
+// 200 when it uses one of types, or 400 with the validation error otherwise.
+func NewValidateHandler(types []string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /validate", func(w http.ResponseWriter, r *http.Request) {
+		handleValidate(w, r, types)
+	})
+	return mux
+}
+
+func handleValidate(w http.ResponseWriter, r *http.Request, types []string) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCommitMessageBytes))
+	if err != nil {
+		http.Error(w, "failed to read commit message: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := ValidateCommitMessageWithTypes(string(body), types); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, err)
+		return
+	}
+	fmt.Fprintln(w, "Commit message format validated: conventional commit")
+}
+
+// Serve runs the validation HTTP server until it fails. args are the
+// arguments following the serve command.
+func Serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	port := fs.Int("port", 9000, "Port to listen on")
+	typesFlag := fs.String("types", "", "Comma-separated allowed types (+type adds, -type removes)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	types, err := ResolveCommitTypes(*typesFlag, DefaultCommitTypesFile)
+	if err != nil {
+		return err
+	}
+
+	addr := fmt.Sprintf(":%d", *port)
+	log.Printf("Validating commit messages on http://localhost%s/validate", addr)
+	return http.ListenAndServe(addr, NewValidateHandler(types))
+}
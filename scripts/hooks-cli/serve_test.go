@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateHandler(t *testing.T) {
+	server := httptest.NewServer(NewValidateHandler(ValidCommitTypes))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		message    string
+		wantStatus int
+	}{
+		{
+			name:       "valid conventional commit",
+			message:    "feat(api): add validation endpoint",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid commit",
+			message:    "Added a validation endpoint",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "empty body",
+			message:    "",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Post(server.URL+"/validate", "text/plain", strings.NewReader(tt.message))
+			if err != nil {
+				t.Fatalf("POST /validate error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("POST /validate status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/validate")
+		if err != nil {
+			t.Fatalf("GET /validate error = %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("GET /validate status = %d, want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+		}
+	})
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -23,11 +26,38 @@ var ValidCommitTypes = []string{
 	"revert",   // Reverts a previous commit
 }
 
+// commitTypeDescriptions explains each standard type in rejection messages
+var commitTypeDescriptions = map[string]string{
+	"feat":     "A new feature",
+	"fix":      "A bug fix",
+	"docs":     "Documentation only changes",
+	"style":    "Formatting, whitespace (no code change)",
+	"refactor": "Code change (no feature or fix)",
+	"perf":     "Performance improvement",
+	"test":     "Adding or correcting tests",
+	"build":    "Build system or dependencies",
+	"ci":       "CI configuration changes",
+	"chore":    "Other maintenance tasks",
+	"revert":   "Reverts a previous commit",
+}
+
+// DefaultCommitTypesFile is read from the working directory to customize the
+// allowed types when no --types flag is given
+const DefaultCommitTypesFile = ".commit-types"
+
 // conventionalCommitPattern matches: type(scope): description or type: description
-// Type must be lowercase, scope is optional and must be lowercase alphanumeric with hyphens/underscores
-var conventionalCommitPattern = regexp.MustCompile(
-	`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([a-z0-9_-]+\))?: .+`,
-)
+// Type must be lowercase, scope is optional and must be lowercase alphanumeric with hyphens/underscores.
+// A "!" before the colon marks a breaking change.
+var conventionalCommitPattern = commitPattern(ValidCommitTypes)
+
+// commitPattern builds the conventional commit pattern for a set of types
+func commitPattern(types []string) *regexp.Regexp {
+	quoted := make([]string, len(types))
+	for i, t := range types {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	return regexp.MustCompile(`^(` + strings.Join(quoted, "|") + `)(\([a-z0-9_-]+\))?!?: .+`)
+}
 
 // mergeCommitPattern matches merge commits generated by git
 var mergeCommitPattern = regexp.MustCompile(`^Merge `)
@@ -40,6 +70,8 @@ type ValidationError struct {
 	Message     string
 	FirstLine   string
 	Suggestion  string
+	// AllowedTypes lists the accepted types; nil means ValidCommitTypes
+	AllowedTypes []string
 }
 
 func (e *ValidationError) Error() string {
@@ -54,17 +86,17 @@ func (e *ValidationError) Error() string {
 	sb.WriteString("Conventional Commits format required:\n")
 	sb.WriteString("  <type>[optional scope]: <description>\n\n")
 	sb.WriteString("Allowed types:\n")
-	sb.WriteString("  feat     - A new feature\n")
-	sb.WriteString("  fix      - A bug fix\n")
-	sb.WriteString("  docs     - Documentation only changes\n")
-	sb.WriteString("  style    - Formatting, whitespace (no code change)\n")
-	sb.WriteString("  refactor - Code change (no feature or fix)\n")
-	sb.WriteString("  perf     - Performance improvement\n")
-	sb.WriteString("  test     - Adding or correcting tests\n")
-	sb.WriteString("  build    - Build system or dependencies\n")
-	sb.WriteString("  ci       - CI configuration changes\n")
-	sb.WriteString("  chore    - Other maintenance tasks\n")
-	sb.WriteString("  revert   - Reverts a previous commit\n")
+	allowed := e.AllowedTypes
+	if allowed == nil {
+		allowed = ValidCommitTypes
+	}
+	for _, t := range allowed {
+		if desc, ok := commitTypeDescriptions[t]; ok {
+			sb.WriteString(fmt.Sprintf("  %-8s - %s\n", t, desc))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s\n", t))
+		}
+	}
 	sb.WriteString("\n")
 	sb.WriteString("Examples:\n")
 	sb.WriteString("  feat: add transaction export feature\n")
@@ -78,11 +110,18 @@ func (e *ValidationError) Error() string {
 
 // ValidateCommitMessage validates a commit message string against conventional commits format
 func ValidateCommitMessage(message string) error {
+	return ValidateCommitMessageWithTypes(message, ValidCommitTypes)
+}
+
+// ValidateCommitMessageWithTypes validates a commit message, accepting only
+// the given types
+func ValidateCommitMessageWithTypes(message string, types []string) error {
 	message = strings.TrimSpace(message)
 	if message == "" {
 		return &ValidationError{
-			Message:   "Empty commit message",
-			FirstLine: "",
+			Message:      "Empty commit message",
+			FirstLine:    "",
+			AllowedTypes: types,
 		}
 	}
 
@@ -101,10 +140,15 @@ func ValidateCommitMessage(message string) error {
 	}
 
 	// Validate against conventional commit pattern
-	if !conventionalCommitPattern.MatchString(firstLine) {
+	pattern := conventionalCommitPattern
+	if !slices.Equal(types, ValidCommitTypes) {
+		pattern = commitPattern(types)
+	}
+	if len(types) == 0 || !pattern.MatchString(firstLine) {
 		return &ValidationError{
-			Message:   "Invalid conventional commit format",
-			FirstLine: firstLine,
+			Message:      "Invalid conventional commit format",
+			FirstLine:    firstLine,
+			AllowedTypes: types,
 		}
 	}
 
@@ -113,9 +157,25 @@ func ValidateCommitMessage(message string) error {
 
 // ValidateCommitMessageFile validates a commit message from a file (used by git hooks)
 func ValidateCommitMessageFile(filePath string) error {
+	return ValidateCommitMessageFileWithTypes(filePath, ValidCommitTypes)
+}
+
+// ValidateCommitMessageFileWithTypes validates a commit message from a file,
+// accepting only the given types
+func ValidateCommitMessageFileWithTypes(filePath string, types []string) error {
+	message, err := ReadCommitMessageFile(filePath)
+	if err != nil {
+		return err
+	}
+	return ValidateCommitMessageWithTypes(message, types)
+}
+
+// ReadCommitMessageFile reads a commit message file, dropping the comment
+// lines git adds
+func ReadCommitMessageFile(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open commit message file: %w", err)
+		return "", fmt.Errorf("failed to open commit message file: %w", err)
 	}
 	defer file.Close()
 
@@ -130,11 +190,10 @@ func ValidateCommitMessageFile(filePath string) error {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("failed to read commit message file: %w", err)
+		return "", fmt.Errorf("failed to read commit message file: %w", err)
 	}
 
-	message := strings.Join(lines, "\n")
-	return ValidateCommitMessage(message)
+	return strings.Join(lines, "\n"), nil
 }
 
 // IsValidCommitType checks if a type is a valid conventional commit type
@@ -146,3 +205,52 @@ func IsValidCommitType(commitType string) bool {
 	}
 	return false
 }
+
+// ParseCommitTypes applies a list of type entries to the defaults. Plain
+// entries replace the default list, "+type" adds a type and "-type" removes
+// one, so "+wip,-revert" keeps the standard types plus wip, without revert.
+func ParseCommitTypes(entries []string) []string {
+	var base, add, remove []string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "" || strings.HasPrefix(entry, "#"):
+		case strings.HasPrefix(entry, "+"):
+			add = append(add, strings.TrimSpace(entry[1:]))
+		case strings.HasPrefix(entry, "-"):
+			remove = append(remove, strings.TrimSpace(entry[1:]))
+		default:
+			base = append(base, entry)
+		}
+	}
+	if base == nil {
+		base = ValidCommitTypes
+	}
+
+	var types []string
+	for _, t := range append(slices.Clone(base), add...) {
+		if t != "" && !slices.Contains(remove, t) && !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// ResolveCommitTypes returns the effective allowed types. A comma-separated
+// --types value wins; otherwise typesFile is read with one entry per line
+// (blank lines and # comments ignored). Without either the standard types
+// apply.
+func ResolveCommitTypes(typesFlag, typesFile string) ([]string, error) {
+	if typesFlag != "" {
+		return ParseCommitTypes(strings.Split(typesFlag, ",")), nil
+	}
+
+	data, err := os.ReadFile(typesFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ValidCommitTypes, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit types file: %w", err)
+	}
+	return ParseCommitTypes(strings.Split(string(data), "\n")), nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -23,6 +24,11 @@ func TestValidateCommitMessage(t *testing.T) {
 			message: "fix(parser): handle edge case",
 			wantErr: false,
 		},
+		{
+			name:    "breaking change marker",
+			message: "feat(api)!: drop v1 export",
+			wantErr: false,
+		},
 		{
 			name:    "docs without scope",
 			message: "docs: update README",
@@ -281,3 +287,101 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestValidateCommitMessageWithTypes(t *testing.T) {
+	types := ParseCommitTypes([]string{"+wip", "-revert"})
+
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{name: "custom type accepted", message: "wip: half done parser", wantErr: false},
+		{name: "standard type still accepted", message: "feat(api): add endpoint", wantErr: false},
+		{name: "removed standard type rejected", message: "revert: undo parser change", wantErr: true},
+		{name: "unknown type rejected", message: "hack: quick fix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommitMessageWithTypes(tt.message, types)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCommitMessageWithTypes(%q) error = %v, wantErr %v", tt.message, err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("rejection lists allowed types", func(t *testing.T) {
+		err := ValidateCommitMessageWithTypes("revert: undo", types)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		msg := err.Error()
+		if !contains(msg, "  wip\n") {
+			t.Error("Error message should list the custom type")
+		}
+		if contains(msg, "revert   -") {
+			t.Error("Error message should not list the removed type")
+		}
+	})
+}
+
+func TestParseCommitTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []string
+	}{
+		{name: "no entries keeps defaults", entries: nil, want: ValidCommitTypes},
+		{name: "plain entries replace defaults", entries: []string{"feat", "fix", "wip"}, want: []string{"feat", "fix", "wip"}},
+		{name: "modifiers adjust defaults", entries: []string{"+wip", "-revert", "-ci"}, want: []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "chore", "wip"}},
+		{name: "comments and blanks ignored", entries: []string{"# team types", "", " feat ", "fix"}, want: []string{"feat", "fix"}},
+		{name: "duplicates dropped", entries: []string{"feat", "+feat"}, want: []string{"feat"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCommitTypes(tt.entries)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseCommitTypes(%q) = %v, want %v", tt.entries, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveCommitTypes(t *testing.T) {
+	typesFile := filepath.Join(t.TempDir(), ".commit-types")
+	if err := os.WriteFile(typesFile, []byte("# allowed here\nfeat\nfix\nwip\n"), 0644); err != nil {
+		t.Fatalf("Failed to write types file: %v", err)
+	}
+
+	t.Run("file used without flag", func(t *testing.T) {
+		got, err := ResolveCommitTypes("", typesFile)
+		if err != nil {
+			t.Fatalf("ResolveCommitTypes() error = %v", err)
+		}
+		if want := []string{"feat", "fix", "wip"}; !slices.Equal(got, want) {
+			t.Errorf("ResolveCommitTypes() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("flag wins over file", func(t *testing.T) {
+		got, err := ResolveCommitTypes("chore", typesFile)
+		if err != nil {
+			t.Fatalf("ResolveCommitTypes() error = %v", err)
+		}
+		if want := []string{"chore"}; !slices.Equal(got, want) {
+			t.Errorf("ResolveCommitTypes() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("missing file keeps defaults", func(t *testing.T) {
+		got, err := ResolveCommitTypes("", filepath.Join(t.TempDir(), "missing"))
+		if err != nil {
+			t.Fatalf("ResolveCommitTypes() error = %v", err)
+		}
+		if !slices.Equal(got, ValidCommitTypes) {
+			t.Errorf("ResolveCommitTypes() = %v, want defaults", got)
+		}
+	})
+}
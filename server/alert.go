@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Defaults for delivering budget alerts when the flags are unset.
+const (
+	defaultAlertAttempts = 3
+	defaultAlertBackoff  = time.Second
+)
+
+// alertClient posts budget alerts; the timeout keeps a hung webhook from
+// holding a delivery goroutine forever.
+var alertClient = &http.Client{Timeout: 10 * time.Second}
+
+// BudgetAlert is the payload posted to -alert-webhook when an expense takes a
+// category over its monthly budget.
+type BudgetAlert struct {
+	Category   string `json:"category"`
+	Month      string `json:"month"`
+	SpentCents int64  `json:"spent_cents"`
+	LimitCents int64  `json:"limit_cents"`
+}
+
+// alertAttempts returns how many times a budget alert is posted before it is
+// given up on.
+func (app *Application) alertAttempts() int {
+	if app.Config.AlertAttempts < 1 {
+		return defaultAlertAttempts
+	}
+	return app.Config.AlertAttempts
+}
+
+// alertBackoff returns the delay before the first retry; each later retry
+// waits twice as long as the one before.
+func (app *Application) alertBackoff() time.Duration {
+	if app.Config.AlertBackoff <= 0 {
+		return defaultAlertBackoff
+	}
+	return app.Config.AlertBackoff
+}
+
+// sendBudgetAlert delivers alert in the background so a slow or unreachable
+// webhook never delays the request that triggered it.
+func (app *Application) sendBudgetAlert(alert BudgetAlert) {
+	if app.Config.AlertWebhook == "" {
+		return
+	}
+	go func() {
+		if err := app.deliverBudgetAlert(alert); err != nil {
+			log.Printf("Warning: budget alert for %s was not delivered: %v", alert.Category, err)
+		}
+	}()
+}
+
+// deliverBudgetAlert posts alert to the webhook, retrying with exponential
+// backoff until it is accepted or the attempts run out.
+func (app *Application) deliverBudgetAlert(alert BudgetAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	attempts := app.alertAttempts()
+	delay := app.alertBackoff()
+	for attempt := 1; ; attempt++ {
+		err = postAlert(app.Config.AlertWebhook, body)
+		if err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			return fmt.Errorf("after %d attempts: %w", attempts, err)
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// postAlert makes a single delivery attempt; any non-2xx status is a failure.
+func postAlert(url string, body []byte) error {
+	resp, err := alertClient.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeliverBudgetAlert_Retries(t *testing.T) {
+	var calls atomic.Int32
+	received := make(chan BudgetAlert, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		var alert BudgetAlert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Errorf("Failed to decode alert: %v", err)
+		}
+		received <- alert
+	}))
+	defer srv.Close()
+
+	app := &Application{Config: Config{
+		AlertWebhook:  srv.URL,
+		AlertAttempts: 3,
+		AlertBackoff:  time.Millisecond,
+	}}
+	want := BudgetAlert{Category: "Food", Month: "2024-05", SpentCents: 52000, LimitCents: 50000}
+
+	if err := app.deliverBudgetAlert(want); err != nil {
+		t.Fatalf("deliverBudgetAlert() error = %v", err)
+	}
+	if got := <-received; got != want {
+		t.Errorf("webhook received %+v, want %+v", got, want)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("webhook called %d times, want 3", calls.Load())
+	}
+}
+
+func TestDeliverBudgetAlert_GivesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "down for maintenance", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	app := &Application{Config: Config{
+		AlertWebhook:  srv.URL,
+		AlertAttempts: 2,
+		AlertBackoff:  time.Millisecond,
+	}}
+
+	if err := app.deliverBudgetAlert(BudgetAlert{Category: "Food"}); err == nil {
+		t.Error("deliverBudgetAlert() succeeded, want an error after the last attempt")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("webhook called %d times, want 2", calls.Load())
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+// recordAudit appends an entry to the audit log. Failures are logged rather
+// than returned so auditing never blocks the change being audited.
+func (app *Application) recordAudit(ctx context.Context, action string, entityID int64, details string) {
+	err := app.Q.CreateAuditEntry(ctx, db.CreateAuditEntryParams{
+		Action:   action,
+		EntityID: entityID,
+		Details:  details,
+	})
+	if err != nil {
+		log.Printf("Audit log write failed (%s %d): %v", action, entityID, err)
+	}
+}
+
+// startAuditCleanupLoop prunes audit entries older than the retention window
+// once at startup and then daily.
+func (app *Application) startAuditCleanupLoop(ctx context.Context) {
+	log.Printf("Audit retention enabled: %d days", app.Config.AuditRetentionDays)
+
+	app.cleanupAuditLog(ctx, time.Now())
+
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Audit cleanup loop stopping")
+			return
+		case <-ticker.C:
+			app.cleanupAuditLog(ctx, time.Now())
+		}
+	}
+}
+
+// cleanupAuditLog deletes audit entries older than the retention window
+// measured back from now, returning how many were removed.
+func (app *Application) cleanupAuditLog(ctx context.Context, now time.Time) int64 {
+	cutoff := now.UTC().AddDate(0, 0, -app.Config.AuditRetentionDays)
+	removed, err := app.Q.DeleteAuditEntriesBefore(ctx, sql.NullTime{Time: cutoff, Valid: true})
+	if err != nil {
+		log.Printf("Audit cleanup failed: %v", err)
+		return 0
+	}
+	if removed > 0 {
+		log.Printf("Audit cleanup removed %d entries older than %s", removed, cutoff.Format(time.RFC3339))
+	}
+	return removed
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// insertAuditEntry writes an audit entry with an explicit timestamp.
+func insertAuditEntry(t *testing.T, app *Application, action string, entityID int64, createdAt time.Time) {
+	t.Helper()
+
+	_, err := app.DB.Exec(
+		"INSERT INTO audit_log (action, entity_id, created_at) VALUES (?, ?, ?)",
+		action, entityID, createdAt.UTC().Format("2006-01-02 15:04:05"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to insert audit entry: %v", err)
+	}
+}
+
+func TestCleanupAuditLog(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.AuditRetentionDays = 90
+
+	now := time.Now()
+	insertAuditEntry(t, app, "create", 1, now.AddDate(0, 0, -200))
+	insertAuditEntry(t, app, "remove", 1, now.AddDate(0, 0, -91))
+	insertAuditEntry(t, app, "create", 2, now.AddDate(0, 0, -89))
+	insertAuditEntry(t, app, "create", 3, now)
+
+	removed := app.cleanupAuditLog(context.Background(), now)
+	if removed != 2 {
+		t.Errorf("cleanupAuditLog() removed %d entries, want 2", removed)
+	}
+
+	rows, err := app.DB.Query("SELECT entity_id FROM audit_log ORDER BY id")
+	if err != nil {
+		t.Fatalf("Failed to query audit log: %v", err)
+	}
+	defer rows.Close()
+	var remaining []int64
+	for rows.Next() {
+		var id int64
+		rows.Scan(&id)
+		remaining = append(remaining, id)
+	}
+	if len(remaining) != 2 || remaining[0] != 2 || remaining[1] != 3 {
+		t.Errorf("Remaining entity IDs = %v, want [2 3]", remaining)
+	}
+}
+
+func TestHandleAuditLog(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	for i := int64(1); i <= 5; i++ {
+		insertAuditEntry(t, app, "create", i, time.Now())
+	}
+
+	fetch := func(query url.Values) AuditPageResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/audit?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		app.HandleAuditLog(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleAuditLog() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var resp AuditPageResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("pages newest first", func(t *testing.T) {
+		first := fetch(url.Values{"limit": {"3"}})
+		if len(first.Entries) != 3 || first.Entries[0].EntityID != 5 || first.Entries[2].EntityID != 3 {
+			t.Fatalf("First page = %+v, want entities 5..3", first.Entries)
+		}
+		if first.NextBefore == 0 {
+			t.Fatal("First page should include a next_before cursor")
+		}
+
+		second := fetch(url.Values{"limit": {"3"}, "before": {strconv.FormatInt(first.NextBefore, 10)}})
+		if len(second.Entries) != 2 || second.Entries[0].EntityID != 2 || second.Entries[1].EntityID != 1 {
+			t.Errorf("Second page = %+v, want entities 2..1", second.Entries)
+		}
+		if second.NextBefore != 0 {
+			t.Errorf("Last page next_before = %d, want 0", second.NextBefore)
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/audit?limit=abc", nil)
+		rec := httptest.NewRecorder()
+		app.HandleAuditLog(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("HandleAuditLog() status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestHandleTransactionCreate_RecordsAudit(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	postTransaction(app, url.Values{"input": {"12 pizza"}})
+
+	var action string
+	if err := app.DB.QueryRow("SELECT action FROM audit_log").Scan(&action); err != nil {
+		t.Fatalf("Expected an audit entry: %v", err)
+	}
+	if action != "create" {
+		t.Errorf("Audit action = %q, want %q", action, "create")
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +20,18 @@ var (
 	lastBackupTime time.Time
 )
 
+// backupManifestName is the file written next to each backup describing it.
+const backupManifestName = "manifest.json"
+
+// BackupManifest records what a backup contains so it can be inspected
+// without opening the database.
+type BackupManifest struct {
+	CreatedAt        string `json:"created_at"`
+	TransactionCount int64  `json:"transaction_count"`
+	CategoryCount    int64  `json:"category_count"`
+	AppVersion       string `json:"app_version"`
+}
+
 // getLastBackupTime returns the time of the last successful backup.
 func getLastBackupTime() time.Time {
 	lastBackupMu.RLock()
@@ -31,9 +45,22 @@ func setLastBackupTime(t time.Time) {
 	lastBackupTime = t
 }
 
+// minBackupInterval is the shortest interval, in minutes, allowed between
+// automatic backups, so a tiny -backup-interval cannot hammer the disk.
+const minBackupInterval = 5
+
+// backupInterval returns the interval between automatic backups, raised to
+// minBackupInterval when the configured value is smaller or negative.
+func (app *Application) backupInterval() time.Duration {
+	return time.Duration(max(app.Config.BackupInterval, minBackupInterval)) * time.Minute
+}
+
 // startBackupLoop runs periodic backups at the configured interval.
 func (app *Application) startBackupLoop(ctx context.Context) {
-	interval := time.Duration(app.Config.BackupInterval) * time.Minute
+	if app.Config.BackupInterval < minBackupInterval {
+		log.Printf("Warning: backup interval %d minutes is below the minimum, using %d minutes", app.Config.BackupInterval, minBackupInterval)
+	}
+	interval := app.backupInterval()
 	log.Printf("Backup enabled: path=%s interval=%s", app.Config.BackupPath, interval)
 
 	// Run once immediately on startup
@@ -67,8 +94,33 @@ func (app *Application) runBackup() {
 	}
 }
 
+// validateBackupPath reports an error if path does not resolve to base or a
+// directory beneath it. An empty base leaves the path unrestricted.
+func validateBackupPath(base, path string) error {
+	if base == "" {
+		return nil
+	}
+	absBase, err := filepath.Abs(base)
+	if err != nil {
+		return err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(absBase, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("backup path %q is outside backup base %q", path, base)
+	}
+	return nil
+}
+
 // performBackup creates a consistent SQLite backup using the backup API.
 func (app *Application) performBackup() error {
+	if err := validateBackupPath(app.Config.BackupBase, app.Config.BackupPath); err != nil {
+		return err
+	}
+
 	destPath := filepath.Join(app.Config.BackupPath, "cheapskate.db")
 
 	// Ensure backup directory exists
@@ -76,17 +128,119 @@ func (app *Application) performBackup() error {
 		return err
 	}
 
-	return sqliteBackup(app.DB, destPath)
+	// Count the source in the snapshot the copy is taken from, so writes
+	// landing after the copy can't fail a good backup
+	var sourceCount int64
+	countSource := func(conn *sql.Conn) error {
+		if err := conn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM transactions").Scan(&sourceCount); err != nil {
+			return fmt.Errorf("count source transactions: %w", err)
+		}
+		return nil
+	}
+	if err := sqliteBackup(app.DB, destPath, countSource); err != nil {
+		return err
+	}
+
+	// Never leave an unverified backup behind: a bad file is worse than none
+	if err := verifyBackup(destPath, sourceCount); err != nil {
+		os.Remove(destPath)
+		return fmt.Errorf("backup verification failed: %w", err)
+	}
+
+	if err := writeBackupManifest(destPath, app.Config.BackupPath); err != nil {
+		return fmt.Errorf("write backup manifest: %w", err)
+	}
+	return nil
+}
+
+// writeBackupManifest counts the rows in the backup at backupPath and writes
+// the manifest into dir.
+func writeBackupManifest(backupPath, dir string) error {
+	backupDB, err := sql.Open("sqlite3", backupPath+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer backupDB.Close()
+
+	manifest := BackupManifest{
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		AppVersion: Version,
+	}
+	if err := backupDB.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&manifest.TransactionCount); err != nil {
+		return err
+	}
+	if err := backupDB.QueryRow("SELECT COUNT(*) FROM categories").Scan(&manifest.CategoryCount); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, backupManifestName), data, 0644)
+}
+
+// readBackupManifest loads the manifest of the latest backup in dir.
+func readBackupManifest(dir string) (BackupManifest, error) {
+	var manifest BackupManifest
+	data, err := os.ReadFile(filepath.Join(dir, backupManifestName))
+	if err != nil {
+		return manifest, err
+	}
+	err = json.Unmarshal(data, &manifest)
+	return manifest, err
+}
+
+// verifyBackup opens the backup read-only, runs an integrity check and
+// compares its transaction count against sourceCount, the count of the
+// snapshot it was copied from.
+func verifyBackup(destPath string, sourceCount int64) error {
+	backupDB, err := sql.Open("sqlite3", destPath+"?mode=ro")
+	if err != nil {
+		return err
+	}
+	defer backupDB.Close()
+
+	var integrity string
+	if err := backupDB.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+		return fmt.Errorf("integrity check: %w", err)
+	}
+	if integrity != "ok" {
+		return fmt.Errorf("integrity check: %s", integrity)
+	}
+
+	var backupCount int64
+	if err := backupDB.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&backupCount); err != nil {
+		return fmt.Errorf("count backup transactions: %w", err)
+	}
+	if backupCount != sourceCount {
+		return fmt.Errorf("transaction count mismatch: backup has %d, source has %d", backupCount, sourceCount)
+	}
+	return nil
 }
 
 // sqliteBackup copies a live SQLite database to destPath using the backup API.
-func sqliteBackup(srcDB *sql.DB, destPath string) error {
-	srcConn, err := srcDB.Conn(context.Background())
+// A non-nil inSnapshot runs on the source connection inside the read
+// transaction the copy is then taken from, so what it reads matches the
+// backup exactly.
+func sqliteBackup(srcDB *sql.DB, destPath string, inSnapshot func(*sql.Conn) error) error {
+	ctx := context.Background()
+	srcConn, err := srcDB.Conn(ctx)
 	if err != nil {
 		return err
 	}
 	defer srcConn.Close()
 
+	if inSnapshot != nil {
+		if _, err := srcConn.ExecContext(ctx, "BEGIN"); err != nil {
+			return err
+		}
+		defer srcConn.ExecContext(ctx, "ROLLBACK")
+		if err := inSnapshot(srcConn); err != nil {
+			return err
+		}
+	}
+
 	return srcConn.Raw(func(driverConn interface{}) error {
 		src := driverConn.(*sqlite3.SQLiteConn)
 
@@ -157,14 +311,38 @@ func sqliteRestore(destDB *sql.DB, srcPath string) error {
 	})
 }
 
+// jsonExportName is the JSON export written next to each DB backup.
+const jsonExportName = "cheapskate.json"
+
 // performJSONExport writes a human-readable JSON export alongside the DB backup.
 func (app *Application) performJSONExport() error {
-	ctx := context.Background()
+	if err := validateBackupPath(app.Config.BackupBase, app.Config.BackupPath); err != nil {
+		return err
+	}
 
-	txRows, err := app.Q.ListAllTransactionsForExport(ctx)
+	resp, err := app.buildJSONExport(context.Background())
+	if err != nil {
+		return err
+	}
+
+	destPath := filepath.Join(app.Config.BackupPath, jsonExportName)
+	f, err := os.Create(destPath)
 	if err != nil {
 		return err
 	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(resp)
+}
+
+// buildJSONExport collects every transaction and category for a JSON export.
+func (app *Application) buildJSONExport(ctx context.Context) (StorageExportResponse, error) {
+	txRows, err := app.Q.ListAllTransactionsForExport(ctx)
+	if err != nil {
+		return StorageExportResponse{}, err
+	}
 
 	transactions := make([]StorageTransaction, 0, len(txRows))
 	for _, tx := range txRows {
@@ -176,12 +354,14 @@ func (app *Application) performJSONExport() error {
 			Date:         tx.Date.UTC().Format(time.RFC3339),
 			CategoryName: tx.CategoryName,
 			CategoryType: tx.CategoryType,
+			ReceiptPath:  tx.ReceiptPath.String,
+			UID:          tx.Uid.String,
 		})
 	}
 
 	catRows, err := app.Q.ListCategories(ctx)
 	if err != nil {
-		return err
+		return StorageExportResponse{}, err
 	}
 
 	categories := make([]StorageCategory, 0, len(catRows))
@@ -203,21 +383,10 @@ func (app *Application) performJSONExport() error {
 		})
 	}
 
-	resp := StorageExportResponse{
+	return StorageExportResponse{
 		Transactions: transactions,
 		Categories:   categories,
 		Year:         "all",
 		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
-	}
-
-	destPath := filepath.Join(app.Config.BackupPath, "cheapskate.json")
-	f, err := os.Create(destPath)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	enc := json.NewEncoder(f)
-	enc.SetIndent("", "  ")
-	return enc.Encode(resp)
+	}, nil
 }
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -39,7 +40,7 @@ func setupTestAppWithFile(t *testing.T, dbPath string) *Application {
 		CREATE TABLE IF NOT EXISTS categories (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT NOT NULL,
-			type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+			type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer')),
 			icon TEXT,
 			color TEXT
 		);
@@ -54,6 +55,8 @@ func setupTestAppWithFile(t *testing.T, dbPath string) *Application {
 			date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			deleted_at DATETIME DEFAULT NULL,
+			receipt_path TEXT DEFAULT NULL,
+			uid TEXT DEFAULT NULL,
 			FOREIGN KEY (user_id) REFERENCES users(id),
 			FOREIGN KEY (category_id) REFERENCES categories(id)
 		);
@@ -243,6 +246,87 @@ func TestHandleBackupDownload(t *testing.T) {
 	}
 }
 
+func TestHandleBackupJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := setupTestAppWithFile(t, filepath.Join(tmpDir, "source.db"))
+	defer app.DB.Close()
+
+	lunch, err := app.Q.CreateTransaction(context.Background(), db.CreateTransactionParams{
+		UserID:      1,
+		CategoryID:  1,
+		Amount:      -750,
+		Currency:    "USD",
+		Description: "lunch",
+		Date:        time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test transaction: %v", err)
+	}
+	if err := app.Q.SetTransactionReceipt(context.Background(), db.SetTransactionReceiptParams{
+		ReceiptPath: sql.NullString{String: "lunch.png", Valid: true},
+		ID:          lunch.ID,
+		UserID:      1,
+	}); err != nil {
+		t.Fatalf("Failed to set receipt: %v", err)
+	}
+
+	download := func(t *testing.T) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/backup/json", nil)
+		rec := httptest.NewRecorder()
+		app.HandleBackupJSON(rec, req)
+		return rec
+	}
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) StorageExportResponse {
+		t.Helper()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleBackupJSON() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if !strings.Contains(rec.Header().Get("Content-Disposition"), "attachment") {
+			t.Errorf("Content-Disposition = %q, want an attachment", rec.Header().Get("Content-Disposition"))
+		}
+		var export StorageExportResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &export); err != nil {
+			t.Fatalf("Response is not valid JSON: %v", err)
+		}
+		return export
+	}
+
+	t.Run("generated when backups are disabled", func(t *testing.T) {
+		export := decode(t, download(t))
+		if len(export.Transactions) != 1 || export.Transactions[0].Description != "lunch" {
+			t.Fatalf("Transactions = %+v, want the lunch transaction", export.Transactions)
+		}
+		if export.Transactions[0].ReceiptPath != "lunch.png" {
+			t.Errorf("ReceiptPath = %q, want %q", export.Transactions[0].ReceiptPath, "lunch.png")
+		}
+	})
+
+	app.Config.BackupPath = filepath.Join(tmpDir, "backups")
+	if err := os.MkdirAll(app.Config.BackupPath, 0755); err != nil {
+		t.Fatalf("Failed to create backup dir: %v", err)
+	}
+
+	t.Run("not found before the first backup", func(t *testing.T) {
+		if rec := download(t); rec.Code != http.StatusNotFound {
+			t.Errorf("HandleBackupJSON() status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("serves the backup export", func(t *testing.T) {
+		if err := app.performJSONExport(); err != nil {
+			t.Fatalf("performJSONExport failed: %v", err)
+		}
+		export := decode(t, download(t))
+		if len(export.Transactions) != 1 || export.Transactions[0].Description != "lunch" {
+			t.Fatalf("Transactions = %+v, want the lunch transaction", export.Transactions)
+		}
+		if export.Transactions[0].ReceiptPath != "lunch.png" {
+			t.Errorf("ReceiptPath = %q, want %q", export.Transactions[0].ReceiptPath, "lunch.png")
+		}
+	})
+}
+
 func TestHandleBackupRestore(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -254,7 +338,7 @@ func TestHandleBackupRestore(t *testing.T) {
 	}
 	_, err = srcDB.Exec(`
 		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, email TEXT NOT NULL UNIQUE, created_at DATETIME DEFAULT CURRENT_TIMESTAMP);
-		CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT NOT NULL, type TEXT NOT NULL CHECK(type IN ('income', 'expense')), icon TEXT, color TEXT);
+		CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT NOT NULL, type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer')), icon TEXT, color TEXT);
 		CREATE TABLE transactions (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL, category_id INTEGER NOT NULL, amount INTEGER NOT NULL, currency TEXT NOT NULL DEFAULT 'USD', description TEXT NOT NULL, date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, created_at DATETIME DEFAULT CURRENT_TIMESTAMP, deleted_at DATETIME DEFAULT NULL);
 		INSERT INTO users (name, email) VALUES ('RestoredUser', 'restored@example.com');
 		INSERT INTO categories (name, type) VALUES ('Restored Cat', 'expense');
@@ -382,6 +466,42 @@ func TestHandleBackupStatus(t *testing.T) {
 			t.Error("Expected LastBackupAt to be set")
 		}
 	})
+
+	t.Run("next backup follows last backup by the interval", func(t *testing.T) {
+		app.Config.BackupPath = "/some/path"
+		app.Config.BackupInterval = 30
+		last := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		setLastBackupTime(last)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/backup/status", nil)
+		rec := httptest.NewRecorder()
+
+		app.HandleBackupStatus(rec, req)
+
+		var status BackupStatusResponse
+		json.NewDecoder(rec.Body).Decode(&status)
+
+		if status.IntervalMinutes != 30 {
+			t.Errorf("Expected interval_minutes 30, got %d", status.IntervalMinutes)
+		}
+		if status.Schedule == "" {
+			t.Error("Expected schedule to be set")
+		}
+		if status.NextBackupAt == "" {
+			t.Fatal("Expected NextBackupAt to be set")
+		}
+		lastAt, _ := time.Parse(time.RFC3339, status.LastBackupAt)
+		nextAt, err := time.Parse(time.RFC3339, status.NextBackupAt)
+		if err != nil {
+			t.Fatalf("NextBackupAt is not RFC3339: %v", err)
+		}
+		if !nextAt.After(lastAt) {
+			t.Errorf("Expected next backup %s after last backup %s", nextAt, lastAt)
+		}
+		if want := last.Add(30 * time.Minute); !nextAt.Equal(want) {
+			t.Errorf("Expected next backup at %s, got %s", want, nextAt)
+		}
+	})
 }
 
 func TestLastBackupTime(t *testing.T) {
@@ -402,6 +522,26 @@ func TestLastBackupTime(t *testing.T) {
 	}
 }
 
+func TestBackupIntervalMinimum(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       time.Duration
+	}{
+		{configured: 30, want: 30 * time.Minute},
+		{configured: minBackupInterval, want: minBackupInterval * time.Minute},
+		{configured: 1, want: minBackupInterval * time.Minute},
+		{configured: 0, want: minBackupInterval * time.Minute},
+		{configured: -10, want: minBackupInterval * time.Minute},
+	}
+
+	for _, tt := range tests {
+		app := &Application{Config: Config{BackupInterval: tt.configured}}
+		if got := app.backupInterval(); got != tt.want {
+			t.Errorf("backupInterval() with %d minutes = %v, want %v", tt.configured, got, tt.want)
+		}
+	}
+}
+
 func TestRunBackupDoesNotUpdateTimeOnFailure(t *testing.T) {
 	tmpDir := t.TempDir()
 	srcPath := filepath.Join(tmpDir, "source.db")
@@ -422,6 +562,70 @@ func TestRunBackupDoesNotUpdateTimeOnFailure(t *testing.T) {
 	}
 }
 
+func TestPerformBackupWritesManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := setupTestAppWithFile(t, filepath.Join(tmpDir, "source.db"))
+	defer app.DB.Close()
+
+	for _, desc := range []string{"lunch", "bus", "rent"} {
+		createTestTransaction(t, app, 1, -500, desc, time.Now())
+	}
+
+	app.Config.BackupPath = filepath.Join(tmpDir, "backups")
+	if err := app.performBackup(); err != nil {
+		t.Fatalf("performBackup failed: %v", err)
+	}
+
+	manifest, err := readBackupManifest(app.Config.BackupPath)
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+	if manifest.TransactionCount != 3 {
+		t.Errorf("TransactionCount = %d, want 3", manifest.TransactionCount)
+	}
+	var categories int64
+	if err := app.DB.QueryRow("SELECT COUNT(*) FROM categories").Scan(&categories); err != nil {
+		t.Fatalf("Failed to count categories: %v", err)
+	}
+	if manifest.CategoryCount != categories {
+		t.Errorf("CategoryCount = %d, want %d", manifest.CategoryCount, categories)
+	}
+	if manifest.AppVersion != Version {
+		t.Errorf("AppVersion = %q, want %q", manifest.AppVersion, Version)
+	}
+	if _, err := time.Parse(time.RFC3339, manifest.CreatedAt); err != nil {
+		t.Errorf("CreatedAt %q is not RFC3339: %v", manifest.CreatedAt, err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/backup/manifest", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBackupManifest(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBackupManifest() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var served BackupManifest
+	if err := json.NewDecoder(rec.Body).Decode(&served); err != nil {
+		t.Fatalf("Failed to decode manifest: %v", err)
+	}
+	if served != manifest {
+		t.Errorf("Served manifest = %+v, want %+v", served, manifest)
+	}
+}
+
+func TestHandleBackupManifest_NoBackup(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.BackupPath = t.TempDir()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/backup/manifest", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBackupManifest(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("HandleBackupManifest() status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
 func TestRunBackupUpdatesTimeOnSuccess(t *testing.T) {
 	tmpDir := t.TempDir()
 	srcPath := filepath.Join(tmpDir, "source.db")
@@ -446,3 +650,146 @@ func TestRunBackupUpdatesTimeOnSuccess(t *testing.T) {
 		t.Errorf("lastBackupTime %v not in expected range [%v, %v]", got, before, after)
 	}
 }
+
+func TestVerifyBackupRejectsCorruptFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcPath := filepath.Join(tmpDir, "source.db")
+	app := setupTestAppWithFile(t, srcPath)
+	defer app.DB.Close()
+
+	corruptPath := filepath.Join(tmpDir, "corrupt.db")
+	if err := os.WriteFile(corruptPath, []byte("definitely not a sqlite database"), 0644); err != nil {
+		t.Fatalf("Failed to write corrupt file: %v", err)
+	}
+
+	if err := verifyBackup(corruptPath, 0); err == nil {
+		t.Error("Expected verifyBackup to reject a corrupt file")
+	}
+}
+
+func TestPerformBackupCountsTheCopiedSnapshot(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := setupTestAppWithFile(t, filepath.Join(tmpDir, "source.db"))
+	defer app.DB.Close()
+	createTestTransaction(t, app, 1, -500, "before", time.Now())
+
+	destPath := filepath.Join(tmpDir, "backup.db")
+	var sourceCount int64
+	err := sqliteBackup(app.DB, destPath, func(conn *sql.Conn) error {
+		return conn.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM transactions").Scan(&sourceCount)
+	})
+	if err != nil {
+		t.Fatalf("sqliteBackup() error = %v", err)
+	}
+
+	// A write after the copy must not fail verification of the copy
+	createTestTransaction(t, app, 1, -700, "after", time.Now())
+
+	if sourceCount != 1 {
+		t.Errorf("sourceCount = %d, want 1", sourceCount)
+	}
+	if err := verifyBackup(destPath, sourceCount); err != nil {
+		t.Errorf("verifyBackup() error = %v", err)
+	}
+}
+
+func TestRunBackupDoesNotUpdateTimeOnVerificationFailure(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A database without a transactions table cannot be counted or
+	// verified, which exercises the failure path end to end.
+	dbConn, err := sql.Open("sqlite3", filepath.Join(tmpDir, "source.db"))
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	defer dbConn.Close()
+	if _, err := dbConn.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+
+	app := &Application{
+		Config: Config{BackupPath: filepath.Join(tmpDir, "backups")},
+		DB:     dbConn,
+		Q:      db.New(dbConn),
+	}
+
+	setLastBackupTime(time.Time{})
+	app.runBackup()
+
+	if !getLastBackupTime().IsZero() {
+		t.Error("Expected lastBackupTime to remain zero after failed verification")
+	}
+	if _, err := os.Stat(filepath.Join(app.Config.BackupPath, "cheapskate.db")); !os.IsNotExist(err) {
+		t.Error("Expected unverified backup file to be removed")
+	}
+}
+
+func TestValidateBackupPath(t *testing.T) {
+	base := t.TempDir()
+
+	tests := []struct {
+		name    string
+		base    string
+		path    string
+		wantErr bool
+	}{
+		{name: "no base allows anything", base: "", path: "/anywhere/backups"},
+		{name: "base itself", base: base, path: base},
+		{name: "subdirectory", base: base, path: filepath.Join(base, "nightly")},
+		{name: "traversal out of base", base: base, path: filepath.Join(base, "..", "elsewhere"), wantErr: true},
+		{name: "nested traversal out of base", base: base, path: base + "/nightly/../../elsewhere", wantErr: true},
+		{name: "sibling with shared prefix", base: base, path: base + "-evil", wantErr: true},
+		{name: "unrelated absolute path", base: base, path: "/etc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackupPath(tt.base, tt.path)
+			if tt.wantErr && err == nil {
+				t.Errorf("validateBackupPath(%q, %q) expected error, got nil", tt.base, tt.path)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateBackupPath(%q, %q) unexpected error: %v", tt.base, tt.path, err)
+			}
+		})
+	}
+}
+
+func TestPerformBackupRejectsPathOutsideBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := setupTestAppWithFile(t, filepath.Join(tmpDir, "source.db"))
+	defer app.DB.Close()
+
+	base := filepath.Join(tmpDir, "allowed")
+	app.Config.BackupBase = base
+	app.Config.BackupPath = filepath.Join(base, "..", "escaped")
+
+	if err := app.performBackup(); err == nil {
+		t.Fatal("Expected performBackup to reject a path outside the backup base")
+	}
+	if _, err := os.Stat(app.Config.BackupPath); !os.IsNotExist(err) {
+		t.Error("Expected no backup directory to be created outside the base")
+	}
+
+	app.Config.BackupPath = filepath.Join(base, "nightly")
+	if err := app.performBackup(); err != nil {
+		t.Fatalf("performBackup inside base failed: %v", err)
+	}
+}
+
+func TestHandleBackupDownloadUsesTempDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := setupTestAppWithFile(t, filepath.Join(tmpDir, "source.db"))
+	defer app.DB.Close()
+
+	// A missing temp dir makes CreateTemp fail, proving the setting is honored
+	app.Config.TempDir = filepath.Join(tmpDir, "missing")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/backup/download", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBackupDownload(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 with a missing temp dir, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+// BudgetCheck describes how a pending expense relates to its category's
+// monthly budget. Soft limits only warn; hard limits block the entry.
+type BudgetCheck struct {
+	Budgeted  bool
+	Spent     int64 // Cents already spent this month, before the new expense
+	Limit     int64 // Soft limit in cents
+	HardLimit int64 // Hard limit in cents, zero when the budget has none
+	HasHard   bool
+	OverSoft  bool
+	OverHard  bool
+}
+
+// checkBudget reports whether adding amount (positive cents) to the category
+// in the month of date would exceed its soft or hard limit.
+func (app *Application) checkBudget(ctx context.Context, categoryID int64, amount int64, date time.Time) (BudgetCheck, error) {
+	budget, err := app.Q.GetBudgetByCategory(ctx, categoryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return BudgetCheck{}, nil
+	}
+	if err != nil {
+		return BudgetCheck{}, err
+	}
+
+	spent, err := app.Q.GetCategorySpendForMonth(ctx, db.GetCategorySpendForMonthParams{
+		CategoryID: categoryID,
+		Month:      date.UTC().Format("2006-01"),
+	})
+	if err != nil {
+		return BudgetCheck{}, err
+	}
+
+	check := BudgetCheck{
+		Budgeted: true,
+		Spent:    spent,
+		Limit:    budget.LimitCents,
+		HasHard:  budget.HardLimitCents.Valid,
+	}
+	projected := spent + amount
+	check.OverSoft = projected > check.Limit
+	if check.HasHard {
+		check.HardLimit = budget.HardLimitCents.Int64
+		check.OverHard = projected > check.HardLimit
+	}
+	return check, nil
+}
+
+// BudgetRequest sets a category's monthly budget. HardLimit is optional: a
+// budget without one only warns, a budget with one also blocks entries.
+type BudgetRequest struct {
+	Category  string `json:"category"`
+	Limit     int64  `json:"limit_cents"`
+	HardLimit *int64 `json:"hard_limit_cents,omitempty"`
+}
+
+// HandleSetBudget creates or replaces an expense category's budget.
+func (app *Application) HandleSetBudget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Limit <= 0 {
+		http.Error(w, "limit_cents must be greater than zero", http.StatusBadRequest)
+		return
+	}
+	hard := sql.NullInt64{}
+	if req.HardLimit != nil {
+		if *req.HardLimit < req.Limit {
+			http.Error(w, "hard_limit_cents must not be below limit_cents", http.StatusBadRequest)
+			return
+		}
+		hard = sql.NullInt64{Int64: *req.HardLimit, Valid: true}
+	}
+
+	cat, err := app.Q.GetCategoryByName(ctx, req.Category)
+	if errors.Is(err, sql.ErrNoRows) {
+		http.Error(w, fmt.Sprintf("Unknown category %q", req.Category), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load category", http.StatusInternalServerError)
+		return
+	}
+	if cat.Type != "expense" {
+		http.Error(w, "Only expense categories can have a budget", http.StatusBadRequest)
+		return
+	}
+
+	budget, err := app.Q.SetBudget(ctx, db.SetBudgetParams{
+		CategoryID:     cat.ID,
+		LimitCents:     req.Limit,
+		HardLimitCents: hard,
+	})
+	if err != nil {
+		http.Error(w, "Failed to save budget", http.StatusInternalServerError)
+		return
+	}
+	details := fmt.Sprintf("%s limit %d", cat.Name, budget.LimitCents)
+	if budget.HardLimitCents.Valid {
+		details += fmt.Sprintf(" hard %d", budget.HardLimitCents.Int64)
+	}
+	app.recordAudit(ctx, "budget", 0, details)
+
+	resp := BudgetRequest{Category: cat.Name, Limit: budget.LimitCents}
+	if budget.HardLimitCents.Valid {
+		resp.HardLimit = &budget.HardLimitCents.Int64
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// BudgetStatus is a budgeted category's progress through one month. Pct is
+// the share of the limit spent, to one decimal; Remaining goes negative once
+// the budget is exceeded.
+type BudgetStatus struct {
+	Category  string  `json:"category"`
+	Limit     int64   `json:"limit"`
+	Spent     int64   `json:"spent"`
+	Remaining int64   `json:"remaining"`
+	Pct       float64 `json:"pct"`
+	Over      bool    `json:"over"`
+}
+
+// HandleBudgetStatus returns each budgeted category's spending against its
+// soft limit for ?year=&month= (the current month by default). Categories
+// without a budget are omitted.
+func (app *Application) HandleBudgetStatus(w http.ResponseWriter, r *http.Request) {
+	year, month, err := yearMonthParam(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.ListBudgetStatusByMonth(r.Context(), fmt.Sprintf("%04d-%02d", year, month))
+	if err != nil {
+		http.Error(w, "Failed to load budgets", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]BudgetStatus, 0, len(rows))
+	for _, row := range rows {
+		status := BudgetStatus{
+			Category:  row.CategoryName,
+			Limit:     row.LimitCents,
+			Spent:     row.Spent,
+			Remaining: row.LimitCents - row.Spent,
+			Over:      row.Spent > row.LimitCents,
+		}
+		if row.LimitCents > 0 {
+			status.Pct = math.Round(float64(row.Spent)/float64(row.LimitCents)*1000) / 10
+		}
+		resp = append(resp, status)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// BudgetProjection is a budgeted category's spending extrapolated to the end
+// of the month. WillExceed warns while Spent is still within the limit.
+type BudgetProjection struct {
+	Category   string `json:"category"`
+	Limit      int64  `json:"limit"`
+	Spent      int64  `json:"spent"`
+	Projected  int64  `json:"projected"`
+	WillExceed bool   `json:"will_exceed"`
+}
+
+// HandleBudgetProjection projects each budgeted category's month-end spend
+// for ?year=&month= (the current month by default) from its pace so far.
+func (app *Application) HandleBudgetProjection(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	year, month, err := yearMonthParam(r, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.ListBudgetStatusByMonth(r.Context(), fmt.Sprintf("%04d-%02d", year, month))
+	if err != nil {
+		http.Error(w, "Failed to load budgets", http.StatusInternalServerError)
+		return
+	}
+
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, now.Location())
+	elapsed, daysInMonth := monthProgress(now, first)
+
+	resp := make([]BudgetProjection, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, projectBudget(row.CategoryName, row.LimitCents, row.Spent, elapsed, daysInMonth))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// projectBudget extrapolates spent at its daily rate so far to the whole
+// month, the same pace computeProjectedSavings assumes. A finished month
+// projects its actual spend and a month not yet started projects nothing.
+func projectBudget(category string, limit, spent int64, elapsedDays, daysInMonth int) BudgetProjection {
+	p := BudgetProjection{Category: category, Limit: limit, Spent: spent}
+	switch {
+	case elapsedDays >= daysInMonth:
+		p.Projected = spent
+	case elapsedDays > 0:
+		p.Projected = int64(math.Round(float64(spent) * float64(daysInMonth) / float64(elapsedDays)))
+	}
+	p.WillExceed = p.Projected > limit
+	return p
+}
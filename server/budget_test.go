@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// putBudget calls PUT /api/budgets with a JSON body.
+func putBudget(app *Application, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/budgets", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.HandleSetBudget(rec, req)
+	return rec
+}
+
+// setBudget sets the named category's budget through the API. A hardLimit
+// of zero leaves the budget soft-only.
+func setBudget(t *testing.T, app *Application, category string, limit, hardLimit int64) {
+	t.Helper()
+
+	req := BudgetRequest{Category: category, Limit: limit}
+	if hardLimit > 0 {
+		req.HardLimit = &hardLimit
+	}
+	body, _ := json.Marshal(req)
+	if rec := putBudget(app, string(body)); rec.Code != http.StatusOK {
+		t.Fatalf("Failed to set budget: status %d, body %s", rec.Code, rec.Body.String())
+	}
+}
+
+func postTransaction(app *Application, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	app.HandleTransactionCreate(rec, req)
+	return rec
+}
+
+func TestHandleTransactionCreate_Budgets(t *testing.T) {
+	t.Run("under limit inserts without warning", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		setBudget(t, app, "Food", 10000, 20000)
+
+		rec := postTransaction(app, url.Values{"input": {"50 pizza"}})
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "Recorded $50.00") {
+			t.Errorf("Expected success fragment, got %q", body)
+		}
+		if strings.Contains(body, "budget") {
+			t.Errorf("Did not expect a budget warning, got %q", body)
+		}
+		if count, _ := app.Q.CountAllTransactions(context.Background()); count != 1 {
+			t.Errorf("Expected 1 transaction, got %d", count)
+		}
+	})
+
+	t.Run("over soft limit inserts with warning", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		setBudget(t, app, "Food", 4000, 0)
+
+		rec := postTransaction(app, url.Values{"input": {"50 pizza"}})
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "Recorded $50.00") {
+			t.Errorf("Expected success fragment, got %q", body)
+		}
+		if !strings.Contains(body, "over its monthly budget of $40.00") {
+			t.Errorf("Expected soft budget warning, got %q", body)
+		}
+		if count, _ := app.Q.CountAllTransactions(context.Background()); count != 1 {
+			t.Errorf("Expected 1 transaction, got %d", count)
+		}
+	})
+
+	t.Run("over hard limit is blocked", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		setBudget(t, app, "Food", 2000, 4000)
+
+		rec := postTransaction(app, url.Values{"input": {"50 pizza"}})
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "hard limit of $40.00") {
+			t.Errorf("Expected hard limit error, got %q", body)
+		}
+		if count, _ := app.Q.CountAllTransactions(context.Background()); count != 0 {
+			t.Errorf("Expected no transaction to be inserted, got %d", count)
+		}
+	})
+
+	t.Run("override records over hard limit", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		setBudget(t, app, "Food", 2000, 4000)
+
+		rec := postTransaction(app, url.Values{"input": {"50 pizza"}, "override": {"true"}})
+
+		if !strings.Contains(rec.Body.String(), "Recorded $50.00") {
+			t.Errorf("Expected success fragment, got %q", rec.Body.String())
+		}
+		if count, _ := app.Q.CountAllTransactions(context.Background()); count != 1 {
+			t.Errorf("Expected 1 transaction, got %d", count)
+		}
+	})
+
+	t.Run("income ignores budgets", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		// The API only budgets expense categories; this row stands in for
+		// one written before that check existed
+		if _, err := app.DB.Exec(`INSERT INTO budgets (category_id, limit_cents, hard_limit_cents) VALUES (4, 100, 100)`); err != nil {
+			t.Fatalf("Failed to insert budget: %v", err)
+		}
+
+		rec := postTransaction(app, url.Values{"input": {"5000 salary"}})
+
+		if !strings.Contains(rec.Body.String(), "Recorded $5000.00") {
+			t.Errorf("Expected success fragment, got %q", rec.Body.String())
+		}
+	})
+}
+
+func TestHandleBudgetStatus(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	setBudget(t, app, "Food", 10000, 0)
+	setBudget(t, app, "Transport", 5000, 0)
+
+	date := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -2500, "groceries", date)
+	createTestTransaction(t, app, 2, -4000, "train", date)
+	createTestTransaction(t, app, 2, -2000, "taxi", date)
+	// Other months and unbudgeted categories don't count
+	createTestTransaction(t, app, 1, -9000, "last month's feast", time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 3, -120000, "rent", date)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/budgets/status?year=2024&month=6", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBudgetStatus(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBudgetStatus() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got []BudgetStatus
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []BudgetStatus{
+		{Category: "Food", Limit: 10000, Spent: 2500, Remaining: 7500, Pct: 25, Over: false},
+		{Category: "Transport", Limit: 5000, Spent: 6000, Remaining: -1000, Pct: 120, Over: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HandleBudgetStatus() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/budgets/status?month=13", nil)
+	rec = httptest.NewRecorder()
+	app.HandleBudgetStatus(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid month status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestProjectBudget(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit, spent  int64
+		elapsed, days int
+		wantProjected int64
+		wantExceed    bool
+	}{
+		{"under budget now but on pace to exceed", 30000, 15000, 10, 30, 45000, true},
+		{"on pace to stay under", 30000, 5000, 10, 30, 15000, false},
+		{"exactly on the limit is not over", 31000, 10000, 10, 31, 31000, false},
+		{"already over", 10000, 12000, 20, 30, 18000, true},
+		{"finished month projects actuals", 10000, 9000, 30, 30, 9000, false},
+		{"future month projects nothing", 10000, 0, 0, 30, 0, false},
+		{"rounds to the nearest cent", 10000, 100, 3, 31, 1033, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectBudget("Food", tt.limit, tt.spent, tt.elapsed, tt.days)
+			if got.Projected != tt.wantProjected || got.WillExceed != tt.wantExceed {
+				t.Errorf("projectBudget(%d, %d, %d, %d) = %+v, want projected %d, will_exceed %v",
+					tt.limit, tt.spent, tt.elapsed, tt.days, got, tt.wantProjected, tt.wantExceed)
+			}
+		})
+	}
+}
+
+func TestHandleBudgetProjection(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	setBudget(t, app, "Food", 10000, 0)
+
+	// A finished month projects what was actually spent
+	createTestTransaction(t, app, 1, -2500, "groceries", time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/budgets/projected?year=2024&month=6", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBudgetProjection(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBudgetProjection() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got []BudgetProjection
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := BudgetProjection{Category: "Food", Limit: 10000, Spent: 2500, Projected: 2500}
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("HandleBudgetProjection() = %+v, want [%+v]", got, want)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/budgets/projected?year=abc", nil)
+	rec = httptest.NewRecorder()
+	app.HandleBudgetProjection(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid year status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestYearMonthParam(t *testing.T) {
+	now := time.Date(2025, 3, 31, 23, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		query     string
+		wantYear  int
+		wantMonth int
+		wantErr   string
+	}{
+		{"", 2025, 3, ""},
+		{"year=2024", 2024, 3, ""},
+		{"month=11", 2025, 11, ""},
+		{"year=2023&month=1", 2023, 1, ""},
+		{"year=abc", 0, 0, "Invalid year"},
+		{"month=13", 0, 0, "Invalid month"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+		year, month, err := yearMonthParam(req, now)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("yearMonthParam(%q) error = %v, want %q", tt.query, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || year != tt.wantYear || month != tt.wantMonth {
+			t.Errorf("yearMonthParam(%q) = %d, %d, %v, want %d, %d", tt.query, year, month, err, tt.wantYear, tt.wantMonth)
+		}
+	}
+}
+
+func TestHandleSetBudget(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	decode := func(rec *httptest.ResponseRecorder) BudgetRequest {
+		t.Helper()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("PUT status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var got BudgetRequest
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return got
+	}
+
+	t.Run("soft budget", func(t *testing.T) {
+		got := decode(putBudget(app, `{"category": "Food", "limit_cents": 5000}`))
+		if got.Category != "Food" || got.Limit != 5000 || got.HardLimit != nil {
+			t.Errorf("PUT = %+v, want a soft 5000 Food budget", got)
+		}
+		check, err := app.checkBudget(context.Background(), 1, 6000, time.Now())
+		if err != nil {
+			t.Fatalf("checkBudget() error = %v", err)
+		}
+		if !check.OverSoft || check.HasHard {
+			t.Errorf("checkBudget() = %+v, want over a soft-only budget", check)
+		}
+	})
+
+	t.Run("update adds a hard limit", func(t *testing.T) {
+		got := decode(putBudget(app, `{"category": "Food", "limit_cents": 5000, "hard_limit_cents": 8000}`))
+		if got.HardLimit == nil || *got.HardLimit != 8000 {
+			t.Errorf("PUT = %+v, want a hard limit of 8000", got)
+		}
+		check, err := app.checkBudget(context.Background(), 1, 9000, time.Now())
+		if err != nil {
+			t.Fatalf("checkBudget() error = %v", err)
+		}
+		if !check.HasHard || !check.OverHard {
+			t.Errorf("checkBudget() = %+v, want over the hard limit", check)
+		}
+	})
+
+	t.Run("update back to soft clears the hard limit", func(t *testing.T) {
+		decode(putBudget(app, `{"category": "Food", "limit_cents": 7000}`))
+		budget, err := app.Q.GetBudgetByCategory(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetBudgetByCategory() error = %v", err)
+		}
+		if budget.LimitCents != 7000 || budget.HardLimitCents.Valid {
+			t.Errorf("Budget = %+v, want a soft 7000 limit", budget)
+		}
+	})
+
+	t.Run("invalid budgets are rejected", func(t *testing.T) {
+		tests := []struct {
+			body string
+			want int
+		}{
+			{`{"category": "Food", "limit_cents": 0}`, http.StatusBadRequest},
+			{`{"category": "Food", "limit_cents": 5000, "hard_limit_cents": 4000}`, http.StatusBadRequest},
+			{`{"category": "Earned Income", "limit_cents": 5000}`, http.StatusBadRequest},
+			{`{"category": "Nope", "limit_cents": 5000}`, http.StatusNotFound},
+			{`{"category": "Food", "limit_cents": "lots"}`, http.StatusBadRequest},
+		}
+		for _, tt := range tests {
+			if rec := putBudget(app, tt.body); rec.Code != tt.want {
+				t.Errorf("PUT %s status = %d, want %d", tt.body, rec.Code, tt.want)
+			}
+		}
+	})
+}
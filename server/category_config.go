@@ -4,17 +4,33 @@ import (
 	"encoding/json"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
 type CategoryEntry struct {
 	Name     string   `json:"name"`
 	Keywords []string `json:"keywords"`
+	// Weight ranks this entry's keywords against other categories. Omitted
+	// or non-positive weights count as 1.
+	Weight int `json:"weight,omitempty"`
+}
+
+// effectiveWeight returns the entry's weight, defaulting to 1.
+func (e CategoryEntry) effectiveWeight() int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
 }
 
 type CategoryConfig struct {
 	DefaultCategory string          `json:"default_category"`
 	Categories      []CategoryEntry `json:"categories"`
+	// CompoundKeywords maps multi-word phrases to a category and is checked
+	// before single keywords, so "uber eats" is not caught by "uber".
+	CompoundKeywords map[string]string `json:"compound_keywords,omitempty"`
 }
 
 // LoadCategoryConfig loads category mappings from a JSON file.
@@ -36,20 +52,119 @@ func LoadCategoryConfig(path string) *CategoryConfig {
 	return &cfg
 }
 
+// categoryConfigPaths returns where a category config is looked for, in
+// order: the -categories path if given, the user's config directory
+// ($XDG_CONFIG_HOME/cheapskate) and the working directory.
+func categoryConfigPaths(explicit string) []string {
+	var paths []string
+	if explicit != "" {
+		paths = append(paths, explicit)
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		dir, _ = os.UserConfigDir()
+	}
+	if dir != "" {
+		paths = append(paths, filepath.Join(dir, "cheapskate", "categories.json"))
+	}
+	return append(paths, "categories.json")
+}
+
+// ResolveCategoryConfig loads the first category config found along
+// categoryConfigPaths, falling back to the built-in defaults when there is
+// none. A file that exists but fails to parse still ends the search.
+func ResolveCategoryConfig(explicit string) *CategoryConfig {
+	paths := categoryConfigPaths(explicit)
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return LoadCategoryConfig(path)
+		}
+	}
+	log.Printf("No category config found at %q, using built-in defaults", paths)
+	return defaultCategoryConfig()
+}
+
 // InferCategory finds the best matching category for a description.
-// Categories are checked in order, so earlier entries take priority.
+// Compound keywords are checked first. Otherwise the matching category with
+// the highest weight wins; among equal weights, earlier entries take priority.
 func (cc *CategoryConfig) InferCategory(desc string) string {
 	lower := strings.ToLower(desc)
 
+	if cat, ok := cc.matchCompound(lower); ok {
+		return cat
+	}
+
+	best := cc.DefaultCategory
+	bestWeight := 0
 	for _, cat := range cc.Categories {
+		weight := cat.effectiveWeight()
+		if weight <= bestWeight {
+			continue
+		}
 		for _, kw := range cat.Keywords {
 			if strings.Contains(lower, kw) {
-				return cat.Name
+				best, bestWeight = cat.Name, weight
+				break
 			}
 		}
 	}
 
-	return cc.DefaultCategory
+	return best
+}
+
+// matchCompound returns the category of the longest compound keyword found
+// in lower.
+func (cc *CategoryConfig) matchCompound(lower string) (string, bool) {
+	phrases := cc.compoundMatches(lower)
+	if len(phrases) == 0 {
+		return "", false
+	}
+	return cc.CompoundKeywords[phrases[0]], true
+}
+
+// compoundMatches returns every compound keyword found in lower, longest
+// first. Equal-length matches are sorted alphabetically so the result does
+// not depend on map iteration order.
+func (cc *CategoryConfig) compoundMatches(lower string) []string {
+	phrases := make([]string, 0, len(cc.CompoundKeywords))
+	for phrase := range cc.CompoundKeywords {
+		if strings.Contains(lower, strings.ToLower(phrase)) {
+			phrases = append(phrases, phrase)
+		}
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+	return phrases
+}
+
+// Match is one keyword found in a description and the category it maps to.
+type Match struct {
+	Category string `json:"category"`
+	Keyword  string `json:"keyword"`
+}
+
+// AllMatches lists every keyword desc contains, not just the one that wins
+// in InferCategory. Compound keywords come first in the order InferCategory
+// tries them, followed by single keywords in config order.
+func (cc *CategoryConfig) AllMatches(desc string) []Match {
+	lower := strings.ToLower(desc)
+
+	matches := []Match{}
+	for _, phrase := range cc.compoundMatches(lower) {
+		matches = append(matches, Match{Category: cc.CompoundKeywords[phrase], Keyword: phrase})
+	}
+	for _, cat := range cc.Categories {
+		for _, kw := range cat.Keywords {
+			if strings.Contains(lower, kw) {
+				matches = append(matches, Match{Category: cat.Name, Keyword: kw})
+			}
+		}
+	}
+	return matches
 }
 
 // defaultCategoryConfig returns a minimal built-in config matching the original
@@ -75,5 +190,9 @@ func defaultCategoryConfig() *CategoryConfig {
 				Keywords: []string{"rent", "mortgage", "electricity", "electric", "water", "internet", "wifi", "cable", "phone", "utility", "utilities", "insurance", "maintenance", "repair", "furniture", "appliance"},
 			},
 		},
+		CompoundKeywords: map[string]string{
+			"uber eats": "Food",
+			"doordash":  "Food",
+		},
 	}
 }
@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -104,6 +105,152 @@ func TestCategoryConfig_InferCategory(t *testing.T) {
 	}
 }
 
+func TestCategoryConfig_InferCategoryWeights(t *testing.T) {
+	cfg := &CategoryConfig{
+		DefaultCategory: "Unknown",
+		Categories: []CategoryEntry{
+			{Name: "Bills", Keywords: []string{"payment"}},
+			{Name: "Food", Keywords: []string{"pizza"}},
+			{Name: "Housing", Keywords: []string{"mortgage"}, Weight: 5},
+			{Name: "Transport", Keywords: []string{"car"}, Weight: 2},
+		},
+	}
+
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{name: "higher weight in later category wins", desc: "mortgage payment", want: "Housing"},
+		{name: "highest of several weights wins", desc: "car mortgage payment", want: "Housing"},
+		{name: "weighted beats default weight", desc: "pizza car", want: "Transport"},
+		{name: "equal weights fall back to position", desc: "pizza payment", want: "Bills"},
+		{name: "low weight still matches alone", desc: "card payment", want: "Transport"}, // "car" matches "card"
+		{name: "no match uses default", desc: "random purchase", want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.InferCategory(tt.desc)
+			if got != tt.want {
+				t.Errorf("InferCategory(%q) = %q, want %q", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryConfig_CompoundKeywords(t *testing.T) {
+	cfg := &CategoryConfig{
+		DefaultCategory: "Unknown",
+		Categories: []CategoryEntry{
+			{Name: "Transport", Keywords: []string{"uber", "car"}},
+			{Name: "Food", Keywords: []string{"pizza"}},
+			{Name: "Shopping", Keywords: []string{"wash"}, Weight: 10},
+		},
+		CompoundKeywords: map[string]string{
+			"uber eats": "Food",
+			"car wash":  "Transport",
+			"car":       "Shopping",
+		},
+	}
+
+	tests := []struct {
+		name string
+		desc string
+		want string
+	}{
+		{name: "compound beats earlier single keyword", desc: "uber eats order", want: "Food"},
+		{name: "single keyword without compound", desc: "uber ride", want: "Transport"},
+		{name: "case insensitive", desc: "UBER EATS", want: "Food"},
+		{name: "compound beats weighted keyword", desc: "car wash", want: "Transport"},
+		{name: "longest compound wins", desc: "car wash and car", want: "Transport"},
+		{name: "no match uses default", desc: "random purchase", want: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.InferCategory(tt.desc)
+			if got != tt.want {
+				t.Errorf("InferCategory(%q) = %q, want %q", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategoryConfig_AllMatches(t *testing.T) {
+	cfg := &CategoryConfig{
+		DefaultCategory: "Unknown",
+		Categories: []CategoryEntry{
+			{Name: "Transport", Keywords: []string{"uber", "car"}},
+			{Name: "Food", Keywords: []string{"pizza", "eats"}},
+		},
+		CompoundKeywords: map[string]string{
+			"uber eats": "Food",
+		},
+	}
+
+	tests := []struct {
+		name string
+		desc string
+		want []Match
+	}{
+		{
+			name: "compound then keywords in config order",
+			desc: "Uber Eats pizza",
+			want: []Match{
+				{Category: "Food", Keyword: "uber eats"},
+				{Category: "Transport", Keyword: "uber"},
+				{Category: "Food", Keyword: "pizza"},
+				{Category: "Food", Keyword: "eats"},
+			},
+		},
+		{
+			name: "several keywords in one category",
+			desc: "uber car",
+			want: []Match{
+				{Category: "Transport", Keyword: "uber"},
+				{Category: "Transport", Keyword: "car"},
+			},
+		},
+		{name: "no match", desc: "random purchase", want: []Match{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cfg.AllMatches(tt.desc)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllMatches(%q) = %v, want %v", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadCategoryConfig_Weights(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "weights.json")
+	configJSON := `{
+		"default_category": "Misc",
+		"categories": [
+			{"name": "Bills", "keywords": ["payment"]},
+			{"name": "Housing", "keywords": ["mortgage"], "weight": 10}
+		]
+	}`
+	if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg := LoadCategoryConfig(configPath)
+
+	if cfg.Categories[0].Weight != 0 {
+		t.Errorf("Categories[0].Weight = %d, want 0 (omitted)", cfg.Categories[0].Weight)
+	}
+	if cfg.Categories[1].Weight != 10 {
+		t.Errorf("Categories[1].Weight = %d, want 10", cfg.Categories[1].Weight)
+	}
+	if got := cfg.InferCategory("mortgage payment"); got != "Housing" {
+		t.Errorf("InferCategory(%q) = %q, want %q", "mortgage payment", got, "Housing")
+	}
+}
+
 func TestDefaultCategoryConfig(t *testing.T) {
 	cfg := defaultCategoryConfig()
 
@@ -126,3 +273,56 @@ func TestDefaultCategoryConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestResolveCategoryConfig_FallbackChain(t *testing.T) {
+	writeConfig := func(t *testing.T, path, defaultCategory string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create config dir: %v", err)
+		}
+		data := `{"default_category": "` + defaultCategory + `", "categories": []}`
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("Failed to write test config: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		explicit bool
+		xdg      bool
+		local    bool
+		want     string
+	}{
+		{"explicit path wins", true, true, true, "Explicit"},
+		{"missing explicit path falls back to XDG", false, true, true, "XDG"},
+		{"XDG falls back to working directory", false, false, true, "Local"},
+		{"nothing found uses built-in defaults", false, false, false, "Housing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			workDir := filepath.Join(tmpDir, "work")
+			if err := os.Mkdir(workDir, 0755); err != nil {
+				t.Fatalf("Failed to create work dir: %v", err)
+			}
+			t.Chdir(workDir)
+			t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "xdg"))
+
+			explicit := filepath.Join(tmpDir, "etc", "categories.json")
+			if tt.explicit {
+				writeConfig(t, explicit, "Explicit")
+			}
+			if tt.xdg {
+				writeConfig(t, filepath.Join(tmpDir, "xdg", "cheapskate", "categories.json"), "XDG")
+			}
+			if tt.local {
+				writeConfig(t, filepath.Join(workDir, "categories.json"), "Local")
+			}
+
+			if got := ResolveCategoryConfig(explicit).DefaultCategory; got != tt.want {
+				t.Errorf("ResolveCategoryConfig() DefaultCategory = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// CurrencyFormat describes how amounts in one currency are written.
+// Decimals is the size of the currency's minor unit, the precision amounts
+// are entered and confirmed with; stored amounts are always cents.
+type CurrencyFormat struct {
+	Symbol       string `json:"symbol"`
+	Decimals     int    `json:"decimals"`
+	SymbolBefore bool   `json:"symbol_before"`
+}
+
+// maxCurrencyDecimals bounds the precision a -currency-symbols entry may use.
+const maxCurrencyDecimals = 4
+
+// builtinCurrencyFormats covers the currencies with a well-known symbol.
+// Anything else is written with its code after the amount.
+var builtinCurrencyFormats = map[string]CurrencyFormat{
+	"USD": {Symbol: "$", Decimals: 2, SymbolBefore: true},
+	"EUR": {Symbol: "€", Decimals: 2, SymbolBefore: true},
+	"GBP": {Symbol: "£", Decimals: 2, SymbolBefore: true},
+	"JPY": {Symbol: "¥", Decimals: 0, SymbolBefore: true},
+	"INR": {Symbol: "₹", Decimals: 2, SymbolBefore: true},
+	"KRW": {Symbol: "₩", Decimals: 0, SymbolBefore: true},
+	"BRL": {Symbol: "R$", Decimals: 2, SymbolBefore: true},
+}
+
+var reCurrencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// LoadCurrencySymbols reads a JSON object mapping currency codes to formats,
+// e.g. {"XBT": {"symbol": "₿", "decimals": 4, "symbol_before": true}}.
+// Unlike the category config, a bad file is an error rather than silently
+// ignored, since it would misstate amounts.
+func LoadCurrencySymbols(path string) (CurrencyTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var formats CurrencyTable
+	if err := json.Unmarshal(data, &formats); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := validateCurrencySymbols(formats); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return formats, nil
+}
+
+// validateCurrencySymbols checks every entry has an ISO-style code, a symbol
+// and a sensible precision.
+func validateCurrencySymbols(formats CurrencyTable) error {
+	for code, f := range formats {
+		if !reCurrencyCode.MatchString(code) {
+			return fmt.Errorf("invalid currency code %q: must be three uppercase letters", code)
+		}
+		if strings.TrimSpace(f.Symbol) == "" {
+			return fmt.Errorf("currency %s: symbol must not be empty", code)
+		}
+		if f.Decimals < 0 || f.Decimals > maxCurrencyDecimals {
+			return fmt.Errorf("currency %s: decimals %d must be between 0 and %d", code, f.Decimals, maxCurrencyDecimals)
+		}
+	}
+	return nil
+}
+
+// CurrencyTable holds the -currency-symbols entries. Lookups consult it
+// first and fall back to the built-in tables, so parsing, validation and
+// formatting all agree on which codes exist and how precise they are.
+type CurrencyTable map[string]CurrencyFormat
+
+// Known reports whether a transaction may use code.
+func (t CurrencyTable) Known(code string) bool {
+	if _, ok := t[code]; ok {
+		return true
+	}
+	return supportedCurrencies[code]
+}
+
+// Format returns how to write amounts in code: the configured entry if there
+// is one, then the built-in format, then the code itself.
+func (t CurrencyTable) Format(code string) CurrencyFormat {
+	if f, ok := t[code]; ok {
+		return f
+	}
+	if f, ok := builtinCurrencyFormats[code]; ok {
+		return f
+	}
+	return CurrencyFormat{Symbol: code, Decimals: currencyPrecision(code)}
+}
+
+// Decimals returns the number of decimal places amounts in code may have.
+func (t CurrencyTable) Decimals(code string) int {
+	return t.Format(code).Decimals
+}
+
+// currencyFormat returns how to write amounts in code.
+func (app *Application) currencyFormat(code string) CurrencyFormat {
+	return app.CurrencySymbols.Format(code)
+}
+
+// toCents converts an amount in minor units of a currency with the given
+// decimals to the hundredths every stored amount uses, so totals, budgets
+// and exports can sum rows regardless of currency. It reports false when the
+// amount has precision a cent cannot hold: 1.250 KWD (1250) is 125, but
+// 1.255 KWD (1255) cannot be stored.
+func toCents(minor int64, decimals int) (int64, bool) {
+	for ; decimals < defaultCurrencyDecimals; decimals++ {
+		minor *= 10
+	}
+	scale := int64(1)
+	for ; decimals > defaultCurrencyDecimals; decimals-- {
+		scale *= 10
+	}
+	if minor%scale != 0 {
+		return 0, false
+	}
+	return minor / scale, true
+}
+
+// fromCents converts a stored amount in cents back to minor units of a
+// currency with the given decimals, for formatting with CurrencyFormat.
+func fromCents(cents int64, decimals int) int64 {
+	for ; decimals > defaultCurrencyDecimals; decimals-- {
+		cents *= 10
+	}
+	for ; decimals < defaultCurrencyDecimals; decimals++ {
+		cents /= 10
+	}
+	return cents
+}
+
+// Format writes an amount given in minor units, e.g. 1250 as "$12.50" or,
+// with the symbol after, "1.250 KWD".
+func (f CurrencyFormat) Format(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+
+	number := fmt.Sprintf("%d", minor)
+	if f.Decimals > 0 {
+		scale := int64(1)
+		for range f.Decimals {
+			scale *= 10
+		}
+		number = fmt.Sprintf("%d.%0*d", minor/scale, f.Decimals, minor%scale)
+	}
+
+	if f.SymbolBefore {
+		return sign + f.Symbol + number
+	}
+	return sign + number + " " + f.Symbol
+}
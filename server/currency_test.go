@@ -0,0 +1,159 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCurrencyFormat_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		format CurrencyFormat
+		minor  int64
+		want   string
+	}{
+		{"symbol before", CurrencyFormat{Symbol: "$", Decimals: 2, SymbolBefore: true}, 1250, "$12.50"},
+		{"symbol after", CurrencyFormat{Symbol: "KWD", Decimals: 3}, 1250, "1.250 KWD"},
+		{"no decimals", CurrencyFormat{Symbol: "¥", SymbolBefore: true}, 1250, "¥1250"},
+		{"small amount keeps leading zeros", CurrencyFormat{Symbol: "€", Decimals: 2, SymbolBefore: true}, 5, "€0.05"},
+		{"negative", CurrencyFormat{Symbol: "$", Decimals: 2, SymbolBefore: true}, -1250, "-$12.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Format(tt.minor); got != tt.want {
+				t.Errorf("Format(%d) = %q, want %q", tt.minor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToCents(t *testing.T) {
+	tests := []struct {
+		minor    int64
+		decimals int
+		want     int64
+		wantOK   bool
+	}{
+		{1250, 2, 1250, true},
+		{500, 0, 50000, true},
+		{1250, 3, 125, true},
+		{-1250, 3, -125, true},
+		{1255, 3, 0, false},
+		{-1255, 3, 0, false},
+		{12300, 4, 123, true},
+		{12345, 4, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := toCents(tt.minor, tt.decimals)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("toCents(%d, %d) = %d, %v, want %d, %v", tt.minor, tt.decimals, got, ok, tt.want, tt.wantOK)
+		}
+		if ok {
+			if back := fromCents(got, tt.decimals); back != tt.minor {
+				t.Errorf("fromCents(%d, %d) = %d, want %d", got, tt.decimals, back, tt.minor)
+			}
+		}
+	}
+}
+
+func TestLoadCurrencySymbols(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "currencies.json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("custom symbol formats amounts", func(t *testing.T) {
+		formats, err := LoadCurrencySymbols(write(t, `{"XBT": {"symbol": "₿", "decimals": 4, "symbol_before": true}, "USD": {"symbol": "USD", "decimals": 2}}`))
+		if err != nil {
+			t.Fatalf("LoadCurrencySymbols() error = %v", err)
+		}
+		app := &Application{CurrencySymbols: formats}
+
+		if got := app.currencyFormat("XBT").Format(12345); got != "₿1.2345" {
+			t.Errorf("XBT format = %q, want %q", got, "₿1.2345")
+		}
+		if got := app.currencyFormat("USD").Format(2500); got != "25.00 USD" {
+			t.Errorf("overridden USD format = %q, want %q", got, "25.00 USD")
+		}
+		// Codes missing from the file keep their built-in format
+		if got := app.currencyFormat("EUR").Format(2500); got != "€25.00" {
+			t.Errorf("EUR format = %q, want %q", got, "€25.00")
+		}
+		if got := app.currencyFormat("OMR").Format(2500); got != "2.500 OMR" {
+			t.Errorf("OMR format = %q, want %q", got, "2.500 OMR")
+		}
+	})
+
+	invalid := map[string]string{
+		"malformed json":  `{"XBT": `,
+		"lowercase code":  `{"xbt": {"symbol": "₿", "decimals": 4}}`,
+		"empty symbol":    `{"XBT": {"symbol": " ", "decimals": 4}}`,
+		"too many digits": `{"XBT": {"symbol": "₿", "decimals": 8}}`,
+	}
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := LoadCurrencySymbols(write(t, content)); err == nil {
+				t.Error("LoadCurrencySymbols() expected error")
+			}
+		})
+	}
+
+	if _, err := LoadCurrencySymbols(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadCurrencySymbols() expected error for a missing file")
+	}
+}
+
+func TestParseTransaction_CurrencySymbols(t *testing.T) {
+	catConfig := testCategoryConfig()
+	currencies := CurrencyTable{
+		"XBT": {Symbol: "₿", Decimals: 4, SymbolBefore: true},
+		"KWD": {Symbol: "KD", Decimals: 2},
+	}
+
+	// A configured code is a currency with the configured precision
+	got, err := ParseTransaction("0.0001 XBT coffee", catConfig, currencies)
+	if err != nil {
+		t.Fatalf("ParseTransaction() error = %v", err)
+	}
+	if got.Currency != "XBT" || got.Amount != 1 || got.Description != "coffee" {
+		t.Errorf("ParseTransaction() = %+v, want 1 XBT coffee", got)
+	}
+
+	// The configured precision replaces the built-in one
+	if _, err := ParseTransaction("1.250 KWD taxi", catConfig, currencies); err == nil {
+		t.Error("ParseTransaction() should reject three decimals when KWD is configured with two")
+	}
+
+	// Without the config XBT is just part of the description
+	if _, err := ParseTransaction("0.0001 XBT coffee", catConfig, nil); err == nil {
+		t.Error("ParseTransaction() should reject four decimals for an unconfigured code")
+	}
+}
+
+func TestHandleTransactionCreate_CurrencySymbols(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.CurrencySymbols = map[string]CurrencyFormat{"EUR": {Symbol: "EUR", Decimals: 2}}
+
+	form := url.Values{}
+	form.Add("input", "12.50 EUR pizza")
+	req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	app.HandleTransactionCreate(rec, req)
+
+	if body := rec.Body.String(); !strings.Contains(body, "12.50 EUR") {
+		t.Errorf("Confirmation should use the configured currency format, got %s", body)
+	}
+}
@@ -0,0 +1,241 @@
+// Schema migrations (manual addition, not generated by SQLC)
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationName matches migration file names such as 0002_add_notes.sql
+var migrationName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.sql$`)
+
+// Migration is one numbered schema change.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// Migrations returns the migrations embedded in the binary, oldest first.
+func Migrations() ([]Migration, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return LoadMigrations(sub)
+}
+
+// LoadMigrations reads NNNN_name.sql files from the root of fsys, ordered by
+// version. Other files are ignored; two files with the same version are an
+// error.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := map[int]string{}
+	for _, entry := range entries {
+		m := migrationName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, err := strconv.Atoi(m[1])
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		if prev, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", prev, entry.Name(), version)
+		}
+		seen[version] = entry.Name()
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, Migration{Version: version, Name: m[2], SQL: string(body)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+const createSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+)`
+
+// Migrate applies every migration not yet recorded in schema_migrations, in
+// version order, each in its own transaction. It returns how many were
+// applied and stops at the first failure, leaving that migration unrecorded.
+func Migrate(ctx context.Context, conn *sql.DB, migrations []Migration) (int, error) {
+	if _, err := conn.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return 0, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	applied := map[int]bool{}
+	rows, err := conn.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return 0, err
+	}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		applied[version] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if err := applyMigration(ctx, conn, m); err != nil {
+			return count, fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func applyMigration(ctx context.Context, conn *sql.DB, m Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Baseline records migrations up to and including version as applied
+// without running them, for databases whose schema predates migrations. It
+// does nothing once any migration has been recorded.
+func Baseline(ctx context.Context, conn *sql.DB, migrations []Migration, version int) error {
+	if _, err := conn.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var recorded int
+	if err := conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&recorded); err != nil {
+		return err
+	}
+	if recorded > 0 {
+		return nil
+	}
+
+	for _, m := range migrations {
+		if m.Version > version {
+			break
+		}
+		if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, name) VALUES (?, ?)`, m.Version, m.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uuidSQL is an SQLite expression producing a random version 4 UUID. It is
+// evaluated per row, so one UPDATE gives every row a distinct value.
+const uuidSQL = `lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + abs(random()) % 4, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6)))`
+
+// legacyColumns are transactions columns that older releases added on
+// startup, in the order they were introduced.
+var legacyColumns = []struct{ name, ddl string }{
+	{"deleted_at", `ALTER TABLE transactions ADD COLUMN deleted_at DATETIME DEFAULT NULL`},
+	{"receipt_path", `ALTER TABLE transactions ADD COLUMN receipt_path TEXT DEFAULT NULL`},
+	{"uid", `ALTER TABLE transactions ADD COLUMN uid TEXT DEFAULT NULL`},
+}
+
+// legacyTables are the tables older releases created on startup, matching
+// the initial migration.
+var legacyTables = []string{
+	`CREATE TABLE IF NOT EXISTS budgets (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  category_id INTEGER NOT NULL UNIQUE,
+  limit_cents INTEGER NOT NULL,
+  hard_limit_cents INTEGER DEFAULT NULL,
+  FOREIGN KEY (category_id) REFERENCES categories(id)
+)`,
+	`CREATE TABLE IF NOT EXISTS audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  action TEXT NOT NULL,
+  entity_id INTEGER NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+)`,
+	`CREATE TABLE IF NOT EXISTS transaction_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  transaction_id INTEGER NOT NULL,
+  field TEXT NOT NULL,
+  old_value TEXT NOT NULL,
+  new_value TEXT NOT NULL,
+  changed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+)`,
+}
+
+// UpgradeLegacy brings a database created before migrations were tracked up
+// to the initial migration: it adds whichever transactions columns and
+// tables are missing, backfills a uid for every transaction and creates the
+// uid index. It runs in one transaction, so call it before Baseline.
+func UpgradeLegacy(ctx context.Context, conn *sql.DB) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, col := range legacyColumns {
+		var exists int
+		err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info('transactions') WHERE name = ?`, col.name).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists > 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, col.ddl); err != nil {
+			return fmt.Errorf("add column %s: %w", col.name, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE transactions SET uid = `+uuidSQL+` WHERE uid IS NULL`); err != nil {
+		return fmt.Errorf("backfill uid: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_uid ON transactions(uid)`); err != nil {
+		return fmt.Errorf("create uid index: %w", err)
+	}
+	for _, ddl := range legacyTables {
+		if _, err := tx.ExecContext(ctx, ddl); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,214 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openMigrationDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_notes.sql": {Data: []byte("ALTER TABLE items ADD COLUMN notes TEXT;")},
+		"0001_items.sql":     {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);")},
+		"README.md":          {Data: []byte("not a migration")},
+	}
+
+	migrations, err := db.LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("LoadMigrations() returned %d migrations, want 2", len(migrations))
+	}
+	if migrations[0].Version != 1 || migrations[0].Name != "items" || migrations[1].Version != 2 || migrations[1].Name != "add_notes" {
+		t.Errorf("LoadMigrations() = %+v, want versions 1 then 2", migrations)
+	}
+
+	fsys["0002_duplicate.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	if _, err := db.LoadMigrations(fsys); err == nil {
+		t.Error("LoadMigrations() should reject duplicate versions")
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	conn := openMigrationDB(t)
+
+	migrations := []db.Migration{
+		{Version: 1, Name: "items", SQL: "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL);"},
+		{Version: 2, Name: "add_notes", SQL: "ALTER TABLE items ADD COLUMN notes TEXT;"},
+	}
+
+	applied, err := db.Migrate(ctx, conn, migrations)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if applied != 2 {
+		t.Errorf("Migrate() applied %d, want 2", applied)
+	}
+	if _, err := conn.Exec("INSERT INTO items (name, notes) VALUES ('a', 'b')"); err != nil {
+		t.Errorf("Migrated schema is missing columns: %v", err)
+	}
+
+	// Re-running must not apply anything again (the ALTER would fail if it did)
+	applied, err = db.Migrate(ctx, conn, migrations)
+	if err != nil {
+		t.Fatalf("Second Migrate() error = %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("Second Migrate() applied %d, want 0", applied)
+	}
+
+	var recorded int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&recorded); err != nil {
+		t.Fatalf("Failed to count schema_migrations: %v", err)
+	}
+	if recorded != 2 {
+		t.Errorf("schema_migrations has %d rows, want 2", recorded)
+	}
+
+	t.Run("failed migration is rolled back and not recorded", func(t *testing.T) {
+		broken := append(migrations, db.Migration{
+			Version: 3,
+			Name:    "broken",
+			SQL:     "CREATE TABLE tags (id INTEGER PRIMARY KEY); INSERT INTO missing_table VALUES (1);",
+		})
+		applied, err := db.Migrate(ctx, conn, broken)
+		if err == nil {
+			t.Fatal("Migrate() should fail on a broken migration")
+		}
+		if applied != 0 {
+			t.Errorf("Migrate() applied %d, want 0", applied)
+		}
+		var name string
+		if err := conn.QueryRow("SELECT name FROM sqlite_master WHERE name = 'tags'").Scan(&name); err != sql.ErrNoRows {
+			t.Error("Partial changes from a failed migration should be rolled back")
+		}
+		if err := conn.QueryRow("SELECT name FROM schema_migrations WHERE version = 3").Scan(&name); err != sql.ErrNoRows {
+			t.Error("Failed migration should not be recorded")
+		}
+	})
+}
+
+func TestBaseline(t *testing.T) {
+	ctx := context.Background()
+	conn := openMigrationDB(t)
+
+	// A database created before migrations were tracked
+	if _, err := conn.Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatalf("Failed to create legacy table: %v", err)
+	}
+	migrations := []db.Migration{
+		{Version: 1, Name: "items", SQL: "CREATE TABLE items (id INTEGER PRIMARY KEY);"},
+		{Version: 2, Name: "add_notes", SQL: "ALTER TABLE items ADD COLUMN notes TEXT;"},
+	}
+
+	if err := db.Baseline(ctx, conn, migrations, 1); err != nil {
+		t.Fatalf("Baseline() error = %v", err)
+	}
+	applied, err := db.Migrate(ctx, conn, migrations)
+	if err != nil {
+		t.Fatalf("Migrate() after Baseline() error = %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("Migrate() applied %d, want only the post-baseline migration", applied)
+	}
+
+	// Once history exists, Baseline must leave it alone
+	if err := db.Baseline(ctx, conn, migrations, 1); err != nil {
+		t.Fatalf("Second Baseline() error = %v", err)
+	}
+	var recorded int
+	if err := conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&recorded); err != nil {
+		t.Fatalf("Failed to count schema_migrations: %v", err)
+	}
+	if recorded != 2 {
+		t.Errorf("schema_migrations has %d rows, want 2", recorded)
+	}
+}
+
+func TestMigrations_Embedded(t *testing.T) {
+	migrations, err := db.Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Fatalf("Migrations() = %+v, want the initial schema first", migrations)
+	}
+
+	conn := openMigrationDB(t)
+	if _, err := db.Migrate(context.Background(), conn, migrations); err != nil {
+		t.Fatalf("Embedded migrations failed on a fresh database: %v", err)
+	}
+}
+
+func TestMigrations_TransferCategoryType(t *testing.T) {
+	ctx := context.Background()
+	conn := openMigrationDB(t)
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		t.Fatalf("Migrations() error = %v", err)
+	}
+	var before []db.Migration
+	for _, m := range migrations {
+		if m.Version < 3 {
+			before = append(before, m)
+		}
+	}
+	if _, err := db.Migrate(ctx, conn, before); err != nil {
+		t.Fatalf("Migrate() to version 2 error = %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO users (name, email) VALUES ('TestUser', 'test@example.com')`); err != nil {
+		t.Fatalf("Failed to insert user: %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO transactions (user_id, category_id, amount, description) VALUES (1, 3, -120000, 'rent')`); err != nil {
+		t.Fatalf("Failed to insert transaction: %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO categories (name, type) VALUES ('Savings', 'transfer')`); err == nil {
+		t.Fatal("transfer categories should be rejected before the migration")
+	}
+
+	if _, err := db.Migrate(ctx, conn, migrations); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if _, err := conn.Exec(`INSERT INTO categories (name, type) VALUES ('Savings', 'transfer')`); err != nil {
+		t.Fatalf("transfer category rejected after the migration: %v", err)
+	}
+	if _, err := conn.Exec(`INSERT INTO categories (name, type) VALUES ('Bogus', 'refund')`); err == nil {
+		t.Error("unknown category types should still be rejected")
+	}
+
+	var name string
+	if err := conn.QueryRow(`SELECT c.name FROM transactions t JOIN categories c ON c.id = t.category_id`).Scan(&name); err != nil {
+		t.Fatalf("Failed to join transaction to its category: %v", err)
+	}
+	if name != "Housing" {
+		t.Errorf("transaction category = %q, want Housing", name)
+	}
+
+	var id int64
+	if err := conn.QueryRow(`SELECT id FROM categories WHERE name = 'Savings'`).Scan(&id); err != nil {
+		t.Fatalf("Failed to load new category: %v", err)
+	}
+	if id != 5 {
+		t.Errorf("new category id = %d, want 5 to follow the copied rows", id)
+	}
+}
@@ -0,0 +1,65 @@
+CREATE TABLE users (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  email TEXT NOT NULL UNIQUE,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE categories (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+  icon TEXT, -- Emoji or icon class
+  color TEXT -- Hex code for UI
+);
+
+CREATE TABLE transactions (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  category_id INTEGER NOT NULL,
+  amount INTEGER NOT NULL, -- Stored in cents (e.g. 100 = $1.00)
+  currency TEXT NOT NULL DEFAULT 'USD',
+  description TEXT NOT NULL,
+  date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  deleted_at DATETIME DEFAULT NULL, -- Soft delete timestamp
+  receipt_path TEXT DEFAULT NULL, -- Stored receipt file name under the uploads dir
+  uid TEXT DEFAULT NULL, -- Stable UUID, shared across devices when merging imports
+  FOREIGN KEY (user_id) REFERENCES users(id),
+  FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+CREATE UNIQUE INDEX idx_transactions_uid ON transactions(uid);
+
+CREATE TABLE budgets (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  category_id INTEGER NOT NULL UNIQUE,
+  limit_cents INTEGER NOT NULL, -- Soft monthly limit, warns when exceeded
+  hard_limit_cents INTEGER DEFAULT NULL, -- Hard monthly cap, blocks entry when exceeded
+  FOREIGN KEY (category_id) REFERENCES categories(id)
+);
+
+CREATE TABLE audit_log (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  action TEXT NOT NULL, -- e.g. create, soft_delete, delete, recategorize
+  entity_id INTEGER NOT NULL, -- ID of the affected transaction
+  details TEXT NOT NULL DEFAULT '',
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE transaction_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  transaction_id INTEGER NOT NULL,
+  field TEXT NOT NULL, -- Column that changed, e.g. amount or category_id
+  old_value TEXT NOT NULL,
+  new_value TEXT NOT NULL,
+  changed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
+
+-- Seed some default categories
+INSERT INTO categories (name, type, icon, color) VALUES
+('Food', 'expense', '🍔', '#FF5733'),
+('Transport', 'expense', '🚕', '#33C1FF'),
+('Housing', 'expense', '🏠', '#8D33FF'),
+('Earned Income', 'income', '💰', '#2ECC71');
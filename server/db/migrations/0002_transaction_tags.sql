@@ -0,0 +1,6 @@
+CREATE TABLE transaction_tags (
+  transaction_id INTEGER NOT NULL,
+  tag TEXT NOT NULL, -- Lowercase, without the leading #, e.g. vacation
+  PRIMARY KEY (transaction_id, tag),
+  FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
@@ -0,0 +1,17 @@
+-- SQLite can't change a CHECK constraint in place, so categories is rebuilt
+-- to also allow 'transfer' (money moved between accounts, neither income nor
+-- expense). Ids are copied, so transactions and budgets keep their links.
+CREATE TABLE categories_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer')),
+  icon TEXT, -- Emoji or icon class
+  color TEXT -- Hex code for UI
+);
+
+INSERT INTO categories_new (id, name, type, icon, color)
+SELECT id, name, type, icon, color FROM categories;
+
+DROP TABLE categories;
+
+ALTER TABLE categories_new RENAME TO categories;
@@ -0,0 +1,6 @@
+-- Runtime settings changed through the API, as opposed to startup flags.
+-- Values are stored as text and parsed by whoever reads them.
+CREATE TABLE settings (
+  key TEXT PRIMARY KEY,
+  value TEXT NOT NULL
+);
@@ -9,6 +9,21 @@ import (
 	"time"
 )
 
+type AuditLog struct {
+	ID        int64        `json:"id"`
+	Action    string       `json:"action"`
+	EntityID  int64        `json:"entity_id"`
+	Details   string       `json:"details"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type Budget struct {
+	ID             int64         `json:"id"`
+	CategoryID     int64         `json:"category_id"`
+	LimitCents     int64         `json:"limit_cents"`
+	HardLimitCents sql.NullInt64 `json:"hard_limit_cents"`
+}
+
 type Category struct {
 	ID    int64          `json:"id"`
 	Name  string         `json:"name"`
@@ -17,16 +32,37 @@ type Category struct {
 	Color sql.NullString `json:"color"`
 }
 
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 type Transaction struct {
-	ID          int64        `json:"id"`
-	UserID      int64        `json:"user_id"`
-	CategoryID  int64        `json:"category_id"`
-	Amount      int64        `json:"amount"`
-	Currency    string       `json:"currency"`
-	Description string       `json:"description"`
-	Date        time.Time    `json:"date"`
-	CreatedAt   sql.NullTime `json:"created_at"`
-	DeletedAt   sql.NullTime `json:"deleted_at"`
+	ID          int64          `json:"id"`
+	UserID      int64          `json:"user_id"`
+	CategoryID  int64          `json:"category_id"`
+	Amount      int64          `json:"amount"`
+	Currency    string         `json:"currency"`
+	Description string         `json:"description"`
+	Date        time.Time      `json:"date"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	DeletedAt   sql.NullTime   `json:"deleted_at"`
+	ReceiptPath sql.NullString `json:"receipt_path"`
+	Uid         sql.NullString `json:"uid"`
+}
+
+type TransactionHistory struct {
+	ID            int64        `json:"id"`
+	TransactionID int64        `json:"transaction_id"`
+	Field         string       `json:"field"`
+	OldValue      string       `json:"old_value"`
+	NewValue      string       `json:"new_value"`
+	ChangedAt     sql.NullTime `json:"changed_at"`
+}
+
+type TransactionTag struct {
+	TransactionID int64  `json:"transaction_id"`
+	Tag           string `json:"tag"`
 }
 
 type User struct {
@@ -6,31 +6,85 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
+	AddTransactionTag(ctx context.Context, arg AddTransactionTagParams) error
 	CountAllTransactions(ctx context.Context) (int64, error)
+	CountDeletedTransactionsByFiscalYear(ctx context.Context, arg CountDeletedTransactionsByFiscalYearParams) (int64, error)
+	CountTransactionsByContent(ctx context.Context, arg CountTransactionsByContentParams) (int64, error)
+	CountTransactionsByFiscalYear(ctx context.Context, arg CountTransactionsByFiscalYearParams) (int64, error)
+	CountTransactionsByUID(ctx context.Context, uid sql.NullString) (int64, error)
 	CountTransactionsByYear(ctx context.Context, dollar_1 string) (int64, error)
 	CountTransactionsByYearWithDeleted(ctx context.Context, dollar_1 string) (int64, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
-	DeleteAllTransactions(ctx context.Context) error
+	CreateTransactionHistory(ctx context.Context, arg CreateTransactionHistoryParams) error
+	DeleteAllTransactions(ctx context.Context) (int64, error)
+	DeleteAuditEntriesBefore(ctx context.Context, createdAt sql.NullTime) (int64, error)
+	DeleteAuditEntriesForEntity(ctx context.Context, entityID int64) error
 	DeleteTransaction(ctx context.Context, arg DeleteTransactionParams) error
+	DeleteTransactionHistory(ctx context.Context, transactionID int64) error
+	DeleteTransactionTags(ctx context.Context, transactionID int64) error
+	FindDuplicateGroups(ctx context.Context) ([]FindDuplicateGroupsRow, error)
+	GetAverageAmountByCategory(ctx context.Context, year string) ([]GetAverageAmountByCategoryRow, error)
+	GetBusiestDays(ctx context.Context, arg GetBusiestDaysParams) ([]GetBusiestDaysRow, error)
+	GetBudgetByCategory(ctx context.Context, categoryID int64) (Budget, error)
 	GetCategoryByName(ctx context.Context, name string) (Category, error)
+	GetCategorySpendForMonth(ctx context.Context, arg GetCategorySpendForMonthParams) (int64, error)
+	GetCategoryTotalsAllTime(ctx context.Context) ([]GetCategoryTotalsAllTimeRow, error)
+	GetCategoryTotalsByMonth(ctx context.Context, month string) ([]GetCategoryTotalsByMonthRow, error)
+	GetCategoryTotalsByFiscalYear(ctx context.Context, arg GetCategoryTotalsByFiscalYearParams) ([]GetCategoryTotalsByFiscalYearRow, error)
 	GetCategoryTotalsByYear(ctx context.Context, dollar_1 string) ([]GetCategoryTotalsByYearRow, error)
+	GetDailyTotalsByMonth(ctx context.Context, month string) ([]GetDailyTotalsByMonthRow, error)
 	GetDistinctTransactionYears(ctx context.Context) ([]int64, error)
+	GetDistinctYearMonths(ctx context.Context) ([]GetDistinctYearMonthsRow, error)
+	GetExpenseTotalExcludingCategories(ctx context.Context, arg GetExpenseTotalExcludingCategoriesParams) ([]GetExpenseTotalExcludingCategoriesRow, error)
+	GetLatestCreatedTransaction(ctx context.Context, userID int64) (GetLatestCreatedTransactionRow, error)
+	GetTagTotalsByYear(ctx context.Context, year string) ([]GetTagTotalsByYearRow, error)
 	GetTopUsedCategories(ctx context.Context, arg GetTopUsedCategoriesParams) ([]GetTopUsedCategoriesRow, error)
+	GetMonthBoundsByYear(ctx context.Context, year string) ([]GetMonthBoundsByYearRow, error)
+	GetMonthlyTotalsByFiscalYear(ctx context.Context, arg GetMonthlyTotalsByFiscalYearParams) ([]GetMonthlyTotalsByFiscalYearRow, error)
+	GetMonthlyTotalsAllYears(ctx context.Context) ([]GetMonthlyTotalsAllYearsRow, error)
 	GetMonthlyTotalsByYear(ctx context.Context, dollar_1 string) ([]GetMonthlyTotalsByYearRow, error)
+	GetSetting(ctx context.Context, key string) (string, error)
+	GetTotalsByWeekday(ctx context.Context, year string) ([]GetTotalsByWeekdayRow, error)
+	GetTransactionByID(ctx context.Context, arg GetTransactionByIDParams) (GetTransactionByIDRow, error)
+	GetTransactionCountsByCurrency(ctx context.Context) ([]GetTransactionCountsByCurrencyRow, error)
 	GetUser(ctx context.Context, id int64) (User, error)
+	GetWeeklyExpenseTotals(ctx context.Context, arg GetWeeklyExpenseTotalsParams) ([]GetWeeklyExpenseTotalsRow, error)
+	HardDeleteTransaction(ctx context.Context, arg HardDeleteTransactionParams) error
 	ListAllTransactionsForExport(ctx context.Context) ([]ListAllTransactionsForExportRow, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error)
+	ListBudgetStatusByMonth(ctx context.Context, month string) ([]ListBudgetStatusByMonthRow, error)
 	ListCategories(ctx context.Context) ([]Category, error)
+	ListLargestTransactions(ctx context.Context, arg ListLargestTransactionsParams) ([]ListLargestTransactionsRow, error)
+	ListLargestTransactionsByMonth(ctx context.Context, arg ListLargestTransactionsByMonthParams) ([]ListLargestTransactionsByMonthRow, error)
+	ListRecentDeletedTransactions(ctx context.Context) ([]ListRecentDeletedTransactionsRow, error)
 	ListRecentTransactions(ctx context.Context) ([]ListRecentTransactionsRow, error)
+	ListRecurringTransactionsByMonth(ctx context.Context, arg ListRecurringTransactionsByMonthParams) ([]ListRecurringTransactionsByMonthRow, error)
+	ListTransactionActivity(ctx context.Context, day string) ([]ListTransactionActivityRow, error)
+	ListTransactionHistory(ctx context.Context, transactionID int64) ([]TransactionHistory, error)
+	ListTransactionsByFiscalYear(ctx context.Context, arg ListTransactionsByFiscalYearParams) ([]ListTransactionsByFiscalYearRow, error)
 	ListTransactionsByYear(ctx context.Context, dollar_1 string) ([]ListTransactionsByYearRow, error)
 	ListTransactionsByYearPaginated(ctx context.Context, arg ListTransactionsByYearPaginatedParams) ([]ListTransactionsByYearPaginatedRow, error)
 	ListTransactionsByYearPaginatedWithDeleted(ctx context.Context, arg ListTransactionsByYearPaginatedWithDeletedParams) ([]ListTransactionsByYearPaginatedWithDeletedRow, error)
+	ListTransactionsInCategory(ctx context.Context, categoryName string) ([]ListTransactionsInCategoryRow, error)
 	ListUsers(ctx context.Context) ([]User, error)
+	ReassignCategoryTransactions(ctx context.Context, arg ReassignCategoryTransactionsParams) (int64, error)
 	RestoreTransaction(ctx context.Context, arg RestoreTransactionParams) error
 	SearchTransactionsForRemoval(ctx context.Context, arg SearchTransactionsForRemovalParams) ([]SearchTransactionsForRemovalRow, error)
+	SetBudget(ctx context.Context, arg SetBudgetParams) (Budget, error)
+	SetSetting(ctx context.Context, arg SetSettingParams) error
+	SetTransactionReceipt(ctx context.Context, arg SetTransactionReceiptParams) error
 	SoftDeleteTransaction(ctx context.Context, arg SoftDeleteTransactionParams) error
+	SumAllTransactionsUpToDate(ctx context.Context, date string) (int64, error)
+	SumExpensesMonthToDate(ctx context.Context, arg SumExpensesMonthToDateParams) (int64, error)
+	TagTransaction(ctx context.Context, arg TagTransactionParams) (int64, error)
+	UpdateTransactionAmount(ctx context.Context, arg UpdateTransactionAmountParams) error
+	UpdateTransactionCategory(ctx context.Context, arg UpdateTransactionCategoryParams) error
+	UpdateTransactionCurrency(ctx context.Context, arg UpdateTransactionCurrencyParams) error
 }
 
 var _ Querier = (*Queries)(nil)
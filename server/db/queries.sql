@@ -8,9 +8,10 @@ ORDER BY name;
 
 -- name: CreateTransaction :one
 INSERT INTO transactions (
-  user_id, category_id, amount, currency, description, date
+  user_id, category_id, amount, currency, description, date, uid
 ) VALUES (
-  ?, ?, ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?,
+  COALESCE(?, lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + abs(random()) % 4, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))))
 )
 RETURNING *;
 
@@ -23,6 +24,24 @@ WHERE t.deleted_at IS NULL
 ORDER BY t.date DESC
 LIMIT 20;
 
+-- name: ListLargestTransactionsByMonth :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(sqlc.arg(month) AS TEXT)
+AND t.deleted_at IS NULL
+ORDER BY ABS(t.amount) DESC, t.id
+LIMIT sqlc.arg(limit);
+
+-- name: ListRecentDeletedTransactions :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE t.deleted_at IS NOT NULL
+ORDER BY t.deleted_at DESC, t.id DESC
+LIMIT 20;
+
 -- name: GetCategoryByName :one
 SELECT * FROM categories
 WHERE name = ? LIMIT 1;
@@ -46,6 +65,15 @@ WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
 AND t.deleted_at IS NULL
 ORDER BY t.date DESC;
 
+-- name: ListTransactionsInCategory :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE c.name = sqlc.arg(category_name)
+AND t.deleted_at IS NULL
+ORDER BY t.date DESC, t.id DESC;
+
 -- name: ListTransactionsByYearPaginated :many
 SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
 FROM transactions t
@@ -62,6 +90,31 @@ FROM transactions t
 WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
 AND t.deleted_at IS NULL;
 
+-- name: GetAverageAmountByCategory :many
+SELECT
+    c.name as category_name,
+    CAST(ROUND(COALESCE(AVG(ABS(t.amount)), 0)) AS INTEGER) as avg_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT) AND t.deleted_at IS NULL
+WHERE c.type = 'expense'
+GROUP BY c.id, c.name
+ORDER BY avg_amount DESC, c.name;
+
+-- name: GetCategoryTotalsAllTime :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    c.color as category_color,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at IS NULL
+GROUP BY c.id, c.name, c.icon, c.type, c.color
+ORDER BY c.type, total_amount DESC;
+
 -- name: GetCategoryTotalsByYear :many
 SELECT
     c.id as category_id,
@@ -73,9 +126,53 @@ SELECT
     COUNT(t.id) as transaction_count
 FROM categories c
 LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y', t.date) = CAST(? AS TEXT) AND t.deleted_at IS NULL
+WHERE c.type != 'transfer'
 GROUP BY c.id, c.name, c.icon, c.type, c.color
 ORDER BY c.type, total_amount DESC;
 
+-- name: GetCategoryTotalsByMonth :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+JOIN transactions t ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(sqlc.arg(month) AS TEXT)
+AND t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY c.id, c.name, c.icon, c.type
+ORDER BY c.type, total_amount DESC;
+
+-- name: GetExpenseTotalExcludingCategories :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+JOIN transactions t ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+AND t.deleted_at IS NULL
+AND instr(',' || CAST(sqlc.arg(excluded) AS TEXT) || ',', ',' || c.name || ',') = 0
+GROUP BY c.id, c.name
+ORDER BY total_amount DESC, c.name;
+
+-- name: GetMonthBoundsByYear :many
+SELECT
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    CAST(strftime('%Y-%m-%d', MIN(date)) AS TEXT) as first_date,
+    CAST(strftime('%Y-%m-%d', MAX(date)) AS TEXT) as last_date,
+    COUNT(*) as transaction_count
+FROM transactions
+WHERE strftime('%Y', date) = CAST(sqlc.arg(year) AS TEXT)
+AND deleted_at IS NULL
+GROUP BY month
+ORDER BY month;
+
 -- name: GetMonthlyTotalsByYear :many
 SELECT
     CAST(strftime('%m', date) AS INTEGER) as month,
@@ -85,9 +182,23 @@ FROM transactions t
 JOIN categories c ON t.category_id = c.id
 WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
 AND t.deleted_at IS NULL
+AND c.type != 'transfer'
 GROUP BY month, c.type
 ORDER BY month;
 
+-- name: GetMonthlyTotalsAllYears :many
+SELECT
+    CAST(strftime('%Y', date) AS INTEGER) as year,
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY year, month, c.type
+ORDER BY year, month;
+
 -- name: DeleteTransaction :exec
 DELETE FROM transactions
 WHERE id = ? AND user_id = ?;
@@ -97,6 +208,22 @@ UPDATE transactions
 SET deleted_at = CURRENT_TIMESTAMP
 WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
 
+-- name: HardDeleteTransaction :exec
+DELETE FROM transactions
+WHERE id = ? AND user_id = ?;
+
+-- name: DeleteTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id = ?;
+
+-- name: DeleteTransactionHistory :exec
+DELETE FROM transaction_history
+WHERE transaction_id = ?;
+
+-- name: DeleteAuditEntriesForEntity :exec
+DELETE FROM audit_log
+WHERE entity_id = ?;
+
 -- name: RestoreTransaction :exec
 UPDATE transactions
 SET deleted_at = NULL
@@ -106,13 +233,13 @@ WHERE id = ? AND user_id = ?;
 SELECT COUNT(*) as count FROM transactions WHERE deleted_at IS NULL;
 
 -- name: ListAllTransactionsForExport :many
-SELECT t.id, t.amount, t.currency, t.description, t.date, c.name as category_name, c.type as category_type
+SELECT t.id, t.amount, t.currency, t.description, t.date, t.receipt_path, t.uid, c.name as category_name, c.type as category_type
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
 WHERE t.deleted_at IS NULL
 ORDER BY t.date DESC;
 
--- name: DeleteAllTransactions :exec
+-- name: DeleteAllTransactions :execrows
 DELETE FROM transactions;
 
 -- name: SearchTransactionsForRemoval :many
@@ -140,6 +267,13 @@ SELECT COUNT(*) as count
 FROM transactions t
 WHERE strftime('%Y', t.date) = CAST(? AS TEXT);
 
+-- name: CountDeletedTransactionsByFiscalYear :one
+SELECT COUNT(*) as count
+FROM transactions t
+WHERE date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+AND t.deleted_at IS NOT NULL;
+
 -- name: GetTopUsedCategories :many
 SELECT c.id, c.name, c.type, c.icon, c.color, COUNT(t.id) as usage_count
 FROM categories c
@@ -147,3 +281,336 @@ LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at IS NULL AND t.
 GROUP BY c.id, c.name, c.type, c.icon, c.color
 ORDER BY usage_count DESC, c.name ASC
 LIMIT ?;
+
+-- name: GetBudgetByCategory :one
+SELECT * FROM budgets
+WHERE category_id = ? LIMIT 1;
+
+-- name: SetBudget :one
+INSERT INTO budgets (category_id, limit_cents, hard_limit_cents) VALUES (?, ?, ?)
+ON CONFLICT(category_id) DO UPDATE SET
+    limit_cents = excluded.limit_cents,
+    hard_limit_cents = excluded.hard_limit_cents
+RETURNING *;
+
+-- name: GetCategorySpendForMonth :one
+SELECT CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+FROM transactions
+WHERE category_id = sqlc.arg(category_id)
+AND strftime('%Y-%m', date) = CAST(sqlc.arg(month) AS TEXT)
+AND deleted_at IS NULL;
+
+-- name: ListBudgetStatusByMonth :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    b.limit_cents,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as spent
+FROM budgets b
+JOIN categories c ON c.id = b.category_id
+LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y-%m', t.date) = CAST(sqlc.arg(month) AS TEXT) AND t.deleted_at IS NULL
+GROUP BY b.id, c.id, c.name, b.limit_cents
+ORDER BY c.name;
+
+-- name: GetTransactionByID :one
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE t.id = ? AND t.user_id = ?
+LIMIT 1;
+
+-- name: UpdateTransactionCategory :exec
+UPDATE transactions
+SET category_id = ?, amount = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: GetDistinctYearMonths :many
+SELECT DISTINCT CAST(strftime('%Y', date) AS INTEGER) as year, CAST(strftime('%m', date) AS INTEGER) as month
+FROM transactions
+WHERE deleted_at IS NULL
+ORDER BY year DESC, month DESC;
+
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (action, entity_id, details)
+VALUES (?, ?, ?);
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log
+WHERE id < sqlc.arg(before)
+ORDER BY id DESC
+LIMIT sqlc.arg(limit);
+
+-- name: DeleteAuditEntriesBefore :execrows
+DELETE FROM audit_log
+WHERE created_at < ?;
+
+-- name: UpdateTransactionAmount :exec
+UPDATE transactions
+SET amount = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: UpdateTransactionCurrency :exec
+UPDATE transactions
+SET currency = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: CreateTransactionHistory :exec
+INSERT INTO transaction_history (transaction_id, field, old_value, new_value)
+VALUES (?, ?, ?, ?);
+
+-- name: ListTransactionHistory :many
+SELECT * FROM transaction_history
+WHERE transaction_id = ?
+ORDER BY id;
+
+-- name: SetTransactionReceipt :exec
+UPDATE transactions
+SET receipt_path = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL;
+
+-- name: ListLargestTransactions :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+AND c.type = sqlc.arg(type)
+AND t.deleted_at IS NULL
+ORDER BY ABS(t.amount) DESC
+LIMIT sqlc.arg(limit);
+
+-- name: ListTransactionActivity :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE CAST(sqlc.arg(day) AS TEXT) IN (date(t.created_at), date(t.deleted_at))
+ORDER BY t.id;
+
+-- name: GetDailyTotalsByMonth :many
+SELECT
+    CAST(strftime('%d', t.date) AS INTEGER) as day,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND strftime('%Y-%m', t.date) = CAST(sqlc.arg(month) AS TEXT)
+GROUP BY day
+ORDER BY day;
+
+-- name: GetTotalsByWeekday :many
+SELECT
+    CAST(strftime('%w', t.date) AS INTEGER) as weekday,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+GROUP BY weekday
+ORDER BY weekday;
+
+-- name: GetWeeklyExpenseTotals :many
+SELECT
+    CAST((julianday(date(t.date)) - julianday(CAST(sqlc.arg(start) AS TEXT))) / 7 AS INTEGER) as week,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+GROUP BY week
+ORDER BY week;
+
+-- name: ListTransactionsByFiscalYear :many
+SELECT t.*, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+JOIN (SELECT CAST(sqlc.arg(sort) AS TEXT) AS sort) o
+WHERE date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+AND (CAST(sqlc.arg(include_deleted) AS BOOLEAN) OR t.deleted_at IS NULL)
+ORDER BY
+    CASE o.sort WHEN 'amount_asc' THEN ABS(t.amount) END ASC,
+    CASE o.sort WHEN 'amount_desc' THEN ABS(t.amount) END DESC,
+    CASE o.sort WHEN 'date_asc' THEN t.date END ASC,
+    t.date DESC
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CountTransactionsByFiscalYear :one
+SELECT COUNT(*) as count
+FROM transactions t
+WHERE date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+AND (CAST(sqlc.arg(include_deleted) AS BOOLEAN) OR t.deleted_at IS NULL);
+
+-- name: GetCategoryTotalsByFiscalYear :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    c.color as category_color,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id
+    AND date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+    AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+    AND t.deleted_at IS NULL
+WHERE c.type != 'transfer'
+GROUP BY c.id, c.name, c.icon, c.type, c.color
+ORDER BY c.type, total_amount DESC;
+
+-- name: GetMonthlyTotalsByFiscalYear :many
+SELECT
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE date(t.date) >= CAST(sqlc.arg(start) AS TEXT)
+AND date(t.date) < CAST(sqlc.arg(end) AS TEXT)
+AND t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY month, c.type
+ORDER BY month;
+
+-- name: GetTransactionCountsByCurrency :many
+SELECT
+    t.currency,
+    CAST(COUNT(*) AS INTEGER) as count,
+    CAST(COALESCE(SUM(t.amount), 0) AS INTEGER) as total_amount
+FROM transactions t
+WHERE t.deleted_at IS NULL
+GROUP BY t.currency
+ORDER BY count DESC, t.currency;
+
+-- name: GetLatestCreatedTransaction :one
+SELECT t.id, t.amount, t.description, c.name as category_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE t.user_id = ? AND t.deleted_at IS NULL
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT 1;
+
+-- name: ReassignCategoryTransactions :execrows
+UPDATE transactions
+SET category_id = sqlc.arg(to_category_id),
+    amount = CASE WHEN CAST(sqlc.arg(to_type) AS TEXT) = 'expense' THEN -ABS(amount) ELSE ABS(amount) END
+WHERE category_id = sqlc.arg(from_category_id) AND user_id = sqlc.arg(user_id) AND deleted_at IS NULL;
+
+-- name: CountTransactionsByUID :one
+SELECT COUNT(*) as count FROM transactions
+WHERE uid = ?;
+
+-- name: CountTransactionsByContent :one
+SELECT COUNT(*) as count FROM transactions
+WHERE amount = sqlc.arg(amount)
+AND description = sqlc.arg(description)
+AND datetime(date) = datetime(sqlc.arg(date));
+
+-- name: AddTransactionTag :exec
+INSERT OR IGNORE INTO transaction_tags (transaction_id, tag)
+VALUES (?, ?);
+
+-- name: TagTransaction :execrows
+INSERT OR IGNORE INTO transaction_tags (transaction_id, tag)
+SELECT id, sqlc.arg(tag) FROM transactions
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) AND deleted_at IS NULL;
+
+-- name: GetTagTotalsByYear :many
+SELECT
+    tt.tag,
+    COUNT(t.id) as transaction_count,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transaction_tags tt
+JOIN transactions t ON t.id = tt.transaction_id
+WHERE strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+AND t.deleted_at IS NULL
+GROUP BY tt.tag
+ORDER BY total_amount DESC, tt.tag;
+
+-- name: ListRecurringTransactionsByMonth :many
+SELECT t.*, c.name as category_name, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(sqlc.arg(source_month) AS TEXT)
+AND t.deleted_at IS NULL
+AND (
+    EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag = CAST(sqlc.arg(tag) AS TEXT))
+    OR (' ' || LOWER(t.description) || ' ') GLOB ('*[^a-z0-9_]#' || CAST(sqlc.arg(tag) AS TEXT) || '[^a-z0-9_]*')
+)
+AND NOT EXISTS (
+    SELECT 1 FROM transactions copy
+    WHERE strftime('%Y-%m', copy.date) = CAST(sqlc.arg(target_month) AS TEXT)
+    AND copy.deleted_at IS NULL
+    AND copy.description = t.description
+    AND copy.amount = t.amount
+    AND copy.category_id = t.category_id
+)
+ORDER BY t.date, t.id;
+
+-- name: FindDuplicateGroups :many
+SELECT
+    amount,
+    description,
+    COUNT(*) as transaction_count,
+    CAST(GROUP_CONCAT(id) AS TEXT) as transaction_ids
+FROM (
+    SELECT id, amount, description
+    FROM transactions
+    WHERE deleted_at IS NULL
+    ORDER BY id
+)
+GROUP BY amount, description
+HAVING COUNT(*) > 1
+ORDER BY transaction_count DESC, amount, description;
+
+-- name: SumExpensesMonthToDate :one
+SELECT CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+AND CAST(strftime('%m', t.date) AS INTEGER) = sqlc.arg(month)
+AND CAST(strftime('%d', t.date) AS INTEGER) <= sqlc.arg(day)
+AND c.type = 'expense'
+AND t.deleted_at IS NULL;
+
+-- name: SumAllTransactionsUpToDate :one
+SELECT CAST(COALESCE(SUM(
+    CASE c.type
+        WHEN 'income' THEN ABS(t.amount)
+        WHEN 'expense' THEN -ABS(t.amount)
+        ELSE 0
+    END
+), 0) AS INTEGER) as balance
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE date(t.date) <= CAST(sqlc.arg(date) AS TEXT)
+AND t.deleted_at IS NULL;
+
+-- name: GetBusiestDays :many
+SELECT
+    CAST(strftime('%Y-%m-%d', t.date) AS TEXT) as day,
+    COUNT(*) as transaction_count,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND strftime('%Y', t.date) = CAST(sqlc.arg(year) AS TEXT)
+GROUP BY day
+ORDER BY transaction_count DESC, total_amount DESC, day
+LIMIT sqlc.arg(limit);
+
+-- name: GetSetting :one
+SELECT value FROM settings
+WHERE key = ?;
+
+-- name: SetSetting :exec
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value;
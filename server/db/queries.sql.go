@@ -11,6 +11,21 @@ import (
 	"time"
 )
 
+const addTransactionTag = `-- name: AddTransactionTag :exec
+INSERT OR IGNORE INTO transaction_tags (transaction_id, tag)
+VALUES (?, ?)
+`
+
+type AddTransactionTagParams struct {
+	TransactionID int64  `json:"transaction_id"`
+	Tag           string `json:"tag"`
+}
+
+func (q *Queries) AddTransactionTag(ctx context.Context, arg AddTransactionTagParams) error {
+	_, err := q.exec(ctx, nil, addTransactionTag, arg.TransactionID, arg.Tag)
+	return err
+}
+
 const countAllTransactions = `-- name: CountAllTransactions :one
 SELECT COUNT(*) as count FROM transactions WHERE deleted_at IS NULL
 `
@@ -22,6 +37,26 @@ func (q *Queries) CountAllTransactions(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countDeletedTransactionsByFiscalYear = `-- name: CountDeletedTransactionsByFiscalYear :one
+SELECT COUNT(*) as count
+FROM transactions t
+WHERE date(t.date) >= CAST(? AS TEXT)
+AND date(t.date) < CAST(? AS TEXT)
+AND t.deleted_at IS NOT NULL
+`
+
+type CountDeletedTransactionsByFiscalYearParams struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+func (q *Queries) CountDeletedTransactionsByFiscalYear(ctx context.Context, arg CountDeletedTransactionsByFiscalYearParams) (int64, error) {
+	row := q.queryRow(ctx, nil, countDeletedTransactionsByFiscalYear, arg.Start, arg.End)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countTransactionsByYear = `-- name: CountTransactionsByYear :one
 SELECT COUNT(*) as count
 FROM transactions t
@@ -51,20 +86,22 @@ func (q *Queries) CountTransactionsByYearWithDeleted(ctx context.Context, dollar
 
 const createTransaction = `-- name: CreateTransaction :one
 INSERT INTO transactions (
-  user_id, category_id, amount, currency, description, date
+  user_id, category_id, amount, currency, description, date, uid
 ) VALUES (
-  ?, ?, ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?,
+  COALESCE(?, lower(hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' || substr(hex(randomblob(2)), 2) || '-' || substr('89AB', 1 + abs(random()) % 4, 1) || substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))))
 )
-RETURNING id, user_id, category_id, amount, currency, description, date, created_at, deleted_at
+RETURNING id, user_id, category_id, amount, currency, description, date, created_at, deleted_at, receipt_path, uid
 `
 
 type CreateTransactionParams struct {
-	UserID      int64     `json:"user_id"`
-	CategoryID  int64     `json:"category_id"`
-	Amount      int64     `json:"amount"`
-	Currency    string    `json:"currency"`
-	Description string    `json:"description"`
-	Date        time.Time `json:"date"`
+	UserID      int64          `json:"user_id"`
+	CategoryID  int64          `json:"category_id"`
+	Amount      int64          `json:"amount"`
+	Currency    string         `json:"currency"`
+	Description string         `json:"description"`
+	Date        time.Time      `json:"date"`
+	Uid         sql.NullString `json:"uid"`
 }
 
 func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error) {
@@ -75,6 +112,7 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		arg.Currency,
 		arg.Description,
 		arg.Date,
+		arg.Uid,
 	)
 	var i Transaction
 	err := row.Scan(
@@ -87,17 +125,22 @@ func (q *Queries) CreateTransaction(ctx context.Context, arg CreateTransactionPa
 		&i.Date,
 		&i.CreatedAt,
 		&i.DeletedAt,
+		&i.ReceiptPath,
+		&i.Uid,
 	)
 	return i, err
 }
 
-const deleteAllTransactions = `-- name: DeleteAllTransactions :exec
+const deleteAllTransactions = `-- name: DeleteAllTransactions :execrows
 DELETE FROM transactions
 `
 
-func (q *Queries) DeleteAllTransactions(ctx context.Context) error {
-	_, err := q.exec(ctx, q.deleteAllTransactionsStmt, deleteAllTransactions)
-	return err
+func (q *Queries) DeleteAllTransactions(ctx context.Context) (int64, error) {
+	result, err := q.exec(ctx, q.deleteAllTransactionsStmt, deleteAllTransactions)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const deleteTransaction = `-- name: DeleteTransaction :exec
@@ -115,6 +158,99 @@ func (q *Queries) DeleteTransaction(ctx context.Context, arg DeleteTransactionPa
 	return err
 }
 
+const findDuplicateGroups = `-- name: FindDuplicateGroups :many
+SELECT
+    amount,
+    description,
+    COUNT(*) as transaction_count,
+    CAST(GROUP_CONCAT(id) AS TEXT) as transaction_ids
+FROM (
+    SELECT id, amount, description
+    FROM transactions
+    WHERE deleted_at IS NULL
+    ORDER BY id
+)
+GROUP BY amount, description
+HAVING COUNT(*) > 1
+ORDER BY transaction_count DESC, amount, description
+`
+
+type FindDuplicateGroupsRow struct {
+	Amount           int64  `json:"amount"`
+	Description      string `json:"description"`
+	TransactionCount int64  `json:"transaction_count"`
+	TransactionIds   string `json:"transaction_ids"`
+}
+
+func (q *Queries) FindDuplicateGroups(ctx context.Context) ([]FindDuplicateGroupsRow, error) {
+	rows, err := q.query(ctx, nil, findDuplicateGroups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindDuplicateGroupsRow
+	for rows.Next() {
+		var i FindDuplicateGroupsRow
+		if err := rows.Scan(
+			&i.Amount,
+			&i.Description,
+			&i.TransactionCount,
+			&i.TransactionIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAverageAmountByCategory = `-- name: GetAverageAmountByCategory :many
+SELECT
+    c.name as category_name,
+    CAST(ROUND(COALESCE(AVG(ABS(t.amount)), 0)) AS INTEGER) as avg_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y', t.date) = CAST(? AS TEXT) AND t.deleted_at IS NULL
+WHERE c.type = 'expense'
+GROUP BY c.id, c.name
+ORDER BY avg_amount DESC, c.name
+`
+
+type GetAverageAmountByCategoryRow struct {
+	CategoryName     string `json:"category_name"`
+	AvgAmount        int64  `json:"avg_amount"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+func (q *Queries) GetAverageAmountByCategory(ctx context.Context, year string) ([]GetAverageAmountByCategoryRow, error) {
+	rows, err := q.query(ctx, nil, getAverageAmountByCategory, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAverageAmountByCategoryRow
+	for rows.Next() {
+		var i GetAverageAmountByCategoryRow
+		if err := rows.Scan(&i.CategoryName, &i.AvgAmount, &i.TransactionCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCategoryByName = `-- name: GetCategoryByName :one
 SELECT id, name, type, icon, color FROM categories
 WHERE name = ? LIMIT 1
@@ -133,6 +269,62 @@ func (q *Queries) GetCategoryByName(ctx context.Context, name string) (Category,
 	return i, err
 }
 
+const getCategoryTotalsByMonth = `-- name: GetCategoryTotalsByMonth :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+JOIN transactions t ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY c.id, c.name, c.icon, c.type
+ORDER BY c.type, total_amount DESC
+`
+
+type GetCategoryTotalsByMonthRow struct {
+	CategoryID       int64          `json:"category_id"`
+	CategoryName     string         `json:"category_name"`
+	CategoryIcon     sql.NullString `json:"category_icon"`
+	CategoryType     string         `json:"category_type"`
+	TotalAmount      int64          `json:"total_amount"`
+	TransactionCount int64          `json:"transaction_count"`
+}
+
+func (q *Queries) GetCategoryTotalsByMonth(ctx context.Context, month string) ([]GetCategoryTotalsByMonthRow, error) {
+	rows, err := q.query(ctx, nil, getCategoryTotalsByMonth, month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCategoryTotalsByMonthRow
+	for rows.Next() {
+		var i GetCategoryTotalsByMonthRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.TotalAmount,
+			&i.TransactionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getCategoryTotalsByYear = `-- name: GetCategoryTotalsByYear :many
 SELECT
     c.id as category_id,
@@ -144,6 +336,7 @@ SELECT
     COUNT(t.id) as transaction_count
 FROM categories c
 LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y', t.date) = CAST(? AS TEXT) AND t.deleted_at IS NULL
+WHERE c.type != 'transfer'
 GROUP BY c.id, c.name, c.icon, c.type, c.color
 ORDER BY c.type, total_amount DESC
 `
@@ -189,26 +382,45 @@ func (q *Queries) GetCategoryTotalsByYear(ctx context.Context, dollar_1 string)
 	return items, nil
 }
 
-const getDistinctTransactionYears = `-- name: GetDistinctTransactionYears :many
-SELECT DISTINCT CAST(strftime('%Y', date) AS INTEGER) as year
-FROM transactions
-WHERE deleted_at IS NULL
-ORDER BY year DESC
+const getBusiestDays = `-- name: GetBusiestDays :many
+SELECT
+    CAST(strftime('%Y-%m-%d', t.date) AS TEXT) as day,
+    COUNT(*) as transaction_count,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND strftime('%Y', t.date) = CAST(? AS TEXT)
+GROUP BY day
+ORDER BY transaction_count DESC, total_amount DESC, day
+LIMIT ?
 `
 
-func (q *Queries) GetDistinctTransactionYears(ctx context.Context) ([]int64, error) {
-	rows, err := q.query(ctx, q.getDistinctTransactionYearsStmt, getDistinctTransactionYears)
+type GetBusiestDaysParams struct {
+	Year  string `json:"year"`
+	Limit int64  `json:"limit"`
+}
+
+type GetBusiestDaysRow struct {
+	Day              string `json:"day"`
+	TransactionCount int64  `json:"transaction_count"`
+	TotalAmount      int64  `json:"total_amount"`
+}
+
+func (q *Queries) GetBusiestDays(ctx context.Context, arg GetBusiestDaysParams) ([]GetBusiestDaysRow, error) {
+	rows, err := q.query(ctx, nil, getBusiestDays, arg.Year, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []int64
+	var items []GetBusiestDaysRow
 	for rows.Next() {
-		var year int64
-		if err := rows.Scan(&year); err != nil {
+		var i GetBusiestDaysRow
+		if err := rows.Scan(&i.Day, &i.TransactionCount, &i.TotalAmount); err != nil {
 			return nil, err
 		}
-		items = append(items, year)
+		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -219,35 +431,34 @@ func (q *Queries) GetDistinctTransactionYears(ctx context.Context) ([]int64, err
 	return items, nil
 }
 
-const getMonthlyTotalsByYear = `-- name: GetMonthlyTotalsByYear :many
+const getDailyTotalsByMonth = `-- name: GetDailyTotalsByMonth :many
 SELECT
-    CAST(strftime('%m', date) AS INTEGER) as month,
-    c.type as category_type,
-    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+    CAST(strftime('%d', t.date) AS INTEGER) as day,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
-WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+WHERE c.type = 'expense'
 AND t.deleted_at IS NULL
-GROUP BY month, c.type
-ORDER BY month
+AND strftime('%Y-%m', t.date) = CAST(? AS TEXT)
+GROUP BY day
+ORDER BY day
 `
 
-type GetMonthlyTotalsByYearRow struct {
-	Month        int64  `json:"month"`
-	CategoryType string `json:"category_type"`
-	TotalAmount  int64  `json:"total_amount"`
+type GetDailyTotalsByMonthRow struct {
+	Day         int64 `json:"day"`
+	TotalAmount int64 `json:"total_amount"`
 }
 
-func (q *Queries) GetMonthlyTotalsByYear(ctx context.Context, dollar_1 string) ([]GetMonthlyTotalsByYearRow, error) {
-	rows, err := q.query(ctx, q.getMonthlyTotalsByYearStmt, getMonthlyTotalsByYear, dollar_1)
+func (q *Queries) GetDailyTotalsByMonth(ctx context.Context, month string) ([]GetDailyTotalsByMonthRow, error) {
+	rows, err := q.query(ctx, nil, getDailyTotalsByMonth, month)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetMonthlyTotalsByYearRow
+	var items []GetDailyTotalsByMonthRow
 	for rows.Next() {
-		var i GetMonthlyTotalsByYearRow
-		if err := rows.Scan(&i.Month, &i.CategoryType, &i.TotalAmount); err != nil {
+		var i GetDailyTotalsByMonthRow
+		if err := rows.Scan(&i.Day, &i.TotalAmount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -261,58 +472,78 @@ func (q *Queries) GetMonthlyTotalsByYear(ctx context.Context, dollar_1 string) (
 	return items, nil
 }
 
-const getUser = `-- name: GetUser :one
-SELECT id, name, email, created_at FROM users
-WHERE id = ? LIMIT 1
+const getDistinctTransactionYears = `-- name: GetDistinctTransactionYears :many
+SELECT DISTINCT CAST(strftime('%Y', date) AS INTEGER) as year
+FROM transactions
+WHERE deleted_at IS NULL
+ORDER BY year DESC
 `
 
-func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
-	row := q.queryRow(ctx, q.getUserStmt, getUser, id)
-	var i User
-	err := row.Scan(
-		&i.ID,
-		&i.Name,
-		&i.Email,
-		&i.CreatedAt,
-	)
-	return i, err
+func (q *Queries) GetDistinctTransactionYears(ctx context.Context) ([]int64, error) {
+	rows, err := q.query(ctx, q.getDistinctTransactionYearsStmt, getDistinctTransactionYears)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var year int64
+		if err := rows.Scan(&year); err != nil {
+			return nil, err
+		}
+		items = append(items, year)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const listAllTransactionsForExport = `-- name: ListAllTransactionsForExport :many
-SELECT t.id, t.amount, t.currency, t.description, t.date, c.name as category_name, c.type as category_type
-FROM transactions t
-JOIN categories c ON t.category_id = c.id
-WHERE t.deleted_at IS NULL
-ORDER BY t.date DESC
+const getExpenseTotalExcludingCategories = `-- name: GetExpenseTotalExcludingCategories :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+JOIN transactions t ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND strftime('%Y', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+AND instr(',' || CAST(? AS TEXT) || ',', ',' || c.name || ',') = 0
+GROUP BY c.id, c.name
+ORDER BY total_amount DESC, c.name
 `
 
-type ListAllTransactionsForExportRow struct {
-	ID           int64     `json:"id"`
-	Amount       int64     `json:"amount"`
-	Currency     string    `json:"currency"`
-	Description  string    `json:"description"`
-	Date         time.Time `json:"date"`
-	CategoryName string    `json:"category_name"`
-	CategoryType string    `json:"category_type"`
+type GetExpenseTotalExcludingCategoriesParams struct {
+	Year     string `json:"year"`
+	Excluded string `json:"excluded"`
 }
 
-func (q *Queries) ListAllTransactionsForExport(ctx context.Context) ([]ListAllTransactionsForExportRow, error) {
-	rows, err := q.query(ctx, q.listAllTransactionsForExportStmt, listAllTransactionsForExport)
+type GetExpenseTotalExcludingCategoriesRow struct {
+	CategoryID       int64  `json:"category_id"`
+	CategoryName     string `json:"category_name"`
+	TotalAmount      int64  `json:"total_amount"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+func (q *Queries) GetExpenseTotalExcludingCategories(ctx context.Context, arg GetExpenseTotalExcludingCategoriesParams) ([]GetExpenseTotalExcludingCategoriesRow, error) {
+	rows, err := q.query(ctx, nil, getExpenseTotalExcludingCategories, arg.Year, arg.Excluded)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListAllTransactionsForExportRow
+	var items []GetExpenseTotalExcludingCategoriesRow
 	for rows.Next() {
-		var i ListAllTransactionsForExportRow
+		var i GetExpenseTotalExcludingCategoriesRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Amount,
-			&i.Currency,
-			&i.Description,
-			&i.Date,
+			&i.CategoryID,
 			&i.CategoryName,
-			&i.CategoryType,
+			&i.TotalAmount,
+			&i.TransactionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -327,26 +558,40 @@ func (q *Queries) ListAllTransactionsForExport(ctx context.Context) ([]ListAllTr
 	return items, nil
 }
 
-const listCategories = `-- name: ListCategories :many
-SELECT id, name, type, icon, color FROM categories
-ORDER BY type, name
+const getMonthBoundsByYear = `-- name: GetMonthBoundsByYear :many
+SELECT
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    CAST(strftime('%Y-%m-%d', MIN(date)) AS TEXT) as first_date,
+    CAST(strftime('%Y-%m-%d', MAX(date)) AS TEXT) as last_date,
+    COUNT(*) as transaction_count
+FROM transactions
+WHERE strftime('%Y', date) = CAST(? AS TEXT)
+AND deleted_at IS NULL
+GROUP BY month
+ORDER BY month
 `
 
-func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
-	rows, err := q.query(ctx, q.listCategoriesStmt, listCategories)
+type GetMonthBoundsByYearRow struct {
+	Month            int64  `json:"month"`
+	FirstDate        string `json:"first_date"`
+	LastDate         string `json:"last_date"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+func (q *Queries) GetMonthBoundsByYear(ctx context.Context, year string) ([]GetMonthBoundsByYearRow, error) {
+	rows, err := q.query(ctx, nil, getMonthBoundsByYear, year)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Category
+	var items []GetMonthBoundsByYearRow
 	for rows.Next() {
-		var i Category
+		var i GetMonthBoundsByYearRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Type,
-			&i.Icon,
-			&i.Color,
+			&i.Month,
+			&i.FirstDate,
+			&i.LastDate,
+			&i.TransactionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -361,54 +606,36 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	return items, nil
 }
 
-const listRecentTransactions = `-- name: ListRecentTransactions :many
-SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, c.name as category_name, c.icon as category_icon, u.name as user_name
+const getMonthlyTotalsByYear = `-- name: GetMonthlyTotalsByYear :many
+SELECT
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
-JOIN users u ON t.user_id = u.id
-WHERE t.deleted_at IS NULL
-ORDER BY t.date DESC
-LIMIT 20
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY month, c.type
+ORDER BY month
 `
 
-type ListRecentTransactionsRow struct {
-	ID           int64          `json:"id"`
-	UserID       int64          `json:"user_id"`
-	CategoryID   int64          `json:"category_id"`
-	Amount       int64          `json:"amount"`
-	Currency     string         `json:"currency"`
-	Description  string         `json:"description"`
-	Date         time.Time      `json:"date"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	DeletedAt    sql.NullTime   `json:"deleted_at"`
-	CategoryName string         `json:"category_name"`
-	CategoryIcon sql.NullString `json:"category_icon"`
-	UserName     string         `json:"user_name"`
+type GetMonthlyTotalsByYearRow struct {
+	Month        int64  `json:"month"`
+	CategoryType string `json:"category_type"`
+	TotalAmount  int64  `json:"total_amount"`
 }
 
-func (q *Queries) ListRecentTransactions(ctx context.Context) ([]ListRecentTransactionsRow, error) {
-	rows, err := q.query(ctx, q.listRecentTransactionsStmt, listRecentTransactions)
+func (q *Queries) GetMonthlyTotalsByYear(ctx context.Context, dollar_1 string) ([]GetMonthlyTotalsByYearRow, error) {
+	rows, err := q.query(ctx, q.getMonthlyTotalsByYearStmt, getMonthlyTotalsByYear, dollar_1)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListRecentTransactionsRow
+	var items []GetMonthlyTotalsByYearRow
 	for rows.Next() {
-		var i ListRecentTransactionsRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.CategoryID,
-			&i.Amount,
-			&i.Currency,
-			&i.Description,
-			&i.Date,
-			&i.CreatedAt,
-			&i.DeletedAt,
-			&i.CategoryName,
-			&i.CategoryIcon,
-			&i.UserName,
-		); err != nil {
+		var i GetMonthlyTotalsByYearRow
+		if err := rows.Scan(&i.Month, &i.CategoryType, &i.TotalAmount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -422,55 +649,41 @@ func (q *Queries) ListRecentTransactions(ctx context.Context) ([]ListRecentTrans
 	return items, nil
 }
 
-const listTransactionsByYear = `-- name: ListTransactionsByYear :many
-SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+const getMonthlyTotalsAllYears = `-- name: GetMonthlyTotalsAllYears :many
+SELECT
+    CAST(strftime('%Y', date) AS INTEGER) as year,
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
-JOIN users u ON t.user_id = u.id
-WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
-AND t.deleted_at IS NULL
-ORDER BY t.date DESC
+WHERE t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY year, month, c.type
+ORDER BY year, month
 `
 
-type ListTransactionsByYearRow struct {
-	ID           int64          `json:"id"`
-	UserID       int64          `json:"user_id"`
-	CategoryID   int64          `json:"category_id"`
-	Amount       int64          `json:"amount"`
-	Currency     string         `json:"currency"`
-	Description  string         `json:"description"`
-	Date         time.Time      `json:"date"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	DeletedAt    sql.NullTime   `json:"deleted_at"`
-	CategoryName string         `json:"category_name"`
-	CategoryIcon sql.NullString `json:"category_icon"`
-	CategoryType string         `json:"category_type"`
-	UserName     string         `json:"user_name"`
+type GetMonthlyTotalsAllYearsRow struct {
+	Year         int64  `json:"year"`
+	Month        int64  `json:"month"`
+	CategoryType string `json:"category_type"`
+	TotalAmount  int64  `json:"total_amount"`
 }
 
-func (q *Queries) ListTransactionsByYear(ctx context.Context, dollar_1 string) ([]ListTransactionsByYearRow, error) {
-	rows, err := q.query(ctx, q.listTransactionsByYearStmt, listTransactionsByYear, dollar_1)
+func (q *Queries) GetMonthlyTotalsAllYears(ctx context.Context) ([]GetMonthlyTotalsAllYearsRow, error) {
+	rows, err := q.query(ctx, nil, getMonthlyTotalsAllYears)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTransactionsByYearRow
+	var items []GetMonthlyTotalsAllYearsRow
 	for rows.Next() {
-		var i ListTransactionsByYearRow
+		var i GetMonthlyTotalsAllYearsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.CategoryID,
-			&i.Amount,
-			&i.Currency,
-			&i.Description,
-			&i.Date,
-			&i.CreatedAt,
-			&i.DeletedAt,
-			&i.CategoryName,
-			&i.CategoryIcon,
+			&i.Year,
+			&i.Month,
 			&i.CategoryType,
-			&i.UserName,
+			&i.TotalAmount,
 		); err != nil {
 			return nil, err
 		}
@@ -485,63 +698,78 @@ func (q *Queries) ListTransactionsByYear(ctx context.Context, dollar_1 string) (
 	return items, nil
 }
 
-const listTransactionsByYearPaginated = `-- name: ListTransactionsByYearPaginated :many
-SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
-FROM transactions t
-JOIN categories c ON t.category_id = c.id
-JOIN users u ON t.user_id = u.id
+const getTagTotalsByYear = `-- name: GetTagTotalsByYear :many
+SELECT
+    tt.tag,
+    COUNT(t.id) as transaction_count,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transaction_tags tt
+JOIN transactions t ON t.id = tt.transaction_id
 WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
 AND t.deleted_at IS NULL
-ORDER BY t.date DESC
-LIMIT ? OFFSET ?
+GROUP BY tt.tag
+ORDER BY total_amount DESC, tt.tag
 `
 
-type ListTransactionsByYearPaginatedParams struct {
-	Year   string
-	Limit  int64
-	Offset int64
+type GetTagTotalsByYearRow struct {
+	Tag              string `json:"tag"`
+	TransactionCount int64  `json:"transaction_count"`
+	TotalAmount      int64  `json:"total_amount"`
 }
 
-type ListTransactionsByYearPaginatedRow struct {
-	ID           int64          `json:"id"`
-	UserID       int64          `json:"user_id"`
-	CategoryID   int64          `json:"category_id"`
-	Amount       int64          `json:"amount"`
-	Currency     string         `json:"currency"`
-	Description  string         `json:"description"`
-	Date         time.Time      `json:"date"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	DeletedAt    sql.NullTime   `json:"deleted_at"`
-	CategoryName string         `json:"category_name"`
-	CategoryIcon sql.NullString `json:"category_icon"`
-	CategoryType string         `json:"category_type"`
-	UserName     string         `json:"user_name"`
+func (q *Queries) GetTagTotalsByYear(ctx context.Context, year string) ([]GetTagTotalsByYearRow, error) {
+	rows, err := q.query(ctx, nil, getTagTotalsByYear, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTagTotalsByYearRow
+	for rows.Next() {
+		var i GetTagTotalsByYearRow
+		if err := rows.Scan(&i.Tag, &i.TransactionCount, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) ListTransactionsByYearPaginated(ctx context.Context, arg ListTransactionsByYearPaginatedParams) ([]ListTransactionsByYearPaginatedRow, error) {
-	rows, err := q.query(ctx, q.listTransactionsByYearPaginatedStmt, listTransactionsByYearPaginated, arg.Year, arg.Limit, arg.Offset)
+const getTotalsByWeekday = `-- name: GetTotalsByWeekday :many
+SELECT
+    CAST(strftime('%w', t.date) AS INTEGER) as weekday,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND strftime('%Y', t.date) = CAST(? AS TEXT)
+GROUP BY weekday
+ORDER BY weekday
+`
+
+type GetTotalsByWeekdayRow struct {
+	Weekday          int64 `json:"weekday"`
+	TotalAmount      int64 `json:"total_amount"`
+	TransactionCount int64 `json:"transaction_count"`
+}
+
+func (q *Queries) GetTotalsByWeekday(ctx context.Context, year string) ([]GetTotalsByWeekdayRow, error) {
+	rows, err := q.query(ctx, nil, getTotalsByWeekday, year)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTransactionsByYearPaginatedRow
+	var items []GetTotalsByWeekdayRow
 	for rows.Next() {
-		var i ListTransactionsByYearPaginatedRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.CategoryID,
-			&i.Amount,
-			&i.Currency,
-			&i.Description,
-			&i.Date,
-			&i.CreatedAt,
-			&i.DeletedAt,
-			&i.CategoryName,
-			&i.CategoryIcon,
-			&i.CategoryType,
-			&i.UserName,
-		); err != nil {
+		var i GetTotalsByWeekdayRow
+		if err := rows.Scan(&i.Weekday, &i.TotalAmount, &i.TransactionCount); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -555,61 +783,107 @@ func (q *Queries) ListTransactionsByYearPaginated(ctx context.Context, arg ListT
 	return items, nil
 }
 
-const listTransactionsByYearPaginatedWithDeleted = `-- name: ListTransactionsByYearPaginatedWithDeleted :many
-SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+const getUser = `-- name: GetUser :one
+SELECT id, name, email, created_at FROM users
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
+	row := q.queryRow(ctx, q.getUserStmt, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const hardDeleteTransaction = `-- name: HardDeleteTransaction :exec
+DELETE FROM transactions
+WHERE id = ? AND user_id = ?
+`
+
+type HardDeleteTransactionParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) HardDeleteTransaction(ctx context.Context, arg HardDeleteTransactionParams) error {
+	_, err := q.exec(ctx, nil, hardDeleteTransaction, arg.ID, arg.UserID)
+	return err
+}
+
+const deleteTransactionTags = `-- name: DeleteTransactionTags :exec
+DELETE FROM transaction_tags
+WHERE transaction_id = ?
+`
+
+func (q *Queries) DeleteTransactionTags(ctx context.Context, transactionID int64) error {
+	_, err := q.exec(ctx, nil, deleteTransactionTags, transactionID)
+	return err
+}
+
+const deleteTransactionHistory = `-- name: DeleteTransactionHistory :exec
+DELETE FROM transaction_history
+WHERE transaction_id = ?
+`
+
+func (q *Queries) DeleteTransactionHistory(ctx context.Context, transactionID int64) error {
+	_, err := q.exec(ctx, nil, deleteTransactionHistory, transactionID)
+	return err
+}
+
+const deleteAuditEntriesForEntity = `-- name: DeleteAuditEntriesForEntity :exec
+DELETE FROM audit_log
+WHERE entity_id = ?
+`
+
+func (q *Queries) DeleteAuditEntriesForEntity(ctx context.Context, entityID int64) error {
+	_, err := q.exec(ctx, nil, deleteAuditEntriesForEntity, entityID)
+	return err
+}
+
+const listAllTransactionsForExport = `-- name: ListAllTransactionsForExport :many
+SELECT t.id, t.amount, t.currency, t.description, t.date, t.receipt_path, t.uid, c.name as category_name, c.type as category_type
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
-JOIN users u ON t.user_id = u.id
-WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+WHERE t.deleted_at IS NULL
 ORDER BY t.date DESC
-LIMIT ? OFFSET ?
 `
 
-type ListTransactionsByYearPaginatedWithDeletedParams struct {
-	Year   string
-	Limit  int64
-	Offset int64
-}
-
-type ListTransactionsByYearPaginatedWithDeletedRow struct {
+type ListAllTransactionsForExportRow struct {
 	ID           int64          `json:"id"`
-	UserID       int64          `json:"user_id"`
-	CategoryID   int64          `json:"category_id"`
 	Amount       int64          `json:"amount"`
 	Currency     string         `json:"currency"`
 	Description  string         `json:"description"`
 	Date         time.Time      `json:"date"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
 	CategoryName string         `json:"category_name"`
-	CategoryIcon sql.NullString `json:"category_icon"`
 	CategoryType string         `json:"category_type"`
-	UserName     string         `json:"user_name"`
 }
 
-func (q *Queries) ListTransactionsByYearPaginatedWithDeleted(ctx context.Context, arg ListTransactionsByYearPaginatedWithDeletedParams) ([]ListTransactionsByYearPaginatedWithDeletedRow, error) {
-	rows, err := q.query(ctx, q.listTransactionsByYearPaginatedWithDeletedStmt, listTransactionsByYearPaginatedWithDeleted, arg.Year, arg.Limit, arg.Offset)
+func (q *Queries) ListAllTransactionsForExport(ctx context.Context) ([]ListAllTransactionsForExportRow, error) {
+	rows, err := q.query(ctx, q.listAllTransactionsForExportStmt, listAllTransactionsForExport)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListTransactionsByYearPaginatedWithDeletedRow
+	var items []ListAllTransactionsForExportRow
 	for rows.Next() {
-		var i ListTransactionsByYearPaginatedWithDeletedRow
+		var i ListAllTransactionsForExportRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.CategoryID,
 			&i.Amount,
 			&i.Currency,
 			&i.Description,
 			&i.Date,
-			&i.CreatedAt,
-			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
 			&i.CategoryName,
-			&i.CategoryIcon,
 			&i.CategoryType,
-			&i.UserName,
 		); err != nil {
 			return nil, err
 		}
@@ -624,27 +898,28 @@ func (q *Queries) ListTransactionsByYearPaginatedWithDeleted(ctx context.Context
 	return items, nil
 }
 
-const listUsers = `-- name: ListUsers :many
-SELECT id, name, email, created_at FROM users
-ORDER BY name
+const listCategories = `-- name: ListCategories :many
+SELECT id, name, type, icon, color FROM categories
+ORDER BY type, name
 `
 
-func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
-	rows, err := q.query(ctx, q.listUsersStmt, listUsers)
+func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
+	rows, err := q.query(ctx, q.listCategoriesStmt, listCategories)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []User
+	var items []Category
 	for rows.Next() {
-		var i User
+		var i Category
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
-			&i.Email,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
+			&i.Type,
+			&i.Icon,
+			&i.Color,
+		); err != nil {
+			return nil, err
 		}
 		items = append(items, i)
 	}
@@ -657,40 +932,87 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
-const restoreTransaction = `-- name: RestoreTransaction :exec
-UPDATE transactions
-SET deleted_at = NULL
-WHERE id = ? AND user_id = ?
+const listLargestTransactionsByMonth = `-- name: ListLargestTransactionsByMonth :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+ORDER BY ABS(t.amount) DESC, t.id
+LIMIT ?
 `
 
-type RestoreTransactionParams struct {
-	ID     int64 `json:"id"`
-	UserID int64 `json:"user_id"`
+type ListLargestTransactionsByMonthParams struct {
+	Month string `json:"month"`
+	Limit int64  `json:"limit"`
 }
 
-func (q *Queries) RestoreTransaction(ctx context.Context, arg RestoreTransactionParams) error {
-	_, err := q.exec(ctx, q.restoreTransactionStmt, restoreTransaction, arg.ID, arg.UserID)
-	return err
+type ListLargestTransactionsByMonthRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
 }
 
-const searchTransactionsForRemoval = `-- name: SearchTransactionsForRemoval :many
-SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+func (q *Queries) ListLargestTransactionsByMonth(ctx context.Context, arg ListLargestTransactionsByMonthParams) ([]ListLargestTransactionsByMonthRow, error) {
+	rows, err := q.query(ctx, nil, listLargestTransactionsByMonth, arg.Month, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLargestTransactionsByMonthRow
+	for rows.Next() {
+		var i ListLargestTransactionsByMonthRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentDeletedTransactions = `-- name: ListRecentDeletedTransactions :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
 FROM transactions t
 JOIN categories c ON t.category_id = c.id
 JOIN users u ON t.user_id = u.id
-WHERE ABS(t.amount) = ?
-AND t.deleted_at IS NULL
-AND t.user_id = ?
-ORDER BY t.date DESC
-LIMIT 10
+WHERE t.deleted_at IS NOT NULL
+ORDER BY t.deleted_at DESC, t.id DESC
+LIMIT 20
 `
 
-type SearchTransactionsForRemovalParams struct {
-	Amount int64 `json:"amount"`
-	UserID int64 `json:"user_id"`
-}
-
-type SearchTransactionsForRemovalRow struct {
+type ListRecentDeletedTransactionsRow struct {
 	ID           int64          `json:"id"`
 	UserID       int64          `json:"user_id"`
 	CategoryID   int64          `json:"category_id"`
@@ -700,21 +1022,23 @@ type SearchTransactionsForRemovalRow struct {
 	Date         time.Time      `json:"date"`
 	CreatedAt    sql.NullTime   `json:"created_at"`
 	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
 	CategoryName string         `json:"category_name"`
 	CategoryIcon sql.NullString `json:"category_icon"`
 	CategoryType string         `json:"category_type"`
 	UserName     string         `json:"user_name"`
 }
 
-func (q *Queries) SearchTransactionsForRemoval(ctx context.Context, arg SearchTransactionsForRemovalParams) ([]SearchTransactionsForRemovalRow, error) {
-	rows, err := q.query(ctx, q.searchTransactionsForRemovalStmt, searchTransactionsForRemoval, arg.Amount, arg.UserID)
+func (q *Queries) ListRecentDeletedTransactions(ctx context.Context) ([]ListRecentDeletedTransactionsRow, error) {
+	rows, err := q.query(ctx, nil, listRecentDeletedTransactions)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SearchTransactionsForRemovalRow
+	var items []ListRecentDeletedTransactionsRow
 	for rows.Next() {
-		var i SearchTransactionsForRemovalRow
+		var i ListRecentDeletedTransactionsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
@@ -725,6 +1049,8 @@ func (q *Queries) SearchTransactionsForRemoval(ctx context.Context, arg SearchTr
 			&i.Date,
 			&i.CreatedAt,
 			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
 			&i.CategoryName,
 			&i.CategoryIcon,
 			&i.CategoryType,
@@ -743,61 +1069,142 @@ func (q *Queries) SearchTransactionsForRemoval(ctx context.Context, arg SearchTr
 	return items, nil
 }
 
-const softDeleteTransaction = `-- name: SoftDeleteTransaction :exec
-UPDATE transactions
-SET deleted_at = CURRENT_TIMESTAMP
-WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+const listRecentTransactions = `-- name: ListRecentTransactions :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE t.deleted_at IS NULL
+ORDER BY t.date DESC
+LIMIT 20
 `
 
-type SoftDeleteTransactionParams struct {
-	ID     int64 `json:"id"`
-	UserID int64 `json:"user_id"`
+type ListRecentTransactionsRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	UserName     string         `json:"user_name"`
 }
 
-func (q *Queries) SoftDeleteTransaction(ctx context.Context, arg SoftDeleteTransactionParams) error {
-	_, err := q.exec(ctx, q.softDeleteTransactionStmt, softDeleteTransaction, arg.ID, arg.UserID)
-	return err
+func (q *Queries) ListRecentTransactions(ctx context.Context) ([]ListRecentTransactionsRow, error) {
+	rows, err := q.query(ctx, q.listRecentTransactionsStmt, listRecentTransactions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecentTransactionsRow
+	for rows.Next() {
+		var i ListRecentTransactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getTopUsedCategories = `-- name: GetTopUsedCategories :many
-SELECT c.id, c.name, c.type, c.icon, c.color, COUNT(t.id) as usage_count
-FROM categories c
-LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at IS NULL AND t.user_id = ?
-GROUP BY c.id, c.name, c.type, c.icon, c.color
-ORDER BY usage_count DESC, c.name ASC
-LIMIT ?
+const listRecurringTransactionsByMonth = `-- name: ListRecurringTransactionsByMonth :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y-%m', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+AND (
+    EXISTS (SELECT 1 FROM transaction_tags tt WHERE tt.transaction_id = t.id AND tt.tag = CAST(? AS TEXT))
+    OR (' ' || LOWER(t.description) || ' ') GLOB ('*[^a-z0-9_]#' || CAST(? AS TEXT) || '[^a-z0-9_]*')
+)
+AND NOT EXISTS (
+    SELECT 1 FROM transactions copy
+    WHERE strftime('%Y-%m', copy.date) = CAST(? AS TEXT)
+    AND copy.deleted_at IS NULL
+    AND copy.description = t.description
+    AND copy.amount = t.amount
+    AND copy.category_id = t.category_id
+)
+ORDER BY t.date, t.id
 `
 
-type GetTopUsedCategoriesParams struct {
-	UserID int64 `json:"user_id"`
-	Limit  int64 `json:"limit"`
+type ListRecurringTransactionsByMonthParams struct {
+	SourceMonth string `json:"source_month"`
+	Tag         string `json:"tag"`
+	TargetMonth string `json:"target_month"`
 }
 
-type GetTopUsedCategoriesRow struct {
-	ID         int64          `json:"id"`
-	Name       string         `json:"name"`
-	Type       string         `json:"type"`
-	Icon       sql.NullString `json:"icon"`
-	Color      sql.NullString `json:"color"`
-	UsageCount int64          `json:"usage_count"`
+type ListRecurringTransactionsByMonthRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryType string         `json:"category_type"`
 }
 
-func (q *Queries) GetTopUsedCategories(ctx context.Context, arg GetTopUsedCategoriesParams) ([]GetTopUsedCategoriesRow, error) {
-	rows, err := q.query(ctx, nil, getTopUsedCategories, arg.UserID, arg.Limit)
+func (q *Queries) ListRecurringTransactionsByMonth(ctx context.Context, arg ListRecurringTransactionsByMonthParams) ([]ListRecurringTransactionsByMonthRow, error) {
+	rows, err := q.query(ctx, nil, listRecurringTransactionsByMonth,
+		arg.SourceMonth,
+		arg.Tag,
+		arg.Tag,
+		arg.TargetMonth,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTopUsedCategoriesRow
+	var items []ListRecurringTransactionsByMonthRow
 	for rows.Next() {
-		var i GetTopUsedCategoriesRow
+		var i ListRecurringTransactionsByMonthRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.Name,
-			&i.Type,
-			&i.Icon,
-			&i.Color,
-			&i.UsageCount,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryType,
 		); err != nil {
 			return nil, err
 		}
@@ -811,3 +1218,1564 @@ func (q *Queries) GetTopUsedCategories(ctx context.Context, arg GetTopUsedCatego
 	}
 	return items, nil
 }
+
+const listTransactionsByYear = `-- name: ListTransactionsByYear :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+ORDER BY t.date DESC
+`
+
+type ListTransactionsByYearRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) ListTransactionsByYear(ctx context.Context, dollar_1 string) ([]ListTransactionsByYearRow, error) {
+	rows, err := q.query(ctx, q.listTransactionsByYearStmt, listTransactionsByYear, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionsByYearRow
+	for rows.Next() {
+		var i ListTransactionsByYearRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsInCategory = `-- name: ListTransactionsInCategory :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE c.name = ?
+AND t.deleted_at IS NULL
+ORDER BY t.date DESC, t.id DESC
+`
+
+type ListTransactionsInCategoryRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) ListTransactionsInCategory(ctx context.Context, categoryName string) ([]ListTransactionsInCategoryRow, error) {
+	rows, err := q.query(ctx, nil, listTransactionsInCategory, categoryName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionsInCategoryRow
+	for rows.Next() {
+		var i ListTransactionsInCategoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsByYearPaginated = `-- name: ListTransactionsByYearPaginated :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+ORDER BY t.date DESC
+LIMIT ? OFFSET ?
+`
+
+type ListTransactionsByYearPaginatedParams struct {
+	Year   string
+	Limit  int64
+	Offset int64
+}
+
+type ListTransactionsByYearPaginatedRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) ListTransactionsByYearPaginated(ctx context.Context, arg ListTransactionsByYearPaginatedParams) ([]ListTransactionsByYearPaginatedRow, error) {
+	rows, err := q.query(ctx, q.listTransactionsByYearPaginatedStmt, listTransactionsByYearPaginated, arg.Year, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionsByYearPaginatedRow
+	for rows.Next() {
+		var i ListTransactionsByYearPaginatedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsByYearPaginatedWithDeleted = `-- name: ListTransactionsByYearPaginatedWithDeleted :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+ORDER BY t.date DESC
+LIMIT ? OFFSET ?
+`
+
+type ListTransactionsByYearPaginatedWithDeletedParams struct {
+	Year   string
+	Limit  int64
+	Offset int64
+}
+
+type ListTransactionsByYearPaginatedWithDeletedRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) ListTransactionsByYearPaginatedWithDeleted(ctx context.Context, arg ListTransactionsByYearPaginatedWithDeletedParams) ([]ListTransactionsByYearPaginatedWithDeletedRow, error) {
+	rows, err := q.query(ctx, q.listTransactionsByYearPaginatedWithDeletedStmt, listTransactionsByYearPaginatedWithDeleted, arg.Year, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionsByYearPaginatedWithDeletedRow
+	for rows.Next() {
+		var i ListTransactionsByYearPaginatedWithDeletedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email, created_at FROM users
+ORDER BY name
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.query(ctx, q.listUsersStmt, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreTransaction = `-- name: RestoreTransaction :exec
+UPDATE transactions
+SET deleted_at = NULL
+WHERE id = ? AND user_id = ?
+`
+
+type RestoreTransactionParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) RestoreTransaction(ctx context.Context, arg RestoreTransactionParams) error {
+	_, err := q.exec(ctx, q.restoreTransactionStmt, restoreTransaction, arg.ID, arg.UserID)
+	return err
+}
+
+const searchTransactionsForRemoval = `-- name: SearchTransactionsForRemoval :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE ABS(t.amount) = ?
+AND t.deleted_at IS NULL
+AND t.user_id = ?
+ORDER BY t.date DESC
+LIMIT 10
+`
+
+type SearchTransactionsForRemovalParams struct {
+	Amount int64 `json:"amount"`
+	UserID int64 `json:"user_id"`
+}
+
+type SearchTransactionsForRemovalRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) SearchTransactionsForRemoval(ctx context.Context, arg SearchTransactionsForRemovalParams) ([]SearchTransactionsForRemovalRow, error) {
+	rows, err := q.query(ctx, q.searchTransactionsForRemovalStmt, searchTransactionsForRemoval, arg.Amount, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchTransactionsForRemovalRow
+	for rows.Next() {
+		var i SearchTransactionsForRemovalRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteTransaction = `-- name: SoftDeleteTransaction :exec
+UPDATE transactions
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type SoftDeleteTransactionParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) SoftDeleteTransaction(ctx context.Context, arg SoftDeleteTransactionParams) error {
+	_, err := q.exec(ctx, q.softDeleteTransactionStmt, softDeleteTransaction, arg.ID, arg.UserID)
+	return err
+}
+
+const sumAllTransactionsUpToDate = `-- name: SumAllTransactionsUpToDate :one
+SELECT CAST(COALESCE(SUM(
+    CASE c.type
+        WHEN 'income' THEN ABS(t.amount)
+        WHEN 'expense' THEN -ABS(t.amount)
+        ELSE 0
+    END
+), 0) AS INTEGER) as balance
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE date(t.date) <= CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+`
+
+func (q *Queries) SumAllTransactionsUpToDate(ctx context.Context, date string) (int64, error) {
+	row := q.queryRow(ctx, nil, sumAllTransactionsUpToDate, date)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
+const sumExpensesMonthToDate = `-- name: SumExpensesMonthToDate :one
+SELECT CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+AND CAST(strftime('%m', t.date) AS INTEGER) = ?
+AND CAST(strftime('%d', t.date) AS INTEGER) <= ?
+AND c.type = 'expense'
+AND t.deleted_at IS NULL
+`
+
+type SumExpensesMonthToDateParams struct {
+	Year  string `json:"year"`
+	Month int64  `json:"month"`
+	Day   int64  `json:"day"`
+}
+
+func (q *Queries) SumExpensesMonthToDate(ctx context.Context, arg SumExpensesMonthToDateParams) (int64, error) {
+	row := q.queryRow(ctx, nil, sumExpensesMonthToDate, arg.Year, arg.Month, arg.Day)
+	var total_amount int64
+	err := row.Scan(&total_amount)
+	return total_amount, err
+}
+
+const getTopUsedCategories = `-- name: GetTopUsedCategories :many
+SELECT c.id, c.name, c.type, c.icon, c.color, COUNT(t.id) as usage_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at IS NULL AND t.user_id = ?
+GROUP BY c.id, c.name, c.type, c.icon, c.color
+ORDER BY usage_count DESC, c.name ASC
+LIMIT ?
+`
+
+type GetTopUsedCategoriesParams struct {
+	UserID int64 `json:"user_id"`
+	Limit  int64 `json:"limit"`
+}
+
+type GetTopUsedCategoriesRow struct {
+	ID         int64          `json:"id"`
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Icon       sql.NullString `json:"icon"`
+	Color      sql.NullString `json:"color"`
+	UsageCount int64          `json:"usage_count"`
+}
+
+func (q *Queries) GetTopUsedCategories(ctx context.Context, arg GetTopUsedCategoriesParams) ([]GetTopUsedCategoriesRow, error) {
+	rows, err := q.query(ctx, nil, getTopUsedCategories, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopUsedCategoriesRow
+	for rows.Next() {
+		var i GetTopUsedCategoriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Type,
+			&i.Icon,
+			&i.Color,
+			&i.UsageCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBudgetByCategory = `-- name: GetBudgetByCategory :one
+SELECT id, category_id, limit_cents, hard_limit_cents FROM budgets
+WHERE category_id = ? LIMIT 1
+`
+
+func (q *Queries) GetBudgetByCategory(ctx context.Context, categoryID int64) (Budget, error) {
+	row := q.queryRow(ctx, nil, getBudgetByCategory, categoryID)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.LimitCents,
+		&i.HardLimitCents,
+	)
+	return i, err
+}
+
+const setBudget = `-- name: SetBudget :one
+INSERT INTO budgets (category_id, limit_cents, hard_limit_cents) VALUES (?, ?, ?)
+ON CONFLICT(category_id) DO UPDATE SET
+    limit_cents = excluded.limit_cents,
+    hard_limit_cents = excluded.hard_limit_cents
+RETURNING id, category_id, limit_cents, hard_limit_cents
+`
+
+type SetBudgetParams struct {
+	CategoryID     int64         `json:"category_id"`
+	LimitCents     int64         `json:"limit_cents"`
+	HardLimitCents sql.NullInt64 `json:"hard_limit_cents"`
+}
+
+func (q *Queries) SetBudget(ctx context.Context, arg SetBudgetParams) (Budget, error) {
+	row := q.queryRow(ctx, nil, setBudget, arg.CategoryID, arg.LimitCents, arg.HardLimitCents)
+	var i Budget
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.LimitCents,
+		&i.HardLimitCents,
+	)
+	return i, err
+}
+
+const getCategorySpendForMonth = `-- name: GetCategorySpendForMonth :one
+SELECT CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+FROM transactions
+WHERE category_id = ?
+AND strftime('%Y-%m', date) = CAST(? AS TEXT)
+AND deleted_at IS NULL
+`
+
+type GetCategorySpendForMonthParams struct {
+	CategoryID int64  `json:"category_id"`
+	Month      string `json:"month"`
+}
+
+func (q *Queries) GetCategorySpendForMonth(ctx context.Context, arg GetCategorySpendForMonthParams) (int64, error) {
+	row := q.queryRow(ctx, nil, getCategorySpendForMonth, arg.CategoryID, arg.Month)
+	var total_amount int64
+	err := row.Scan(&total_amount)
+	return total_amount, err
+}
+
+const listBudgetStatusByMonth = `-- name: ListBudgetStatusByMonth :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    b.limit_cents,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as spent
+FROM budgets b
+JOIN categories c ON c.id = b.category_id
+LEFT JOIN transactions t ON t.category_id = c.id AND strftime('%Y-%m', t.date) = CAST(? AS TEXT) AND t.deleted_at IS NULL
+GROUP BY b.id, c.id, c.name, b.limit_cents
+ORDER BY c.name
+`
+
+type ListBudgetStatusByMonthRow struct {
+	CategoryID   int64  `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	LimitCents   int64  `json:"limit_cents"`
+	Spent        int64  `json:"spent"`
+}
+
+func (q *Queries) ListBudgetStatusByMonth(ctx context.Context, month string) ([]ListBudgetStatusByMonthRow, error) {
+	rows, err := q.query(ctx, nil, listBudgetStatusByMonth, month)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBudgetStatusByMonthRow
+	for rows.Next() {
+		var i ListBudgetStatusByMonthRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.LimitCents,
+			&i.Spent,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionByID = `-- name: GetTransactionByID :one
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+WHERE t.id = ? AND t.user_id = ?
+LIMIT 1
+`
+
+type GetTransactionByIDParams struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+type GetTransactionByIDRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) GetTransactionByID(ctx context.Context, arg GetTransactionByIDParams) (GetTransactionByIDRow, error) {
+	row := q.queryRow(ctx, nil, getTransactionByID, arg.ID, arg.UserID)
+	var i GetTransactionByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CategoryID,
+		&i.Amount,
+		&i.Currency,
+		&i.Description,
+		&i.Date,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.ReceiptPath,
+		&i.Uid,
+		&i.CategoryName,
+		&i.CategoryIcon,
+		&i.CategoryType,
+		&i.UserName,
+	)
+	return i, err
+}
+
+const tagTransaction = `-- name: TagTransaction :execrows
+INSERT OR IGNORE INTO transaction_tags (transaction_id, tag)
+SELECT id, ? FROM transactions
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type TagTransactionParams struct {
+	Tag    string `json:"tag"`
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+}
+
+func (q *Queries) TagTransaction(ctx context.Context, arg TagTransactionParams) (int64, error) {
+	result, err := q.exec(ctx, nil, tagTransaction, arg.Tag, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateTransactionCategory = `-- name: UpdateTransactionCategory :exec
+UPDATE transactions
+SET category_id = ?, amount = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type UpdateTransactionCategoryParams struct {
+	CategoryID int64 `json:"category_id"`
+	Amount     int64 `json:"amount"`
+	ID         int64 `json:"id"`
+	UserID     int64 `json:"user_id"`
+}
+
+func (q *Queries) UpdateTransactionCategory(ctx context.Context, arg UpdateTransactionCategoryParams) error {
+	_, err := q.exec(ctx, nil, updateTransactionCategory,
+		arg.CategoryID,
+		arg.Amount,
+		arg.ID,
+		arg.UserID,
+	)
+	return err
+}
+
+const getDistinctYearMonths = `-- name: GetDistinctYearMonths :many
+SELECT DISTINCT CAST(strftime('%Y', date) AS INTEGER) as year, CAST(strftime('%m', date) AS INTEGER) as month
+FROM transactions
+WHERE deleted_at IS NULL
+ORDER BY year DESC, month DESC
+`
+
+type GetDistinctYearMonthsRow struct {
+	Year  int64 `json:"year"`
+	Month int64 `json:"month"`
+}
+
+func (q *Queries) GetDistinctYearMonths(ctx context.Context) ([]GetDistinctYearMonthsRow, error) {
+	rows, err := q.query(ctx, nil, getDistinctYearMonths)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDistinctYearMonthsRow
+	for rows.Next() {
+		var i GetDistinctYearMonthsRow
+		if err := rows.Scan(
+			&i.Year,
+			&i.Month,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (action, entity_id, details)
+VALUES (?, ?, ?)
+`
+
+type CreateAuditEntryParams struct {
+	Action   string `json:"action"`
+	EntityID int64  `json:"entity_id"`
+	Details  string `json:"details"`
+}
+
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.exec(ctx, nil, createAuditEntry, arg.Action, arg.EntityID, arg.Details)
+	return err
+}
+
+const listAuditEntries = `-- name: ListAuditEntries :many
+SELECT id, action, entity_id, details, created_at FROM audit_log
+WHERE id < ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type ListAuditEntriesParams struct {
+	Before int64 `json:"before"`
+	Limit  int64 `json:"limit"`
+}
+
+func (q *Queries) ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error) {
+	rows, err := q.query(ctx, nil, listAuditEntries, arg.Before, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.EntityID,
+			&i.Details,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteAuditEntriesBefore = `-- name: DeleteAuditEntriesBefore :execrows
+DELETE FROM audit_log
+WHERE created_at < ?
+`
+
+func (q *Queries) DeleteAuditEntriesBefore(ctx context.Context, createdAt sql.NullTime) (int64, error) {
+	result, err := q.exec(ctx, nil, deleteAuditEntriesBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateTransactionAmount = `-- name: UpdateTransactionAmount :exec
+UPDATE transactions
+SET amount = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type UpdateTransactionAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+}
+
+func (q *Queries) UpdateTransactionAmount(ctx context.Context, arg UpdateTransactionAmountParams) error {
+	_, err := q.exec(ctx, nil, updateTransactionAmount, arg.Amount, arg.ID, arg.UserID)
+	return err
+}
+
+const updateTransactionCurrency = `-- name: UpdateTransactionCurrency :exec
+UPDATE transactions
+SET currency = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type UpdateTransactionCurrencyParams struct {
+	Currency string `json:"currency"`
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"user_id"`
+}
+
+func (q *Queries) UpdateTransactionCurrency(ctx context.Context, arg UpdateTransactionCurrencyParams) error {
+	_, err := q.exec(ctx, nil, updateTransactionCurrency, arg.Currency, arg.ID, arg.UserID)
+	return err
+}
+
+const createTransactionHistory = `-- name: CreateTransactionHistory :exec
+INSERT INTO transaction_history (transaction_id, field, old_value, new_value)
+VALUES (?, ?, ?, ?)
+`
+
+type CreateTransactionHistoryParams struct {
+	TransactionID int64  `json:"transaction_id"`
+	Field         string `json:"field"`
+	OldValue      string `json:"old_value"`
+	NewValue      string `json:"new_value"`
+}
+
+func (q *Queries) CreateTransactionHistory(ctx context.Context, arg CreateTransactionHistoryParams) error {
+	_, err := q.exec(ctx, nil, createTransactionHistory,
+		arg.TransactionID,
+		arg.Field,
+		arg.OldValue,
+		arg.NewValue,
+	)
+	return err
+}
+
+const listTransactionHistory = `-- name: ListTransactionHistory :many
+SELECT id, transaction_id, field, old_value, new_value, changed_at FROM transaction_history
+WHERE transaction_id = ?
+ORDER BY id
+`
+
+func (q *Queries) ListTransactionHistory(ctx context.Context, transactionID int64) ([]TransactionHistory, error) {
+	rows, err := q.query(ctx, nil, listTransactionHistory, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TransactionHistory
+	for rows.Next() {
+		var i TransactionHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.Field,
+			&i.OldValue,
+			&i.NewValue,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTransactionReceipt = `-- name: SetTransactionReceipt :exec
+UPDATE transactions
+SET receipt_path = ?
+WHERE id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type SetTransactionReceiptParams struct {
+	ReceiptPath sql.NullString `json:"receipt_path"`
+	ID          int64          `json:"id"`
+	UserID      int64          `json:"user_id"`
+}
+
+func (q *Queries) SetTransactionReceipt(ctx context.Context, arg SetTransactionReceiptParams) error {
+	_, err := q.exec(ctx, nil, setTransactionReceipt, arg.ReceiptPath, arg.ID, arg.UserID)
+	return err
+}
+
+const listLargestTransactions = `-- name: ListLargestTransactions :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE strftime('%Y', t.date) = CAST(? AS TEXT)
+AND c.type = ?
+AND t.deleted_at IS NULL
+ORDER BY ABS(t.amount) DESC
+LIMIT ?
+`
+
+type ListLargestTransactionsParams struct {
+	Year  string `json:"year"`
+	Type  string `json:"type"`
+	Limit int64  `json:"limit"`
+}
+
+type ListLargestTransactionsRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+}
+
+func (q *Queries) ListLargestTransactions(ctx context.Context, arg ListLargestTransactionsParams) ([]ListLargestTransactionsRow, error) {
+	rows, err := q.query(ctx, nil, listLargestTransactions, arg.Year, arg.Type, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListLargestTransactionsRow
+	for rows.Next() {
+		var i ListLargestTransactionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionActivity = `-- name: ListTransactionActivity :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE CAST(? AS TEXT) IN (date(t.created_at), date(t.deleted_at))
+ORDER BY t.id
+`
+
+type ListTransactionActivityRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+}
+
+func (q *Queries) ListTransactionActivity(ctx context.Context, day string) ([]ListTransactionActivityRow, error) {
+	rows, err := q.query(ctx, nil, listTransactionActivity, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionActivityRow
+	for rows.Next() {
+		var i ListTransactionActivityRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWeeklyExpenseTotals = `-- name: GetWeeklyExpenseTotals :many
+SELECT
+    CAST((julianday(date(t.date)) - julianday(CAST(? AS TEXT))) / 7 AS INTEGER) as week,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE c.type = 'expense'
+AND t.deleted_at IS NULL
+AND date(t.date) >= CAST(? AS TEXT)
+AND date(t.date) < CAST(? AS TEXT)
+GROUP BY week
+ORDER BY week
+`
+
+type GetWeeklyExpenseTotalsParams struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type GetWeeklyExpenseTotalsRow struct {
+	Week        int64 `json:"week"`
+	TotalAmount int64 `json:"total_amount"`
+}
+
+func (q *Queries) GetWeeklyExpenseTotals(ctx context.Context, arg GetWeeklyExpenseTotalsParams) ([]GetWeeklyExpenseTotalsRow, error) {
+	rows, err := q.query(ctx, nil, getWeeklyExpenseTotals, arg.Start, arg.Start, arg.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWeeklyExpenseTotalsRow
+	for rows.Next() {
+		var i GetWeeklyExpenseTotalsRow
+		if err := rows.Scan(&i.Week, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsByFiscalYear = `-- name: ListTransactionsByFiscalYear :many
+SELECT t.id, t.user_id, t.category_id, t.amount, t.currency, t.description, t.date, t.created_at, t.deleted_at, t.receipt_path, t.uid, c.name as category_name, c.icon as category_icon, c.type as category_type, u.name as user_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+JOIN users u ON t.user_id = u.id
+JOIN (SELECT CAST(? AS TEXT) AS sort) o
+WHERE date(t.date) >= CAST(? AS TEXT)
+AND date(t.date) < CAST(? AS TEXT)
+AND (CAST(? AS BOOLEAN) OR t.deleted_at IS NULL)
+ORDER BY
+    CASE o.sort WHEN 'amount_asc' THEN ABS(t.amount) END ASC,
+    CASE o.sort WHEN 'amount_desc' THEN ABS(t.amount) END DESC,
+    CASE o.sort WHEN 'date_asc' THEN t.date END ASC,
+    t.date DESC
+LIMIT ? OFFSET ?
+`
+
+type ListTransactionsByFiscalYearParams struct {
+	Sort           string `json:"sort"`
+	Start          string `json:"start"`
+	End            string `json:"end"`
+	IncludeDeleted bool   `json:"include_deleted"`
+	Limit          int64  `json:"limit"`
+	Offset         int64  `json:"offset"`
+}
+
+type ListTransactionsByFiscalYearRow struct {
+	ID           int64          `json:"id"`
+	UserID       int64          `json:"user_id"`
+	CategoryID   int64          `json:"category_id"`
+	Amount       int64          `json:"amount"`
+	Currency     string         `json:"currency"`
+	Description  string         `json:"description"`
+	Date         time.Time      `json:"date"`
+	CreatedAt    sql.NullTime   `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+	ReceiptPath  sql.NullString `json:"receipt_path"`
+	Uid          sql.NullString `json:"uid"`
+	CategoryName string         `json:"category_name"`
+	CategoryIcon sql.NullString `json:"category_icon"`
+	CategoryType string         `json:"category_type"`
+	UserName     string         `json:"user_name"`
+}
+
+func (q *Queries) ListTransactionsByFiscalYear(ctx context.Context, arg ListTransactionsByFiscalYearParams) ([]ListTransactionsByFiscalYearRow, error) {
+	rows, err := q.query(ctx, nil, listTransactionsByFiscalYear,
+		arg.Sort,
+		arg.Start,
+		arg.End,
+		arg.IncludeDeleted,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTransactionsByFiscalYearRow
+	for rows.Next() {
+		var i ListTransactionsByFiscalYearRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CategoryID,
+			&i.Amount,
+			&i.Currency,
+			&i.Description,
+			&i.Date,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.ReceiptPath,
+			&i.Uid,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countTransactionsByFiscalYear = `-- name: CountTransactionsByFiscalYear :one
+SELECT COUNT(*) as count
+FROM transactions t
+WHERE date(t.date) >= CAST(? AS TEXT)
+AND date(t.date) < CAST(? AS TEXT)
+AND (CAST(? AS BOOLEAN) OR t.deleted_at IS NULL)
+`
+
+type CountTransactionsByFiscalYearParams struct {
+	Start          string `json:"start"`
+	End            string `json:"end"`
+	IncludeDeleted bool   `json:"include_deleted"`
+}
+
+func (q *Queries) CountTransactionsByFiscalYear(ctx context.Context, arg CountTransactionsByFiscalYearParams) (int64, error) {
+	row := q.queryRow(ctx, nil, countTransactionsByFiscalYear, arg.Start, arg.End, arg.IncludeDeleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCategoryTotalsAllTime = `-- name: GetCategoryTotalsAllTime :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    c.color as category_color,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id AND t.deleted_at IS NULL
+GROUP BY c.id, c.name, c.icon, c.type, c.color
+ORDER BY c.type, total_amount DESC
+`
+
+type GetCategoryTotalsAllTimeRow struct {
+	CategoryID       int64          `json:"category_id"`
+	CategoryName     string         `json:"category_name"`
+	CategoryIcon     sql.NullString `json:"category_icon"`
+	CategoryType     string         `json:"category_type"`
+	CategoryColor    sql.NullString `json:"category_color"`
+	TotalAmount      int64          `json:"total_amount"`
+	TransactionCount int64          `json:"transaction_count"`
+}
+
+func (q *Queries) GetCategoryTotalsAllTime(ctx context.Context) ([]GetCategoryTotalsAllTimeRow, error) {
+	rows, err := q.query(ctx, nil, getCategoryTotalsAllTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCategoryTotalsAllTimeRow
+	for rows.Next() {
+		var i GetCategoryTotalsAllTimeRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.CategoryColor,
+			&i.TotalAmount,
+			&i.TransactionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategoryTotalsByFiscalYear = `-- name: GetCategoryTotalsByFiscalYear :many
+SELECT
+    c.id as category_id,
+    c.name as category_name,
+    c.icon as category_icon,
+    c.type as category_type,
+    c.color as category_color,
+    CAST(COALESCE(SUM(ABS(t.amount)), 0) AS INTEGER) as total_amount,
+    COUNT(t.id) as transaction_count
+FROM categories c
+LEFT JOIN transactions t ON t.category_id = c.id
+    AND date(t.date) >= CAST(? AS TEXT)
+    AND date(t.date) < CAST(? AS TEXT)
+    AND t.deleted_at IS NULL
+WHERE c.type != 'transfer'
+GROUP BY c.id, c.name, c.icon, c.type, c.color
+ORDER BY c.type, total_amount DESC
+`
+
+type GetCategoryTotalsByFiscalYearParams struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type GetCategoryTotalsByFiscalYearRow struct {
+	CategoryID       int64          `json:"category_id"`
+	CategoryName     string         `json:"category_name"`
+	CategoryIcon     sql.NullString `json:"category_icon"`
+	CategoryType     string         `json:"category_type"`
+	CategoryColor    sql.NullString `json:"category_color"`
+	TotalAmount      int64          `json:"total_amount"`
+	TransactionCount int64          `json:"transaction_count"`
+}
+
+func (q *Queries) GetCategoryTotalsByFiscalYear(ctx context.Context, arg GetCategoryTotalsByFiscalYearParams) ([]GetCategoryTotalsByFiscalYearRow, error) {
+	rows, err := q.query(ctx, nil, getCategoryTotalsByFiscalYear, arg.Start, arg.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCategoryTotalsByFiscalYearRow
+	for rows.Next() {
+		var i GetCategoryTotalsByFiscalYearRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CategoryIcon,
+			&i.CategoryType,
+			&i.CategoryColor,
+			&i.TotalAmount,
+			&i.TransactionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMonthlyTotalsByFiscalYear = `-- name: GetMonthlyTotalsByFiscalYear :many
+SELECT
+    CAST(strftime('%m', date) AS INTEGER) as month,
+    c.type as category_type,
+    CAST(COALESCE(SUM(ABS(amount)), 0) AS INTEGER) as total_amount
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE date(t.date) >= CAST(? AS TEXT)
+AND date(t.date) < CAST(? AS TEXT)
+AND t.deleted_at IS NULL
+AND c.type != 'transfer'
+GROUP BY month, c.type
+ORDER BY month
+`
+
+type GetMonthlyTotalsByFiscalYearParams struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type GetMonthlyTotalsByFiscalYearRow struct {
+	Month        int64  `json:"month"`
+	CategoryType string `json:"category_type"`
+	TotalAmount  int64  `json:"total_amount"`
+}
+
+func (q *Queries) GetMonthlyTotalsByFiscalYear(ctx context.Context, arg GetMonthlyTotalsByFiscalYearParams) ([]GetMonthlyTotalsByFiscalYearRow, error) {
+	rows, err := q.query(ctx, nil, getMonthlyTotalsByFiscalYear, arg.Start, arg.End)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMonthlyTotalsByFiscalYearRow
+	for rows.Next() {
+		var i GetMonthlyTotalsByFiscalYearRow
+		if err := rows.Scan(&i.Month, &i.CategoryType, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionCountsByCurrency = `-- name: GetTransactionCountsByCurrency :many
+SELECT
+    t.currency,
+    CAST(COUNT(*) AS INTEGER) as count,
+    CAST(COALESCE(SUM(t.amount), 0) AS INTEGER) as total_amount
+FROM transactions t
+WHERE t.deleted_at IS NULL
+GROUP BY t.currency
+ORDER BY count DESC, t.currency
+`
+
+type GetTransactionCountsByCurrencyRow struct {
+	Currency    string `json:"currency"`
+	Count       int64  `json:"count"`
+	TotalAmount int64  `json:"total_amount"`
+}
+
+func (q *Queries) GetTransactionCountsByCurrency(ctx context.Context) ([]GetTransactionCountsByCurrencyRow, error) {
+	rows, err := q.query(ctx, nil, getTransactionCountsByCurrency)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTransactionCountsByCurrencyRow
+	for rows.Next() {
+		var i GetTransactionCountsByCurrencyRow
+		if err := rows.Scan(&i.Currency, &i.Count, &i.TotalAmount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestCreatedTransaction = `-- name: GetLatestCreatedTransaction :one
+SELECT t.id, t.amount, t.description, c.name as category_name
+FROM transactions t
+JOIN categories c ON t.category_id = c.id
+WHERE t.user_id = ? AND t.deleted_at IS NULL
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT 1
+`
+
+type GetLatestCreatedTransactionRow struct {
+	ID           int64  `json:"id"`
+	Amount       int64  `json:"amount"`
+	Description  string `json:"description"`
+	CategoryName string `json:"category_name"`
+}
+
+func (q *Queries) GetLatestCreatedTransaction(ctx context.Context, userID int64) (GetLatestCreatedTransactionRow, error) {
+	row := q.queryRow(ctx, nil, getLatestCreatedTransaction, userID)
+	var i GetLatestCreatedTransactionRow
+	err := row.Scan(
+		&i.ID,
+		&i.Amount,
+		&i.Description,
+		&i.CategoryName,
+	)
+	return i, err
+}
+
+const reassignCategoryTransactions = `-- name: ReassignCategoryTransactions :execrows
+UPDATE transactions
+SET category_id = ?,
+    amount = CASE WHEN CAST(? AS TEXT) = 'expense' THEN -ABS(amount) ELSE ABS(amount) END
+WHERE category_id = ? AND user_id = ? AND deleted_at IS NULL
+`
+
+type ReassignCategoryTransactionsParams struct {
+	ToCategoryID   int64  `json:"to_category_id"`
+	ToType         string `json:"to_type"`
+	FromCategoryID int64  `json:"from_category_id"`
+	UserID         int64  `json:"user_id"`
+}
+
+func (q *Queries) ReassignCategoryTransactions(ctx context.Context, arg ReassignCategoryTransactionsParams) (int64, error) {
+	result, err := q.exec(ctx, nil, reassignCategoryTransactions,
+		arg.ToCategoryID,
+		arg.ToType,
+		arg.FromCategoryID,
+		arg.UserID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countTransactionsByUID = `-- name: CountTransactionsByUID :one
+SELECT COUNT(*) as count FROM transactions
+WHERE uid = ?
+`
+
+func (q *Queries) CountTransactionsByUID(ctx context.Context, uid sql.NullString) (int64, error) {
+	row := q.queryRow(ctx, nil, countTransactionsByUID, uid)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransactionsByContent = `-- name: CountTransactionsByContent :one
+SELECT COUNT(*) as count FROM transactions
+WHERE amount = ?
+AND description = ?
+AND datetime(date) = datetime(?)
+`
+
+type CountTransactionsByContentParams struct {
+	Amount      int64     `json:"amount"`
+	Description string    `json:"description"`
+	Date        time.Time `json:"date"`
+}
+
+func (q *Queries) CountTransactionsByContent(ctx context.Context, arg CountTransactionsByContentParams) (int64, error) {
+	row := q.queryRow(ctx, nil, countTransactionsByContent, arg.Amount, arg.Description, arg.Date)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getSetting = `-- name: GetSetting :one
+SELECT value FROM settings
+WHERE key = ?
+`
+
+func (q *Queries) GetSetting(ctx context.Context, key string) (string, error) {
+	row := q.queryRow(ctx, nil, getSetting, key)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
+const setSetting = `-- name: SetSetting :exec
+INSERT INTO settings (key, value) VALUES (?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value
+`
+
+type SetSettingParams struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (q *Queries) SetSetting(ctx context.Context, arg SetSettingParams) error {
+	_, err := q.exec(ctx, nil, setSetting, arg.Key, arg.Value)
+	return err
+}
@@ -36,7 +36,7 @@ func setupTestDB(t *testing.T) (*db.Queries, func()) {
 		CREATE TABLE categories (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT NOT NULL,
-			type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+			type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer')),
 			icon TEXT,
 			color TEXT
 		);
@@ -51,6 +51,8 @@ func setupTestDB(t *testing.T) (*db.Queries, func()) {
 			date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			deleted_at DATETIME DEFAULT NULL,
+			receipt_path TEXT DEFAULT NULL,
+			uid TEXT DEFAULT NULL,
 			FOREIGN KEY (user_id) REFERENCES users(id),
 			FOREIGN KEY (category_id) REFERENCES categories(id)
 		);
@@ -584,6 +586,56 @@ func TestListTransactionsByYear(t *testing.T) {
 	})
 }
 
+func TestListTransactionsByFiscalYear(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		desc string
+		date time.Time
+	}{
+		{"march", time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC)},
+		{"april", time.Date(2024, 4, 1, 1, 0, 0, 0, time.UTC)},
+	} {
+		_, err := queries.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  1,
+			Amount:      1000,
+			Currency:    "USD",
+			Description: tc.desc,
+			Date:        tc.date,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create %s transaction: %v", tc.desc, err)
+		}
+	}
+
+	// Fiscal 2024 starting in April
+	txs, err := queries.ListTransactionsByFiscalYear(ctx, db.ListTransactionsByFiscalYearParams{
+		Start: "2024-04-01",
+		End:   "2025-04-01",
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatalf("ListTransactionsByFiscalYear() error = %v", err)
+	}
+	if len(txs) != 1 || txs[0].Description != "april" {
+		t.Errorf("Expected only the april transaction, got %+v", txs)
+	}
+
+	count, err := queries.CountTransactionsByFiscalYear(ctx, db.CountTransactionsByFiscalYearParams{
+		Start: "2023-04-01",
+		End:   "2024-04-01",
+	})
+	if err != nil {
+		t.Fatalf("CountTransactionsByFiscalYear() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 transaction in fiscal 2023, got %d", count)
+	}
+}
+
 func TestGetCategoryTotalsByYear(t *testing.T) {
 	queries, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -784,10 +836,13 @@ func TestDeleteAllTransactions(t *testing.T) {
 	}
 
 	// Delete all
-	err = queries.DeleteAllTransactions(ctx)
+	deleted, err := queries.DeleteAllTransactions(ctx)
 	if err != nil {
 		t.Fatalf("DeleteAllTransactions() error = %v", err)
 	}
+	if deleted != 2 {
+		t.Errorf("DeleteAllTransactions() = %d, want 2", deleted)
+	}
 
 	// Verify all gone
 	txs, err = queries.ListRecentTransactions(ctx)
@@ -889,6 +944,123 @@ func TestGetMonthlyTotalsByYear(t *testing.T) {
 	})
 }
 
+func TestGetMonthBoundsByYear(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dates := []time.Time{
+		time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 18, 30, 0, 0, time.UTC),
+		time.Date(2024, 3, 29, 8, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 23, 0, 0, 0, time.UTC), // deleted below
+		time.Date(2024, 5, 7, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC), // other year
+	}
+	for i, date := range dates {
+		tx, err := queries.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  1,
+			Amount:      -1000,
+			Currency:    "USD",
+			Description: "spread",
+			Date:        date,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+		if i == 3 {
+			if err := queries.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: tx.ID, UserID: 1}); err != nil {
+				t.Fatalf("Failed to soft-delete transaction: %v", err)
+			}
+		}
+	}
+
+	bounds, err := queries.GetMonthBoundsByYear(ctx, "2024")
+	if err != nil {
+		t.Fatalf("GetMonthBoundsByYear() error = %v", err)
+	}
+
+	// April has no transactions and is omitted
+	want := []db.GetMonthBoundsByYearRow{
+		{Month: 3, FirstDate: "2024-03-02", LastDate: "2024-03-29", TransactionCount: 3},
+		{Month: 5, FirstDate: "2024-05-07", LastDate: "2024-05-07", TransactionCount: 1},
+	}
+	if len(bounds) != len(want) {
+		t.Fatalf("GetMonthBoundsByYear() = %+v, want %+v", bounds, want)
+	}
+	for i := range want {
+		if bounds[i] != want[i] {
+			t.Errorf("month %d = %+v, want %+v", i, bounds[i], want[i])
+		}
+	}
+}
+
+func TestCountDeletedTransactionsByFiscalYear(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dates := []time.Time{
+		time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC),
+	}
+	var ids []int64
+	for _, date := range dates {
+		tx, err := queries.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  1,
+			Amount:      -1000,
+			Currency:    "USD",
+			Description: "countable",
+			Date:        date,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+		ids = append(ids, tx.ID)
+	}
+	// Remove two 2024 transactions and the 2023 one
+	for _, id := range []int64{ids[0], ids[1], ids[3]} {
+		if err := queries.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: id, UserID: 1}); err != nil {
+			t.Fatalf("Failed to soft-delete transaction: %v", err)
+		}
+	}
+
+	deleted, err := queries.CountDeletedTransactionsByFiscalYear(ctx, db.CountDeletedTransactionsByFiscalYearParams{
+		Start: "2024-01-01",
+		End:   "2025-01-01",
+	})
+	if err != nil {
+		t.Fatalf("CountDeletedTransactionsByFiscalYear() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("CountDeletedTransactionsByFiscalYear() = %d, want 2", deleted)
+	}
+
+	// A July fiscal year also picks up the September 2023 removal
+	deleted, err = queries.CountDeletedTransactionsByFiscalYear(ctx, db.CountDeletedTransactionsByFiscalYearParams{
+		Start: "2023-07-01",
+		End:   "2024-07-01",
+	})
+	if err != nil {
+		t.Fatalf("CountDeletedTransactionsByFiscalYear() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("CountDeletedTransactionsByFiscalYear() fiscal = %d, want 3", deleted)
+	}
+
+	active, err := queries.CountTransactionsByYear(ctx, "2024")
+	if err != nil {
+		t.Fatalf("CountTransactionsByYear() error = %v", err)
+	}
+	if active != 1 {
+		t.Errorf("CountTransactionsByYear() = %d, want 1", active)
+	}
+}
+
 func TestSoftDeleteTransaction(t *testing.T) {
 	queries, cleanup := setupTestDB(t)
 	defer cleanup()
@@ -1003,3 +1175,41 @@ func TestSearchTransactionsForRemoval(t *testing.T) {
 		}
 	})
 }
+
+func TestSumExpensesMonthToDate(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	txs := []struct {
+		categoryID int64
+		amount     int64
+		date       time.Time
+	}{
+		{1, -1000, time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{2, -2500, time.Date(2024, 6, 15, 23, 30, 0, 0, time.UTC)},
+		{1, -4000, time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)}, // after day 15
+		{4, 300000, time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)}, // income
+		{1, -7000, time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)}, // other month
+	}
+	for _, tx := range txs {
+		if _, err := queries.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  tx.categoryID,
+			Amount:      tx.amount,
+			Currency:    "USD",
+			Description: "to date",
+			Date:        tx.date,
+		}); err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+	}
+
+	spent, err := queries.SumExpensesMonthToDate(ctx, db.SumExpensesMonthToDateParams{Year: "2024", Month: 6, Day: 15})
+	if err != nil {
+		t.Fatalf("SumExpensesMonthToDate() error = %v", err)
+	}
+	if spent != 3500 {
+		t.Errorf("SumExpensesMonthToDate() = %d, want 3500", spent)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+// A fiscal year is labeled by the calendar year it starts in: with a start
+// month of April, fiscal 2024 runs from April 2024 through March 2025. A
+// start month of January makes fiscal years match calendar years.
+
+// fiscalStart returns the configured first month of the fiscal year,
+// defaulting to January.
+func (app *Application) fiscalStart() int {
+	if app.Config.FiscalStart < 1 || app.Config.FiscalStart > 12 {
+		return 1
+	}
+	return app.Config.FiscalStart
+}
+
+// fiscalYearOf returns the label of the fiscal year containing t.
+func fiscalYearOf(t time.Time, startMonth int) int {
+	if int(t.Month()) < startMonth {
+		return t.Year() - 1
+	}
+	return t.Year()
+}
+
+// fiscalYearRange returns the first day of the fiscal year and the first day
+// of the next one as YYYY-MM-DD, for half-open date comparisons.
+func fiscalYearRange(year, startMonth int) (start, end string) {
+	first := time.Date(year, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	return first.Format("2006-01-02"), first.AddDate(1, 0, 0).Format("2006-01-02")
+}
+
+// currentFiscalYear returns the fiscal year label for today.
+func (app *Application) currentFiscalYear() int {
+	return fiscalYearOf(time.Now(), app.fiscalStart())
+}
+
+// fiscalYearParam parses the ?year= query value, defaulting to the current
+// fiscal year.
+func (app *Application) fiscalYearParam(v string) (string, int, error) {
+	if v == "" {
+		year := app.currentFiscalYear()
+		return strconv.Itoa(year), year, nil
+	}
+	year, err := strconv.Atoi(v)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid year %q", v)
+	}
+	return v, year, nil
+}
+
+// fiscalYears returns the fiscal years that have transactions, newest
+// first, always including the current one.
+func (app *Application) fiscalYears(ctx context.Context) ([]db.GetDistinctTransactionYearsRow, error) {
+	periods, err := app.Q.GetDistinctYearMonths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startMonth := app.fiscalStart()
+	seen := map[int64]bool{int64(app.currentFiscalYear()): true}
+	for _, p := range periods {
+		first := time.Date(int(p.Year), time.Month(p.Month), 1, 0, 0, 0, 0, time.UTC)
+		seen[int64(fiscalYearOf(first, startMonth))] = true
+	}
+
+	years := make([]db.GetDistinctTransactionYearsRow, 0, len(seen))
+	for y := range seen {
+		years = append(years, db.GetDistinctTransactionYearsRow{Year: y})
+	}
+	sort.Slice(years, func(i, j int) bool { return years[i].Year > years[j].Year })
+	return years, nil
+}
+
+// weekBounds returns the Monday starting the week containing now, shifted by
+// offset weeks, and the Monday after it for half-open comparisons.
+func weekBounds(now time.Time, offset int) (start, end time.Time) {
+	daysSinceMonday := (int(now.Weekday()) + 6) % 7
+	start = time.Date(now.Year(), now.Month(), now.Day()-daysSinceMonday+7*offset, 0, 0, 0, 0, now.Location())
+	return start, start.AddDate(0, 0, 7)
+}
+
+// listFiscalYearTransactions returns a page of the fiscal year's transactions.
+func (app *Application) listFiscalYearTransactions(ctx context.Context, year int, includeDeleted bool, sort string, limit, offset int64) ([]db.ListTransactionsByYearPaginatedRow, error) {
+	start, end := fiscalYearRange(year, app.fiscalStart())
+	return app.listRangeTransactions(ctx, start, end, includeDeleted, sort, limit, offset)
+}
+
+// listRangeTransactions returns a page of transactions dated in [start, end),
+// in the order named by sort (see transactionSortParam).
+func (app *Application) listRangeTransactions(ctx context.Context, start, end string, includeDeleted bool, sort string, limit, offset int64) ([]db.ListTransactionsByYearPaginatedRow, error) {
+	rows, err := app.Q.ListTransactionsByFiscalYear(ctx, db.ListTransactionsByFiscalYearParams{
+		Sort:           sort,
+		Start:          start,
+		End:            end,
+		IncludeDeleted: includeDeleted,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]db.ListTransactionsByYearPaginatedRow, len(rows))
+	for i, row := range rows {
+		txs[i] = db.ListTransactionsByYearPaginatedRow(row)
+	}
+	return txs, nil
+}
+
+// countFiscalYearTransactions counts the fiscal year's transactions.
+func (app *Application) countFiscalYearTransactions(ctx context.Context, year int, includeDeleted bool) (int64, error) {
+	start, end := fiscalYearRange(year, app.fiscalStart())
+	return app.countRangeTransactions(ctx, start, end, includeDeleted)
+}
+
+// countRangeTransactions counts transactions dated in [start, end).
+func (app *Application) countRangeTransactions(ctx context.Context, start, end string, includeDeleted bool) (int64, error) {
+	return app.Q.CountTransactionsByFiscalYear(ctx, db.CountTransactionsByFiscalYearParams{
+		Start:          start,
+		End:            end,
+		IncludeDeleted: includeDeleted,
+	})
+}
+
+// countFiscalYearDeleted counts the soft-deleted transactions in the fiscal
+// year.
+func (app *Application) countFiscalYearDeleted(ctx context.Context, year int) (int64, error) {
+	start, end := fiscalYearRange(year, app.fiscalStart())
+	return app.Q.CountDeletedTransactionsByFiscalYear(ctx, db.CountDeletedTransactionsByFiscalYearParams{Start: start, End: end})
+}
+
+// fiscalYearCategoryTotals returns per-category totals for the fiscal year.
+func (app *Application) fiscalYearCategoryTotals(ctx context.Context, year int) ([]db.GetCategoryTotalsByYearRow, error) {
+	start, end := fiscalYearRange(year, app.fiscalStart())
+	return app.rangeCategoryTotals(ctx, start, end)
+}
+
+// rangeCategoryTotals returns per-category totals for transactions dated in
+// [start, end).
+func (app *Application) rangeCategoryTotals(ctx context.Context, start, end string) ([]db.GetCategoryTotalsByYearRow, error) {
+	rows, err := app.Q.GetCategoryTotalsByFiscalYear(ctx, db.GetCategoryTotalsByFiscalYearParams{Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+	totals := make([]db.GetCategoryTotalsByYearRow, len(rows))
+	for i, row := range rows {
+		totals[i] = db.GetCategoryTotalsByYearRow(row)
+	}
+	return totals, nil
+}
+
+// fiscalYearMonthlyTotals returns income and expense totals per calendar
+// month for the fiscal year.
+func (app *Application) fiscalYearMonthlyTotals(ctx context.Context, year int) ([]db.GetMonthlyTotalsByYearRow, error) {
+	start, end := fiscalYearRange(year, app.fiscalStart())
+	rows, err := app.Q.GetMonthlyTotalsByFiscalYear(ctx, db.GetMonthlyTotalsByFiscalYearParams{Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+	totals := make([]db.GetMonthlyTotalsByYearRow, len(rows))
+	for i, row := range rows {
+		totals[i] = db.GetMonthlyTotalsByYearRow(row)
+	}
+	return totals, nil
+}
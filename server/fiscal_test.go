@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestFiscalYearOf(t *testing.T) {
+	tests := []struct {
+		name       string
+		date       time.Time
+		startMonth int
+		want       int
+	}{
+		{name: "calendar year january", date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), startMonth: 1, want: 2024},
+		{name: "calendar year december", date: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), startMonth: 1, want: 2024},
+		{name: "april start, march belongs to prior year", date: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), startMonth: 4, want: 2024},
+		{name: "april start, april starts new year", date: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), startMonth: 4, want: 2025},
+		{name: "december start", date: time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC), startMonth: 12, want: 2024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fiscalYearOf(tt.date, tt.startMonth); got != tt.want {
+				t.Errorf("fiscalYearOf(%s, %d) = %d, want %d", tt.date.Format("2006-01-02"), tt.startMonth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiscalYearRange(t *testing.T) {
+	start, end := fiscalYearRange(2024, 4)
+	if start != "2024-04-01" || end != "2025-04-01" {
+		t.Errorf("fiscalYearRange(2024, 4) = (%s, %s), want (2024-04-01, 2025-04-01)", start, end)
+	}
+
+	start, end = fiscalYearRange(2024, 1)
+	if start != "2024-01-01" || end != "2025-01-01" {
+		t.Errorf("fiscalYearRange(2024, 1) = (%s, %s), want (2024-01-01, 2025-01-01)", start, end)
+	}
+}
+
+func TestWeekBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		now       time.Time
+		offset    int
+		wantStart string
+	}{
+		{name: "monday is its own start", now: time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), wantStart: "2024-03-04"},
+		{name: "sunday belongs to previous monday", now: time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC), wantStart: "2024-03-04"},
+		{name: "midweek", now: time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC), wantStart: "2024-03-04"},
+		{name: "previous week", now: time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC), offset: -1, wantStart: "2024-02-26"},
+		{name: "next week crosses year", now: time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC), offset: 1, wantStart: "2025-01-06"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := weekBounds(tt.now, tt.offset)
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("weekBounds(%s, %d) start = %s, want %s", tt.now.Format("2006-01-02"), tt.offset, got, tt.wantStart)
+			}
+			if start.Weekday() != time.Monday || start.Hour() != 0 {
+				t.Errorf("weekBounds() start = %v, want Monday midnight", start)
+			}
+			if !end.Equal(start.AddDate(0, 0, 7)) {
+				t.Errorf("weekBounds() end = %v, want a week after %v", end, start)
+			}
+		})
+	}
+}
+
+func TestHandleDashboard_Week(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	weekStart, _ := weekBounds(time.Now(), 0)
+	createTestTransaction(t, app, 1, -1200, "this week lunch", weekStart.Add(12*time.Hour))
+	createTestTransaction(t, app, 1, -3400, "last week dinner", weekStart.AddDate(0, 0, -3))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       string
+		unwanted   string
+	}{
+		{"current week", "?period=week", http.StatusOK, "this week lunch", "last week dinner"},
+		{"previous week", "?period=week&offset=-1", http.StatusOK, "last week dinner", "this week lunch"},
+		{"invalid offset", "?period=week&offset=abc", http.StatusBadRequest, "", ""},
+		{"invalid period", "?period=fortnight", http.StatusBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/dashboard"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.HandleDashboard(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("HandleDashboard() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			body := rec.Body.String()
+			if tt.want != "" && !strings.Contains(body, tt.want) {
+				t.Errorf("Week view missing %q", tt.want)
+			}
+			if tt.unwanted != "" && strings.Contains(body, tt.unwanted) {
+				t.Errorf("Week view should not contain %q", tt.unwanted)
+			}
+		})
+	}
+}
+
+func TestFiscalStartDashboard(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.FiscalStart = 4
+
+	createTestTransaction(t, app, 1, -1000, "groceries 2024-03", time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -2000, "groceries 2024-04", time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -3000, "groceries 2025-03", time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	dashboard := func(year string) string {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard?year="+year, nil)
+		rec := httptest.NewRecorder()
+		app.HandleDashboard(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleDashboard(year=%s) status = %d, want %d", year, rec.Code, http.StatusOK)
+		}
+		return rec.Body.String()
+	}
+
+	body := dashboard("2023")
+	if !strings.Contains(body, "groceries 2024-03") {
+		t.Error("Fiscal 2023 should include March 2024")
+	}
+	if strings.Contains(body, "groceries 2024-04") {
+		t.Error("Fiscal 2023 should not include April 2024")
+	}
+
+	body = dashboard("2024")
+	if !strings.Contains(body, "groceries 2024-04") || !strings.Contains(body, "groceries 2025-03") {
+		t.Error("Fiscal 2024 should include April 2024 through March 2025")
+	}
+	if strings.Contains(body, "groceries 2024-03") {
+		t.Error("Fiscal 2024 should not include March 2024")
+	}
+
+	totals, err := app.fiscalYearCategoryTotals(context.Background(), 2024)
+	if err != nil {
+		t.Fatalf("fiscalYearCategoryTotals() error = %v", err)
+	}
+	for _, ct := range totals {
+		if ct.CategoryName == "Food" && ct.TotalAmount != 5000 {
+			t.Errorf("Fiscal 2024 Food total = %d, want 5000", ct.TotalAmount)
+		}
+	}
+
+	years, err := app.fiscalYears(context.Background())
+	if err != nil {
+		t.Fatalf("fiscalYears() error = %v", err)
+	}
+	found := map[int64]bool{}
+	for _, y := range years {
+		found[y.Year] = true
+	}
+	if !found[2023] || !found[2024] || found[2025] {
+		t.Errorf("fiscalYears() = %+v, want 2023 and 2024 but not 2025", years)
+	}
+}
+
+func TestFiscalStartMonthlyChart(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.FiscalStart = 4
+
+	createTestTransaction(t, app, 1, -2000, "groceries 2024-04", time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -3000, "groceries 2025-03", time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/detailed?year=2024", nil)
+	rec := httptest.NewRecorder()
+	app.HandleDashboardDetailed(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleDashboardDetailed() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+
+	assertOrder := func(want ...string) {
+		t.Helper()
+		last := -1
+		for _, s := range want {
+			i := strings.Index(body, s)
+			if i == -1 {
+				t.Fatalf("%q missing from chart", s)
+			}
+			if i < last {
+				t.Errorf("%q appears out of order, want %v", s, want)
+			}
+			last = i
+		}
+	}
+
+	// The chart runs Apr 2024 to Mar 2025, each bar labelled with its year
+	assertOrder("Apr &#39;24", "Dec &#39;24", "Jan &#39;25", "Mar &#39;25")
+	assertOrder("Expenses: $20.00", "Expenses: $30.00")
+}
+
+func TestHandleDashboardInvalidYear(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard?year=abc", nil)
+	rec := httptest.NewRecorder()
+	app.HandleDashboard(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleDashboard() status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
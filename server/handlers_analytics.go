@@ -0,0 +1,890 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+// PeriodsResponse maps each year that has data to its months with data,
+// newest month first.
+type PeriodsResponse map[string][]int64
+
+// HandlePeriods returns the years and months that contain transactions so a
+// date picker never has to probe empty months.
+func (app *Application) HandlePeriods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	rows, err := app.Q.GetDistinctYearMonths(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load periods", http.StatusInternalServerError)
+		return
+	}
+
+	resp := PeriodsResponse{}
+	for _, row := range rows {
+		year := strconv.FormatInt(row.Year, 10)
+		resp[year] = append(resp[year], row.Month)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CategoryBreakdown is one expense category's share of the year's spending
+type CategoryBreakdown struct {
+	Category   string  `json:"category"`
+	TotalCents int64   `json:"total_cents"`
+	Pct        float64 `json:"pct"`
+}
+
+// HandleCategoryBreakdown returns each expense category's share of the
+// year's total expense, for rendering a pie chart.
+func (app *Application) HandleCategoryBreakdown(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	totals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load category totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := []CategoryBreakdown{}
+	for _, ct := range totals {
+		if ct.CategoryType != "expense" || ct.TotalAmount == 0 {
+			continue
+		}
+		resp = append(resp, CategoryBreakdown{
+			Category:   ct.CategoryName,
+			TotalCents: ct.TotalAmount,
+		})
+	}
+	assignBreakdownPcts(resp)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// assignBreakdownPcts sets each entry's share of the total to one decimal
+// place. Tenths of a percent are handed out by largest remainder so the
+// rounded values always sum to exactly 100.
+func assignBreakdownPcts(entries []CategoryBreakdown) {
+	var sum int64
+	for _, e := range entries {
+		sum += e.TotalCents
+	}
+	if sum == 0 {
+		return
+	}
+
+	tenths := make([]int64, len(entries))
+	remainders := make([]int64, len(entries))
+	var assigned int64
+	for i, e := range entries {
+		tenths[i] = e.TotalCents * 1000 / sum
+		remainders[i] = e.TotalCents * 1000 % sum
+		assigned += tenths[i]
+	}
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := int64(0); i < 1000-assigned; i++ {
+		tenths[order[i]]++
+	}
+
+	for i := range entries {
+		entries[i].Pct = float64(tenths[i]) / 10
+	}
+}
+
+// CategorySpend is an expense category with its total spend for the year
+type CategorySpend struct {
+	Category         string `json:"category"`
+	Icon             string `json:"icon"`
+	Color            string `json:"color"`
+	TotalCents       int64  `json:"total_cents"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// HandleCategoriesBySpend returns expense categories ordered by the year's
+// spend, biggest first. Categories with no spend trail the list.
+func (app *Application) HandleCategoriesBySpend(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	totals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load category totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := []CategorySpend{}
+	for _, ct := range totals {
+		if ct.CategoryType != "expense" {
+			continue
+		}
+		resp = append(resp, CategorySpend{
+			Category:         ct.CategoryName,
+			Icon:             ct.CategoryIcon.String,
+			Color:            ct.CategoryColor.String,
+			TotalCents:       ct.TotalAmount,
+			TransactionCount: ct.TransactionCount,
+		})
+	}
+	sort.SliceStable(resp, func(i, j int) bool {
+		if resp[i].TotalCents != resp[j].TotalCents {
+			return resp[i].TotalCents > resp[j].TotalCents
+		}
+		return resp[i].Category < resp[j].Category
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CategoryTotal is a category's lifetime total across every year
+type CategoryTotal struct {
+	Category         string `json:"category"`
+	Type             string `json:"type"`
+	Icon             string `json:"icon"`
+	Color            string `json:"color"`
+	TotalCents       int64  `json:"total_cents"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// HandleCategoryTotalsAllTime returns every category's all-time total,
+// including categories that were never used.
+func (app *Application) HandleCategoryTotalsAllTime(w http.ResponseWriter, r *http.Request) {
+	totals, err := app.Q.GetCategoryTotalsAllTime(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load category totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]CategoryTotal, 0, len(totals))
+	for _, ct := range totals {
+		resp = append(resp, CategoryTotal{
+			Category:         ct.CategoryName,
+			Type:             ct.CategoryType,
+			Icon:             ct.CategoryIcon.String,
+			Color:            ct.CategoryColor.String,
+			TotalCents:       ct.TotalAmount,
+			TransactionCount: ct.TransactionCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	defaultLargestLimit = 10
+	maxLargestLimit     = 100
+)
+
+// LargestTransaction is a single entry in the largest transactions response
+type LargestTransaction struct {
+	ID           int64  `json:"id"`
+	Amount       int64  `json:"amount"`
+	Description  string `json:"description"`
+	Date         string `json:"date"`
+	CategoryName string `json:"category_name"`
+	CategoryIcon string `json:"category_icon"`
+	CategoryType string `json:"category_type"`
+}
+
+// HandleLargestTransactions returns the year's biggest transactions of one
+// type (expense by default), largest magnitude first, to help spot anomalies.
+func (app *Application) HandleLargestTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	txType := r.URL.Query().Get("type")
+	if txType == "" {
+		txType = "expense"
+	}
+	if txType != "expense" && txType != "income" {
+		http.Error(w, "Invalid type: must be expense or income", http.StatusBadRequest)
+		return
+	}
+
+	limit := int64(defaultLargestLimit)
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxLargestLimit)
+	}
+
+	rows, err := app.Q.ListLargestTransactions(ctx, db.ListLargestTransactionsParams{
+		Year:  yearParam,
+		Type:  txType,
+		Limit: limit,
+	})
+	if err != nil {
+		http.Error(w, "Failed to load transactions", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]LargestTransaction, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, LargestTransaction{
+			ID:           row.ID,
+			Amount:       row.Amount,
+			Description:  row.Description,
+			Date:         row.Date.UTC().Format(time.RFC3339),
+			CategoryName: row.CategoryName,
+			CategoryIcon: row.CategoryIcon.String,
+			CategoryType: row.CategoryType,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// NetWorthPoint is the running balance at the end of one month
+type NetWorthPoint struct {
+	Period       string `json:"period"`
+	IncomeCents  int64  `json:"income_cents"`
+	ExpenseCents int64  `json:"expense_cents"`
+	NetCents     int64  `json:"net_cents"`
+	BalanceCents int64  `json:"balance_cents"`
+}
+
+// HandleNetWorthSeries returns the cumulative monthly balance across every
+// year with data, starting from the ?opening= balance in cents, or the
+// stored opening balance without it. Months without transactions between the
+// first and last month with data are included and carry the prior balance
+// forward.
+func (app *Application) HandleNetWorthSeries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	opening, ok := app.openingParam(w, r)
+	if !ok {
+		return
+	}
+
+	rows, err := app.Q.GetMonthlyTotalsAllYears(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load monthly totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := buildNetWorthSeries(rows, opening)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// openingParam returns the ?opening= balance in cents, falling back to the
+// stored opening balance. It writes the error response and reports false
+// when neither can be read.
+func (app *Application) openingParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	if v := r.URL.Query().Get("opening"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid opening balance", http.StatusBadRequest)
+			return 0, false
+		}
+		return n, true
+	}
+	opening, err := app.openingBalance(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load opening balance", http.StatusInternalServerError)
+		return 0, false
+	}
+	return opening, true
+}
+
+// buildNetWorthSeries folds per-type monthly totals (ordered by year and
+// month) into a gap-free running balance.
+func buildNetWorthSeries(rows []db.GetMonthlyTotalsAllYearsRow, opening int64) []NetWorthPoint {
+	series := []NetWorthPoint{}
+	if len(rows) == 0 {
+		return series
+	}
+
+	type monthKey struct{ year, month int64 }
+	income := map[monthKey]int64{}
+	expense := map[monthKey]int64{}
+	for _, row := range rows {
+		key := monthKey{row.Year, row.Month}
+		if row.CategoryType == "income" {
+			income[key] += row.TotalAmount
+		} else {
+			expense[key] += row.TotalAmount
+		}
+	}
+
+	first, last := rows[0], rows[len(rows)-1]
+	balance := opening
+	for y, m := first.Year, first.Month; y < last.Year || (y == last.Year && m <= last.Month); {
+		key := monthKey{y, m}
+		net := income[key] - expense[key]
+		balance += net
+		series = append(series, NetWorthPoint{
+			Period:       fmt.Sprintf("%04d-%02d", y, m),
+			IncomeCents:  income[key],
+			ExpenseCents: expense[key],
+			NetCents:     net,
+			BalanceCents: balance,
+		})
+
+		m++
+		if m > 12 {
+			m = 1
+			y++
+		}
+	}
+	return series
+}
+
+// BalanceAsOf is the net balance at the end of one day
+type BalanceAsOf struct {
+	Date         string `json:"date"`
+	OpeningCents int64  `json:"opening_cents"`
+	BalanceCents int64  `json:"balance_cents"`
+}
+
+// HandleBalanceAsOf returns the balance after every transaction dated on or
+// before ?date=YYYY-MM-DD, starting from the ?opening= balance in cents or
+// the stored opening balance, for reconciling against a bank statement.
+func (app *Application) HandleBalanceAsOf(w http.ResponseWriter, r *http.Request) {
+	date, err := time.Parse(time.DateOnly, r.URL.Query().Get("date"))
+	if err != nil {
+		http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	opening, ok := app.openingParam(w, r)
+	if !ok {
+		return
+	}
+
+	net, err := app.Q.SumAllTransactionsUpToDate(r.Context(), date.Format(time.DateOnly))
+	if err != nil {
+		http.Error(w, "Failed to sum transactions", http.StatusInternalServerError)
+		return
+	}
+
+	resp := BalanceAsOf{
+		Date:         date.Format(time.DateOnly),
+		OpeningCents: opening,
+		BalanceCents: opening + net,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+const (
+	// velocityTrailingWeeks is how many weeks before this one form the baseline
+	velocityTrailingWeeks = 4
+	// velocityAlertPct is how far above the baseline this week must be to alert
+	velocityAlertPct = 50
+)
+
+// VelocityReport compares this week's expense total with the trailing
+// weekly average. Weeks are rolling 7-day windows ending today.
+type VelocityReport struct {
+	ThisWeek int64   `json:"this_week"`
+	AvgWeek  int64   `json:"avg_week"`
+	OverPct  float64 `json:"over_pct"`
+	Alert    bool    `json:"alert"`
+}
+
+// HandleSpendingVelocity reports whether this week's spending is running
+// well ahead of the trailing four-week average.
+func (app *Application) HandleSpendingVelocity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	start := today.AddDate(0, 0, -7*(velocityTrailingWeeks+1)+1)
+	end := today.AddDate(0, 0, 1)
+
+	rows, err := app.Q.GetWeeklyExpenseTotals(ctx, db.GetWeeklyExpenseTotalsParams{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+	})
+	if err != nil {
+		http.Error(w, "Failed to load weekly totals", http.StatusInternalServerError)
+		return
+	}
+
+	// Week 0 is the oldest trailing week, the last one is this week
+	weekly := make([]int64, velocityTrailingWeeks+1)
+	for _, row := range rows {
+		if row.Week >= 0 && row.Week < int64(len(weekly)) {
+			weekly[row.Week] = row.TotalAmount
+		}
+	}
+
+	resp := computeVelocity(weekly[velocityTrailingWeeks], weekly[:velocityTrailingWeeks])
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// computeVelocity compares thisWeek against the average of the trailing
+// weekly totals. With no trailing spend there is no baseline and no alert.
+func computeVelocity(thisWeek int64, trailing []int64) VelocityReport {
+	report := VelocityReport{ThisWeek: thisWeek}
+	if len(trailing) == 0 {
+		return report
+	}
+
+	var sum int64
+	for _, total := range trailing {
+		sum += total
+	}
+	report.AvgWeek = sum / int64(len(trailing))
+	if report.AvgWeek == 0 {
+		return report
+	}
+
+	over := float64(thisWeek-report.AvgWeek) / float64(report.AvgWeek) * 100
+	report.OverPct = math.Round(over*10) / 10
+	report.Alert = over > velocityAlertPct
+	return report
+}
+
+// ProjectedSavings is the expected income, expense and savings for a whole
+// month, extrapolated from the days elapsed so far.
+type ProjectedSavings struct {
+	ProjectedIncome  int64 `json:"projected_income"`
+	ProjectedExpense int64 `json:"projected_expense"`
+	ProjectedSavings int64 `json:"projected_savings"`
+}
+
+// HandleProjectedSavings projects month-end savings for ?year=&month= (the
+// current month by default) from month-to-date income and spending.
+func (app *Application) HandleProjectedSavings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	now := time.Now()
+	year, month, err := yearMonthParam(r, now)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, now.Location())
+	elapsed, daysInMonth := monthProgress(now, first)
+
+	income, expense, err := app.monthTypeTotals(ctx, first)
+	if err != nil {
+		http.Error(w, "Failed to load month totals", http.StatusInternalServerError)
+		return
+	}
+	lastIncome, _, err := app.monthTypeTotals(ctx, first.AddDate(0, -1, 0))
+	if err != nil {
+		http.Error(w, "Failed to load month totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := computeProjectedSavings(income, expense, lastIncome, elapsed, daysInMonth)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// monthProgress returns how many days of the month starting at first have
+// begun by now: none for a future month, all of them for a past one.
+func monthProgress(now, first time.Time) (elapsed, daysInMonth int) {
+	next := first.AddDate(0, 1, 0)
+	daysInMonth = next.AddDate(0, 0, -1).Day()
+	switch {
+	case now.Before(first):
+		return 0, daysInMonth
+	case now.Before(next):
+		return now.Day(), daysInMonth
+	}
+	return daysInMonth, daysInMonth
+}
+
+// monthTypeTotals returns the income and expense totals of the calendar
+// month starting at first.
+func (app *Application) monthTypeTotals(ctx context.Context, first time.Time) (income, expense int64, err error) {
+	rows, err := app.Q.GetCategoryTotalsByMonth(ctx, first.Format("2006-01"))
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, row := range rows {
+		if row.CategoryType == "income" {
+			income += row.TotalAmount
+		} else {
+			expense += row.TotalAmount
+		}
+	}
+	return income, expense, nil
+}
+
+// computeProjectedSavings extrapolates month-to-date totals to the end of
+// the month. Spending is assumed to continue at the same daily rate. Income
+// usually arrives in a few lump sums, so rather than scaling it the
+// projection expects at least last month's income to recur. A finished
+// month reports its actual totals.
+func computeProjectedSavings(incomeMTD, expenseMTD, lastMonthIncome int64, elapsedDays, daysInMonth int) ProjectedSavings {
+	var p ProjectedSavings
+	switch {
+	case elapsedDays >= daysInMonth:
+		p.ProjectedIncome = incomeMTD
+		p.ProjectedExpense = expenseMTD
+	case elapsedDays <= 0:
+		p.ProjectedIncome = lastMonthIncome
+	default:
+		p.ProjectedIncome = max(incomeMTD, lastMonthIncome)
+		p.ProjectedExpense = int64(math.Round(float64(expenseMTD) * float64(daysInMonth) / float64(elapsedDays)))
+	}
+	p.ProjectedSavings = p.ProjectedIncome - p.ProjectedExpense
+	return p
+}
+
+// MonthToDate is the expense spending of the current month up to and
+// including today.
+type MonthToDate struct {
+	Date       string `json:"date"`
+	SpentCents int64  `json:"spent_cents"`
+}
+
+// HandleMonthToDate returns this month's spending so far. Transactions dated
+// later in the month, e.g. scheduled bills entered early, are not counted.
+func (app *Application) HandleMonthToDate(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+
+	spent, err := app.Q.SumExpensesMonthToDate(r.Context(), db.SumExpensesMonthToDateParams{
+		Year:  strconv.Itoa(now.Year()),
+		Month: int64(now.Month()),
+		Day:   int64(now.Day()),
+	})
+	if err != nil {
+		http.Error(w, "Failed to load month-to-date spending", http.StatusInternalServerError)
+		return
+	}
+
+	resp := MonthToDate{
+		Date:       now.Format("2006-01-02"),
+		SpentCents: spent,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CurrencyCount is the number and signed total of transactions in one currency
+type CurrencyCount struct {
+	Currency   string `json:"currency"`
+	Count      int64  `json:"count"`
+	TotalCents int64  `json:"total_cents"`
+}
+
+// HandleCurrencyBreakdown returns how many transactions exist per currency,
+// most common first, so stray mis-tagged currencies stand out.
+func (app *Application) HandleCurrencyBreakdown(w http.ResponseWriter, r *http.Request) {
+	rows, err := app.Q.GetTransactionCountsByCurrency(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to load currency counts", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]CurrencyCount, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, CurrencyCount{
+			Currency:   row.Currency,
+			Count:      row.Count,
+			TotalCents: row.TotalAmount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DailyTotal is the expense total for one day of a month
+type DailyTotal struct {
+	Day        int   `json:"day"`
+	TotalCents int64 `json:"total_cents"`
+}
+
+// HandleDailyTotals returns the expense total for every day of ?year=&month=
+// (defaulting to the current month), zero-filled so the array always has one
+// entry per day.
+func (app *Application) HandleDailyTotals(w http.ResponseWriter, r *http.Request) {
+	year, month, err := yearMonthParam(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	rows, err := app.Q.GetDailyTotalsByMonth(r.Context(), first.Format("2006-01"))
+	if err != nil {
+		http.Error(w, "Failed to load daily totals", http.StatusInternalServerError)
+		return
+	}
+
+	// The day before the next month starts is the last day of this one
+	days := first.AddDate(0, 1, -1).Day()
+	resp := make([]DailyTotal, days)
+	for i := range resp {
+		resp[i].Day = i + 1
+	}
+	for _, row := range rows {
+		if row.Day >= 1 && row.Day <= int64(days) {
+			resp[row.Day-1].TotalCents = row.TotalAmount
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// BusyDay is one day's expense transaction count and total
+type BusyDay struct {
+	Day              string `json:"day"`
+	TransactionCount int64  `json:"transaction_count"`
+	TotalCents       int64  `json:"total_cents"`
+}
+
+// HandleBusiestDays returns the days of ?year= with the most expense
+// transactions, busiest first, up to ?limit= days. Ties go to the day that
+// spent more.
+func (app *Application) HandleBusiestDays(w http.ResponseWriter, r *http.Request) {
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	limit := int64(defaultLargestLimit)
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxLargestLimit)
+	}
+
+	rows, err := app.Q.GetBusiestDays(r.Context(), db.GetBusiestDaysParams{
+		Year:  yearParam,
+		Limit: limit,
+	})
+	if err != nil {
+		http.Error(w, "Failed to load busiest days", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]BusyDay, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, BusyDay{
+			Day:              row.Day,
+			TransactionCount: row.TransactionCount,
+			TotalCents:       row.TotalAmount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// TagTotal is a tag with the number and total of its transactions for a year
+type TagTotal struct {
+	Tag              string `json:"tag"`
+	TransactionCount int64  `json:"transaction_count"`
+	TotalCents       int64  `json:"total_cents"`
+}
+
+// HandleTagTotals returns each tag used in ?year= with its transaction count
+// and total, biggest total first.
+func (app *Application) HandleTagTotals(w http.ResponseWriter, r *http.Request) {
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.GetTagTotalsByYear(r.Context(), yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load tag totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]TagTotal, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, TagTotal{
+			Tag:              row.Tag,
+			TransactionCount: row.TransactionCount,
+			TotalCents:       row.TotalAmount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CategoryAverage is an expense category's average transaction size for a year
+type CategoryAverage struct {
+	Category         string `json:"category"`
+	AvgCents         int64  `json:"avg_cents"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// HandleAverageByCategory returns the average expense per transaction for
+// each expense category in ?year=, largest first. Unused categories report a
+// zero average and count.
+func (app *Application) HandleAverageByCategory(w http.ResponseWriter, r *http.Request) {
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.GetAverageAmountByCategory(r.Context(), yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load category averages", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]CategoryAverage, 0, len(rows))
+	for _, row := range rows {
+		resp = append(resp, CategoryAverage{
+			Category:         row.CategoryName,
+			AvgCents:         row.AvgAmount,
+			TransactionCount: row.TransactionCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DiscretionaryCategory is one category's share of discretionary spending
+type DiscretionaryCategory struct {
+	Category         string `json:"category"`
+	TotalCents       int64  `json:"total_cents"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// DiscretionaryResponse is the year's expense total left after leaving out
+// fixed-cost categories, with the remaining categories' breakdown.
+type DiscretionaryResponse struct {
+	Year       string                  `json:"year"`
+	Excluded   []string                `json:"excluded"`
+	TotalCents int64                   `json:"total_cents"`
+	Categories []DiscretionaryCategory `json:"categories"`
+}
+
+// HandleDiscretionarySpending returns the year's expense total excluding the
+// comma-separated category names in ?exclude=, e.g. Housing,Subscriptions.
+func (app *Application) HandleDiscretionarySpending(w http.ResponseWriter, r *http.Request) {
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	excluded := []string{}
+	for _, name := range strings.Split(r.URL.Query().Get("exclude"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			excluded = append(excluded, name)
+		}
+	}
+
+	rows, err := app.Q.GetExpenseTotalExcludingCategories(r.Context(), db.GetExpenseTotalExcludingCategoriesParams{
+		Year:     yearParam,
+		Excluded: strings.Join(excluded, ","),
+	})
+	if err != nil {
+		http.Error(w, "Failed to load discretionary spending", http.StatusInternalServerError)
+		return
+	}
+
+	resp := DiscretionaryResponse{
+		Year:       yearParam,
+		Excluded:   excluded,
+		Categories: make([]DiscretionaryCategory, 0, len(rows)),
+	}
+	for _, row := range rows {
+		resp.TotalCents += row.TotalAmount
+		resp.Categories = append(resp.Categories, DiscretionaryCategory{
+			Category:         row.CategoryName,
+			TotalCents:       row.TotalAmount,
+			TransactionCount: row.TransactionCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// WeekdayTotal is the expense total for one day of the week; Weekday runs
+// from 0 (Sunday) to 6 (Saturday).
+type WeekdayTotal struct {
+	Weekday          int    `json:"weekday"`
+	Label            string `json:"label"`
+	TotalCents       int64  `json:"total_cents"`
+	TransactionCount int64  `json:"transaction_count"`
+}
+
+// HandleWeekdayTotals returns the year's expense totals for each day of the
+// week, Sunday first, zero-filled so there are always seven entries.
+func (app *Application) HandleWeekdayTotals(w http.ResponseWriter, r *http.Request) {
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.GetTotalsByWeekday(r.Context(), yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load weekday totals", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]WeekdayTotal, 7)
+	for i := range resp {
+		resp[i] = WeekdayTotal{Weekday: i, Label: time.Weekday(i).String()}
+	}
+	for _, row := range rows {
+		if row.Weekday >= 0 && row.Weekday < 7 {
+			resp[row.Weekday].TotalCents = row.TotalAmount
+			resp[row.Weekday].TransactionCount = row.TransactionCount
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,942 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// createTestTransaction inserts a transaction for user 1 and fails the test on error.
+func createTestTransaction(t *testing.T, app *Application, categoryID, amount int64, desc string, date time.Time) db.Transaction {
+	t.Helper()
+
+	tx, err := app.Q.CreateTransaction(context.Background(), db.CreateTransactionParams{
+		UserID:      1,
+		CategoryID:  categoryID,
+		Amount:      amount,
+		Currency:    "USD",
+		Description: desc,
+		Date:        date,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create transaction: %v", err)
+	}
+	return tx
+}
+
+func TestHandlePeriods(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -1000, "January lunch", time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -2000, "March dinner", time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -3000, "March snack", time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 2, -500, "Old taxi", time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/periods", nil)
+	rec := httptest.NewRecorder()
+
+	app.HandlePeriods(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandlePeriods() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp PeriodsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	months := resp["2024"]
+	if len(months) != 2 || months[0] != 3 || months[1] != 1 {
+		t.Errorf("2024 months = %v, want [3 1]", months)
+	}
+	if got := resp["2023"]; len(got) != 1 || got[0] != 12 {
+		t.Errorf("2023 months = %v, want [12]", got)
+	}
+	if len(resp) != 2 {
+		t.Errorf("Expected 2 years, got %d", len(resp))
+	}
+}
+
+func TestHandleCategoryBreakdown(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -2000, "Groceries", date)
+	createTestTransaction(t, app, 1, -1000, "Pizza", date)
+	createTestTransaction(t, app, 2, -1000, "Taxi", date)
+	createTestTransaction(t, app, 4, 500000, "Salary", date)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/breakdown?year=2024", nil)
+	rec := httptest.NewRecorder()
+
+	app.HandleCategoryBreakdown(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleCategoryBreakdown() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp []CategoryBreakdown
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want := []CategoryBreakdown{
+		{Category: "Food", TotalCents: 3000, Pct: 75},
+		{Category: "Transport", TotalCents: 1000, Pct: 25},
+	}
+	if len(resp) != len(want) {
+		t.Fatalf("Expected %d categories, got %d: %+v", len(want), len(resp), resp)
+	}
+	for i := range want {
+		if resp[i] != want[i] {
+			t.Errorf("resp[%d] = %+v, want %+v", i, resp[i], want[i])
+		}
+	}
+}
+
+func TestAssignBreakdownPcts(t *testing.T) {
+	entries := []CategoryBreakdown{
+		{Category: "A", TotalCents: 100},
+		{Category: "B", TotalCents: 100},
+		{Category: "C", TotalCents: 100},
+	}
+
+	assignBreakdownPcts(entries)
+
+	var tenths int
+	for _, e := range entries {
+		tenths += int(e.Pct*10 + 0.5)
+	}
+	if tenths != 1000 {
+		t.Errorf("Percentages sum to %.1f, want 100", float64(tenths)/10)
+	}
+	if entries[0].Pct != 33.4 || entries[1].Pct != 33.3 || entries[2].Pct != 33.3 {
+		t.Errorf("Pcts = %v/%v/%v, want 33.4/33.3/33.3", entries[0].Pct, entries[1].Pct, entries[2].Pct)
+	}
+}
+
+func TestHandleCategoriesBySpend(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -1500, "Pizza", date)
+	createTestTransaction(t, app, 3, -90000, "Rent", date)
+	createTestTransaction(t, app, 2, -800, "Taxi", date)
+	createTestTransaction(t, app, 2, -5000, "Old taxi", time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories/by-spend?year=2024", nil)
+	rec := httptest.NewRecorder()
+
+	app.HandleCategoriesBySpend(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleCategoriesBySpend() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp []CategorySpend
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	var order []string
+	for _, c := range resp {
+		order = append(order, c.Category)
+	}
+	want := []string{"Housing", "Food", "Transport"}
+	if len(order) != len(want) {
+		t.Fatalf("Categories = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Categories = %v, want %v", order, want)
+			break
+		}
+	}
+	if resp[0].TotalCents != 90000 {
+		t.Errorf("Top category total = %d, want 90000", resp[0].TotalCents)
+	}
+}
+
+func TestHandleCategoriesBySpend_ZeroSpendTrails(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 2, -800, "Taxi", time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories/by-spend?year=2024", nil)
+	rec := httptest.NewRecorder()
+
+	app.HandleCategoriesBySpend(rec, req)
+
+	var resp []CategorySpend
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp) != 3 || resp[0].Category != "Transport" {
+		t.Fatalf("Expected Transport first of 3 expense categories, got %+v", resp)
+	}
+	for _, c := range resp[1:] {
+		if c.TotalCents != 0 {
+			t.Errorf("Expected zero-spend categories to trail, got %+v", resp)
+		}
+	}
+}
+
+func TestHandleLargestTransactions(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -1500, "Pizza", date)
+	createTestTransaction(t, app, 3, -90000, "Rent", date)
+	createTestTransaction(t, app, 2, -800, "Taxi", date)
+	createTestTransaction(t, app, 1, -12000, "Dinner party", date)
+	createTestTransaction(t, app, 4, 500000, "Salary", date)
+	createTestTransaction(t, app, 3, -200000, "Old deposit", time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	fetch := func(query string) []LargestTransaction {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/largest?"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleLargestTransactions(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleLargestTransactions() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var resp []LargestTransaction
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("largest expenses first and limited", func(t *testing.T) {
+		resp := fetch("year=2024&limit=3")
+
+		want := []string{"Rent", "Dinner party", "Pizza"}
+		if len(resp) != len(want) {
+			t.Fatalf("Expected %d transactions, got %d: %+v", len(want), len(resp), resp)
+		}
+		for i := range want {
+			if resp[i].Description != want[i] {
+				t.Errorf("resp[%d] = %q, want %q", i, resp[i].Description, want[i])
+			}
+		}
+		if resp[0].CategoryName != "Housing" || resp[0].Date == "" {
+			t.Errorf("Expected category and date on results, got %+v", resp[0])
+		}
+	})
+
+	t.Run("income type", func(t *testing.T) {
+		resp := fetch("year=2024&type=income")
+		if len(resp) != 1 || resp[0].Description != "Salary" {
+			t.Errorf("Expected only the salary, got %+v", resp)
+		}
+	})
+}
+
+func TestHandleNetWorthSeries_CarriesAcrossYears(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	// Category IDs from setupTestApp: 1 = Food (expense), 4 = Earned Income
+	createTestTransaction(t, app, 4, 300000, "salary", time.Date(2023, 11, 1, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -50000, "groceries", time.Date(2023, 12, 15, 12, 0, 0, 0, time.UTC))
+	// January 2024 has no data and must carry December's balance
+	createTestTransaction(t, app, 4, 100000, "bonus", time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -20000, "dinner", time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/networth-series?opening=10000", nil)
+	rec := httptest.NewRecorder()
+	app.HandleNetWorthSeries(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleNetWorthSeries() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var series []NetWorthPoint
+	if err := json.NewDecoder(rec.Body).Decode(&series); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want := []NetWorthPoint{
+		{Period: "2023-11", IncomeCents: 300000, ExpenseCents: 0, NetCents: 300000, BalanceCents: 310000},
+		{Period: "2023-12", IncomeCents: 0, ExpenseCents: 50000, NetCents: -50000, BalanceCents: 260000},
+		{Period: "2024-01", IncomeCents: 0, ExpenseCents: 0, NetCents: 0, BalanceCents: 260000},
+		{Period: "2024-02", IncomeCents: 100000, ExpenseCents: 20000, NetCents: 80000, BalanceCents: 340000},
+	}
+	if len(series) != len(want) {
+		t.Fatalf("Expected %d points, got %d: %+v", len(want), len(series), series)
+	}
+	for i := range want {
+		if series[i] != want[i] {
+			t.Errorf("series[%d] = %+v, want %+v", i, series[i], want[i])
+		}
+	}
+}
+
+func TestHandleNetWorthSeries_InvalidOpening(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/networth-series?opening=abc", nil)
+	rec := httptest.NewRecorder()
+	app.HandleNetWorthSeries(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleNetWorthSeries() status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestComputeVelocity(t *testing.T) {
+	tests := []struct {
+		name      string
+		thisWeek  int64
+		trailing  []int64
+		wantAvg   int64
+		wantPct   float64
+		wantAlert bool
+	}{
+		{name: "well above average", thisWeek: 20000, trailing: []int64{10000, 10000, 10000, 10000}, wantAvg: 10000, wantPct: 100, wantAlert: true},
+		{name: "exactly fifty percent over", thisWeek: 15000, trailing: []int64{10000, 10000, 10000, 10000}, wantAvg: 10000, wantPct: 50, wantAlert: false},
+		{name: "just over threshold", thisWeek: 15100, trailing: []int64{10000, 10000, 10000, 10000}, wantAvg: 10000, wantPct: 51, wantAlert: true},
+		{name: "below average", thisWeek: 5000, trailing: []int64{8000, 12000, 10000, 10000}, wantAvg: 10000, wantPct: -50, wantAlert: false},
+		{name: "uneven weeks", thisWeek: 30000, trailing: []int64{0, 40000, 0, 0}, wantAvg: 10000, wantPct: 200, wantAlert: true},
+		{name: "no baseline", thisWeek: 5000, trailing: []int64{0, 0, 0, 0}, wantAvg: 0, wantPct: 0, wantAlert: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeVelocity(tt.thisWeek, tt.trailing)
+			if got.ThisWeek != tt.thisWeek {
+				t.Errorf("ThisWeek = %d, want %d", got.ThisWeek, tt.thisWeek)
+			}
+			if got.AvgWeek != tt.wantAvg {
+				t.Errorf("AvgWeek = %d, want %d", got.AvgWeek, tt.wantAvg)
+			}
+			if got.OverPct != tt.wantPct {
+				t.Errorf("OverPct = %v, want %v", got.OverPct, tt.wantPct)
+			}
+			if got.Alert != tt.wantAlert {
+				t.Errorf("Alert = %v, want %v", got.Alert, tt.wantAlert)
+			}
+		})
+	}
+}
+
+func TestComputeProjectedSavings(t *testing.T) {
+	tests := []struct {
+		name        string
+		incomeMTD   int64
+		expenseMTD  int64
+		lastIncome  int64
+		elapsed     int
+		daysInMonth int
+		want        ProjectedSavings
+	}{
+		{
+			name:      "mid-month extrapolates spending",
+			incomeMTD: 500000, expenseMTD: 100000, lastIncome: 500000, elapsed: 10, daysInMonth: 30,
+			want: ProjectedSavings{ProjectedIncome: 500000, ProjectedExpense: 300000, ProjectedSavings: 200000},
+		},
+		{
+			name:      "salary not yet paid expects last month's",
+			incomeMTD: 0, expenseMTD: 50000, lastIncome: 400000, elapsed: 5, daysInMonth: 31,
+			want: ProjectedSavings{ProjectedIncome: 400000, ProjectedExpense: 310000, ProjectedSavings: 90000},
+		},
+		{
+			name:      "extra income this month counts",
+			incomeMTD: 600000, expenseMTD: 0, lastIncome: 400000, elapsed: 15, daysInMonth: 30,
+			want: ProjectedSavings{ProjectedIncome: 600000, ProjectedExpense: 0, ProjectedSavings: 600000},
+		},
+		{
+			name:      "overspending projects a loss",
+			incomeMTD: 100000, expenseMTD: 90000, lastIncome: 100000, elapsed: 14, daysInMonth: 28,
+			want: ProjectedSavings{ProjectedIncome: 100000, ProjectedExpense: 180000, ProjectedSavings: -80000},
+		},
+		{
+			name:      "expense rounds to the nearest cent",
+			incomeMTD: 0, expenseMTD: 1000, lastIncome: 0, elapsed: 3, daysInMonth: 31,
+			want: ProjectedSavings{ProjectedIncome: 0, ProjectedExpense: 10333, ProjectedSavings: -10333},
+		},
+		{
+			name:      "finished month reports actuals",
+			incomeMTD: 300000, expenseMTD: 250000, lastIncome: 500000, elapsed: 30, daysInMonth: 30,
+			want: ProjectedSavings{ProjectedIncome: 300000, ProjectedExpense: 250000, ProjectedSavings: 50000},
+		},
+		{
+			name:      "future month expects last month's income only",
+			incomeMTD: 0, expenseMTD: 0, lastIncome: 500000, elapsed: 0, daysInMonth: 31,
+			want: ProjectedSavings{ProjectedIncome: 500000, ProjectedExpense: 0, ProjectedSavings: 500000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeProjectedSavings(tt.incomeMTD, tt.expenseMTD, tt.lastIncome, tt.elapsed, tt.daysInMonth)
+			if got != tt.want {
+				t.Errorf("computeProjectedSavings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleProjectedSavings(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 4, 500000, "salary", date)
+	createTestTransaction(t, app, 3, -120000, "rent", date)
+	createTestTransaction(t, app, 1, -30000, "groceries", date)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/projected-savings?year=2024&month=3", nil)
+	rec := httptest.NewRecorder()
+	app.HandleProjectedSavings(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleProjectedSavings() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got ProjectedSavings
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	// March 2024 is over, so the projection is what actually happened
+	want := ProjectedSavings{ProjectedIncome: 500000, ProjectedExpense: 150000, ProjectedSavings: 350000}
+	if got != want {
+		t.Errorf("HandleProjectedSavings() = %+v, want %+v", got, want)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/analytics/projected-savings?month=0", nil)
+	rec = httptest.NewRecorder()
+	app.HandleProjectedSavings(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid month status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleSpendingVelocity(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	now := time.Now().UTC()
+	// One 100.00 expense in each trailing week, 300.00 this week
+	for week := 1; week <= 4; week++ {
+		createTestTransaction(t, app, 1, -10000, "groceries", now.AddDate(0, 0, -7*week))
+	}
+	createTestTransaction(t, app, 1, -30000, "splurge", now)
+	// Income and older spending must not count
+	createTestTransaction(t, app, 4, 500000, "salary", now)
+	createTestTransaction(t, app, 1, -99999, "ancient", now.AddDate(0, 0, -60))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/velocity", nil)
+	rec := httptest.NewRecorder()
+	app.HandleSpendingVelocity(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleSpendingVelocity() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got VelocityReport
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := VelocityReport{ThisWeek: 30000, AvgWeek: 10000, OverPct: 200, Alert: true}
+	if got != want {
+		t.Errorf("HandleSpendingVelocity() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleCurrencyBreakdown(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		createTestTransaction(t, app, 1, -1000, "lunch", date)
+	}
+	createTestTransaction(t, app, 4, 50000, "salary", date)
+	for _, amount := range []int64{-2500, -700} {
+		tx := createTestTransaction(t, app, 2, amount, "train", date)
+		if err := app.Q.UpdateTransactionCurrency(context.Background(), db.UpdateTransactionCurrencyParams{
+			Currency: "EUR",
+			ID:       tx.ID,
+			UserID:   1,
+		}); err != nil {
+			t.Fatalf("Failed to set currency: %v", err)
+		}
+	}
+	// Deleted transactions are not counted
+	deleted := createTestTransaction(t, app, 1, -9999, "refunded", date)
+	if err := app.Q.SoftDeleteTransaction(context.Background(), db.SoftDeleteTransactionParams{ID: deleted.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to delete transaction: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/currencies", nil)
+	rec := httptest.NewRecorder()
+	app.HandleCurrencyBreakdown(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleCurrencyBreakdown() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []CurrencyCount
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []CurrencyCount{
+		{Currency: "USD", Count: 4, TotalCents: 47000},
+		{Currency: "EUR", Count: 2, TotalCents: -3200},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HandleCurrencyBreakdown() returned %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleCategoryTotalsAllTime(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -1500, "lunch", time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -2500, "dinner", time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC))
+	deleted := createTestTransaction(t, app, 1, -9999, "refunded", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err := app.Q.SoftDeleteTransaction(context.Background(), db.SoftDeleteTransactionParams{ID: deleted.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to delete transaction: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/category-totals/all", nil)
+	rec := httptest.NewRecorder()
+	app.HandleCategoryTotalsAllTime(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleCategoryTotalsAllTime() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []CategoryTotal
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("HandleCategoryTotalsAllTime() returned %d categories, want 4 (zero categories included): %+v", len(got), got)
+	}
+
+	byName := make(map[string]CategoryTotal, len(got))
+	for _, ct := range got {
+		byName[ct.Category] = ct
+	}
+	if food := byName["Food"]; food.TotalCents != 4000 || food.TransactionCount != 2 {
+		t.Errorf("Food = %+v, want total 4000 across 2 transactions", food)
+	}
+	if transport, ok := byName["Transport"]; !ok || transport.TotalCents != 0 {
+		t.Errorf("Transport = %+v, want zero total", transport)
+	}
+}
+
+func TestHandleDailyTotals(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -1200, "lunch", time.Date(2024, 2, 3, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 2, -800, "bus", time.Date(2024, 2, 3, 18, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -4500, "leap dinner", time.Date(2024, 2, 29, 19, 0, 0, 0, time.UTC))
+	// Income and other months are not counted
+	createTestTransaction(t, app, 4, 300000, "salary", time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -9900, "march groceries", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name     string
+		query    string
+		wantDays int
+		want     map[int]int64
+	}{
+		{"leap february", "?year=2024&month=2", 29, map[int]int64{1: 0, 3: 2000, 29: 4500}},
+		{"common february", "?year=2023&month=2", 28, map[int]int64{3: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/analytics/daily"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.HandleDailyTotals(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("HandleDailyTotals() status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			var got []DailyTotal
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if len(got) != tt.wantDays {
+				t.Fatalf("HandleDailyTotals() returned %d days, want %d", len(got), tt.wantDays)
+			}
+			for i, d := range got {
+				if d.Day != i+1 {
+					t.Errorf("entry %d day = %d, want %d", i, d.Day, i+1)
+				}
+			}
+			for day, cents := range tt.want {
+				if got[day-1].TotalCents != cents {
+					t.Errorf("day %d total = %d, want %d", day, got[day-1].TotalCents, cents)
+				}
+			}
+		})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/daily?year=2024&month=13", nil)
+	rec := httptest.NewRecorder()
+	app.HandleDailyTotals(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleDailyTotals() invalid month status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleTagTotals(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	ctx := context.Background()
+	tag := func(tx db.Transaction, tags ...string) {
+		t.Helper()
+		for _, name := range tags {
+			if err := app.Q.AddTransactionTag(ctx, db.AddTransactionTagParams{TransactionID: tx.ID, Tag: name}); err != nil {
+				t.Fatalf("Failed to tag transaction: %v", err)
+			}
+		}
+	}
+
+	date := time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC)
+	tag(createTestTransaction(t, app, 2, -30000, "flight", date), "vacation", "reimbursable")
+	tag(createTestTransaction(t, app, 1, -4500, "beach dinner", date), "vacation")
+	tag(createTestTransaction(t, app, 2, -1200, "client taxi", date), "reimbursable")
+	// Other years and deleted transactions are not counted
+	tag(createTestTransaction(t, app, 1, -9900, "old trip", time.Date(2023, 7, 10, 12, 0, 0, 0, time.UTC)), "vacation")
+	deleted := createTestTransaction(t, app, 1, -7700, "cancelled hotel", date)
+	tag(deleted, "vacation")
+	if err := app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: deleted.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to delete transaction: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/tags?year=2024", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTagTotals(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTagTotals() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []TagTotal
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []TagTotal{
+		{Tag: "vacation", TransactionCount: 2, TotalCents: 34500},
+		{Tag: "reimbursable", TransactionCount: 2, TotalCents: 31200},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HandleTagTotals() returned %d tags, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleTagTotals_HashtagsFromEntry(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	postTransaction(app, url.Values{"input": {"120 hotel #Trip (#work)"}})
+	postTransaction(app, url.Values{"input": {"30 taxi #trip"}})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/tags", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTagTotals(rec, req)
+
+	var got []TagTotal
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []TagTotal{
+		{Tag: "trip", TransactionCount: 2, TotalCents: 15000},
+		{Tag: "work", TransactionCount: 1, TotalCents: 12000},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("HandleTagTotals() = %+v, want %+v", got, want)
+	}
+}
+
+func TestHandleAverageByCategory(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -1000, "lunch", date)
+	createTestTransaction(t, app, 1, -3000, "dinner", date)
+	createTestTransaction(t, app, 4, 500000, "salary", date)
+	createTestTransaction(t, app, 1, -90000, "last year's feast", time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/avg-by-category?year=2024", nil)
+	rec := httptest.NewRecorder()
+	app.HandleAverageByCategory(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleAverageByCategory() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []CategoryAverage
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []CategoryAverage{
+		{Category: "Food", AvgCents: 2000, TransactionCount: 2},
+		{Category: "Housing", AvgCents: 0, TransactionCount: 0},
+		{Category: "Transport", AvgCents: 0, TransactionCount: 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HandleAverageByCategory() returned %d categories, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestHandleDiscretionarySpending(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -2000, "groceries", date)
+	createTestTransaction(t, app, 2, -500, "bus", date)
+	createTestTransaction(t, app, 3, -120000, "rent", date)
+	createTestTransaction(t, app, 4, 500000, "salary", date)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/discretionary?year=2024&exclude=Housing,%20Subscriptions", nil)
+	rec := httptest.NewRecorder()
+	app.HandleDiscretionarySpending(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleDiscretionarySpending() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got DiscretionaryResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if got.TotalCents != 2500 {
+		t.Errorf("TotalCents = %d, want 2500 (rent excluded)", got.TotalCents)
+	}
+	if len(got.Excluded) != 2 || got.Excluded[0] != "Housing" || got.Excluded[1] != "Subscriptions" {
+		t.Errorf("Excluded = %v, want [Housing Subscriptions]", got.Excluded)
+	}
+	want := []DiscretionaryCategory{
+		{Category: "Food", TotalCents: 2000, TransactionCount: 1},
+		{Category: "Transport", TotalCents: 500, TransactionCount: 1},
+	}
+	if len(got.Categories) != len(want) {
+		t.Fatalf("Categories = %+v, want %+v", got.Categories, want)
+	}
+	for i := range want {
+		if got.Categories[i] != want[i] {
+			t.Errorf("category %d = %+v, want %+v", i, got.Categories[i], want[i])
+		}
+	}
+}
+
+func TestHandleWeekdayTotals(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	monday := time.Date(2024, 3, 4, 12, 0, 0, 0, time.UTC)
+	saturday := time.Date(2024, 3, 9, 20, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -1500, "lunch", monday)
+	createTestTransaction(t, app, 1, -6000, "night out", saturday)
+	createTestTransaction(t, app, 2, -2500, "taxi home", saturday)
+	// Income is not spending
+	createTestTransaction(t, app, 4, 300000, "salary", monday)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/weekday?year=2024", nil)
+	rec := httptest.NewRecorder()
+	app.HandleWeekdayTotals(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleWeekdayTotals() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var got []WeekdayTotal
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(got) != 7 {
+		t.Fatalf("HandleWeekdayTotals() returned %d entries, want 7", len(got))
+	}
+	want := map[time.Weekday]WeekdayTotal{
+		time.Sunday:   {Weekday: 0, Label: "Sunday"},
+		time.Monday:   {Weekday: 1, Label: "Monday", TotalCents: 1500, TransactionCount: 1},
+		time.Saturday: {Weekday: 6, Label: "Saturday", TotalCents: 8500, TransactionCount: 2},
+	}
+	for day, w := range want {
+		if got[day] != w {
+			t.Errorf("%s = %+v, want %+v", day, got[day], w)
+		}
+	}
+}
+
+func TestHandleMonthToDate(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	now := time.Now().UTC()
+	createTestTransaction(t, app, 1, -1200, "lunch", now)
+	createTestTransaction(t, app, 4, 500000, "salary", now)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/month-to-date", nil)
+	rec := httptest.NewRecorder()
+	app.HandleMonthToDate(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleMonthToDate() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp MonthToDate
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := MonthToDate{Date: now.Format("2006-01-02"), SpentCents: 1200}
+	if resp != want {
+		t.Errorf("HandleMonthToDate() = %+v, want %+v", resp, want)
+	}
+}
+
+func TestHandleBalanceAsOf(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 4, 300000, "salary", time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 3, -120000, "rent", time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -4500, "late dinner", time.Date(2024, 3, 31, 23, 30, 0, 0, time.UTC))
+	// After the statement date
+	createTestTransaction(t, app, 1, -9900, "april groceries", time.Date(2024, 4, 1, 0, 30, 0, 0, time.UTC))
+	createTestTransaction(t, app, 4, 300000, "april salary", time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/balance-asof?date=2024-03-31&opening=50000", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBalanceAsOf(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBalanceAsOf() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp BalanceAsOf
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := BalanceAsOf{Date: "2024-03-31", OpeningCents: 50000, BalanceCents: 50000 + 300000 - 120000 - 4500}
+	if resp != want {
+		t.Errorf("HandleBalanceAsOf() = %+v, want %+v", resp, want)
+	}
+
+	for _, query := range []string{"", "?date=31/03/2024", "?date=2024-03-31&opening=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/balance-asof"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleBalanceAsOf(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("HandleBalanceAsOf(%q) status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHandleBusiestDays(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	busy := time.Date(2024, 7, 6, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []int64{-300, -450, -1200} {
+		createTestTransaction(t, app, 1, amount, "market stall", busy.Add(time.Duration(9+i)*time.Hour))
+	}
+	// One big purchase spends more but is a single transaction
+	createTestTransaction(t, app, 3, -150000, "rent", time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 2, -900, "bus", time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 2, -900, "bus", time.Date(2024, 7, 2, 18, 0, 0, 0, time.UTC))
+	// Income and other years don't count
+	createTestTransaction(t, app, 4, 500000, "salary", busy)
+	createTestTransaction(t, app, 1, -100, "snack", time.Date(2023, 7, 6, 9, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -100, "snack", time.Date(2023, 7, 6, 10, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -100, "snack", time.Date(2023, 7, 6, 11, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -100, "snack", time.Date(2023, 7, 6, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/busiest-days?year=2024&limit=2", nil)
+	rec := httptest.NewRecorder()
+	app.HandleBusiestDays(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBusiestDays() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got []BusyDay
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	want := []BusyDay{
+		{Day: "2024-07-06", TransactionCount: 3, TotalCents: 1950},
+		{Day: "2024-07-02", TransactionCount: 2, TotalCents: 1800},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("HandleBusiestDays() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/analytics/busiest-days?limit=0", nil)
+	rec = httptest.NewRecorder()
+	app.HandleBusiestDays(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid limit status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestYearAnalytics_InvalidYear(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	handlers := map[string]http.HandlerFunc{
+		"largest":         app.HandleLargestTransactions,
+		"by-spend":        app.HandleCategoriesBySpend,
+		"breakdown":       app.HandleCategoryBreakdown,
+		"busiest-days":    app.HandleBusiestDays,
+		"tags":            app.HandleTagTotals,
+		"avg-by-category": app.HandleAverageByCategory,
+		"discretionary":   app.HandleDiscretionarySpending,
+		"weekday":         app.HandleWeekdayTotals,
+	}
+
+	for name, handler := range handlers {
+		for _, year := range []string{"abc", "25", "2024-01"} {
+			req := httptest.NewRequest(http.MethodGet, "/api/analytics/"+name+"?year="+year, nil)
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("%s?year=%s status = %d, want %d", name, year, rec.Code, http.StatusBadRequest)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// AuditEntry is a single audit log entry in the API response
+type AuditEntry struct {
+	ID        int64  `json:"id"`
+	Action    string `json:"action"`
+	EntityID  int64  `json:"entity_id"`
+	Details   string `json:"details"`
+	CreatedAt string `json:"created_at"`
+}
+
+// AuditPageResponse is the response for the audit log endpoint. NextBefore
+// is the cursor for the following page and is omitted on the last page.
+type AuditPageResponse struct {
+	Entries    []AuditEntry `json:"entries"`
+	NextBefore int64        `json:"next_before,omitempty"`
+}
+
+// HandleAuditLog returns audit entries newest first. Pages are keyed by
+// entry ID: pass the previous response's next_before as ?before= to continue.
+func (app *Application) HandleAuditLog(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	limit := int64(defaultAuditPageSize)
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxAuditPageSize)
+	}
+
+	before := int64(math.MaxInt64)
+	if v := r.URL.Query().Get("before"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid before cursor", http.StatusBadRequest)
+			return
+		}
+		before = n
+	}
+
+	rows, err := app.Q.ListAuditEntries(ctx, db.ListAuditEntriesParams{
+		Before: before,
+		Limit:  limit,
+	})
+	if err != nil {
+		http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+
+	resp := AuditPageResponse{Entries: make([]AuditEntry, 0, len(rows))}
+	for _, row := range rows {
+		createdAt := ""
+		if row.CreatedAt.Valid {
+			createdAt = row.CreatedAt.Time.UTC().Format(time.RFC3339)
+		}
+		resp.Entries = append(resp.Entries, AuditEntry{
+			ID:        row.ID,
+			Action:    row.Action,
+			EntityID:  row.EntityID,
+			Details:   row.Details,
+			CreatedAt: createdAt,
+		})
+	}
+	if int64(len(rows)) == limit {
+		resp.NextBefore = rows[len(rows)-1].ID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
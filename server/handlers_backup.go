@@ -2,11 +2,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/calexandrepcjr/cheapskate-finance-tracker/client/templates"
@@ -14,33 +17,83 @@ import (
 
 // BackupStatusResponse is the JSON response for backup status.
 type BackupStatusResponse struct {
-	Enabled      bool   `json:"enabled"`
-	BackupPath   string `json:"backup_path"`
-	LastBackupAt string `json:"last_backup_at"`
+	Enabled         bool   `json:"enabled"`
+	BackupPath      string `json:"backup_path"`
+	LastBackupAt    string `json:"last_backup_at"`
+	IntervalMinutes int    `json:"interval_minutes"`
+	Schedule        string `json:"schedule"`
+	NextBackupAt    string `json:"next_backup_at"`
+	// Manifest describes the latest backup, when one has been written
+	Manifest *BackupManifest `json:"manifest,omitempty"`
 }
 
-// HandleBackupStatus returns the current backup configuration and last backup time.
+// HandleBackupStatus returns the current backup configuration, last backup
+// time and when the next scheduled backup will run.
 func (app *Application) HandleBackupStatus(w http.ResponseWriter, r *http.Request) {
+	enabled := app.Config.BackupPath != ""
+
 	lastBackup := getLastBackupTime()
 	lastBackupStr := ""
 	if !lastBackup.IsZero() {
 		lastBackupStr = lastBackup.UTC().Format(time.RFC3339)
 	}
 
+	// The loop backs up on startup and then every interval, so the next run
+	// is only known once a backup has completed
+	interval := app.backupInterval()
+	schedule := ""
+	nextBackupStr := ""
+	if enabled {
+		schedule = fmt.Sprintf("every %d minutes", int(interval.Minutes()))
+		if !lastBackup.IsZero() {
+			next := lastBackup.Add(interval)
+			nextBackupStr = next.UTC().Format(time.RFC3339)
+		}
+	}
+
 	resp := BackupStatusResponse{
-		Enabled:      app.Config.BackupPath != "",
-		BackupPath:   app.Config.BackupPath,
-		LastBackupAt: lastBackupStr,
+		Enabled:         enabled,
+		BackupPath:      app.Config.BackupPath,
+		LastBackupAt:    lastBackupStr,
+		IntervalMinutes: int(interval.Minutes()),
+		Schedule:        schedule,
+		NextBackupAt:    nextBackupStr,
+	}
+	if enabled {
+		if manifest, err := readBackupManifest(app.Config.BackupPath); err == nil {
+			resp.Manifest = &manifest
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
+// HandleBackupManifest returns the manifest of the latest automatic backup.
+func (app *Application) HandleBackupManifest(w http.ResponseWriter, r *http.Request) {
+	if app.Config.BackupPath == "" {
+		http.Error(w, "Backups are not enabled", http.StatusNotFound)
+		return
+	}
+
+	manifest, err := readBackupManifest(app.Config.BackupPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, "No backup manifest yet", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to read backup manifest", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(manifest)
+}
+
 // HandleBackupDownload creates a consistent SQLite backup and serves it as a download.
 func (app *Application) HandleBackupDownload(w http.ResponseWriter, r *http.Request) {
 	// Create temp file for the backup
-	tmpFile, err := os.CreateTemp("", "cheapskate-backup-*.db")
+	tmpFile, err := os.CreateTemp(app.Config.TempDir, "cheapskate-backup-*.db")
 	if err != nil {
 		http.Error(w, "Failed to create backup", http.StatusInternalServerError)
 		return
@@ -50,7 +103,7 @@ func (app *Application) HandleBackupDownload(w http.ResponseWriter, r *http.Requ
 	defer os.Remove(tmpPath)
 
 	// Perform backup to temp file
-	if err := sqliteBackup(app.DB, tmpPath); err != nil {
+	if err := sqliteBackup(app.DB, tmpPath, nil); err != nil {
 		log.Printf("Backup download failed: %v", err)
 		http.Error(w, "Failed to create backup", http.StatusInternalServerError)
 		return
@@ -63,6 +116,38 @@ func (app *Application) HandleBackupDownload(w http.ResponseWriter, r *http.Requ
 	http.ServeFile(w, r, tmpPath)
 }
 
+// HandleBackupJSON downloads the JSON export written by the last backup. With
+// backups disabled there is no such file, so an export is generated on the
+// fly instead.
+func (app *Application) HandleBackupJSON(w http.ResponseWriter, r *http.Request) {
+	filename := fmt.Sprintf("cheapskate-export-%s.json", time.Now().Format("2006-01-02"))
+
+	if app.Config.BackupPath != "" {
+		path := filepath.Join(app.Config.BackupPath, jsonExportName)
+		if _, err := os.Stat(path); err != nil {
+			http.Error(w, "No JSON export available yet", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		http.ServeFile(w, r, path)
+		return
+	}
+
+	resp, err := app.buildJSONExport(r.Context())
+	if err != nil {
+		log.Printf("JSON export failed: %v", err)
+		http.Error(w, "Failed to build JSON export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(resp)
+}
+
 // HandleBackupRestore accepts a .db file upload and restores it into the live database.
 func (app *Application) HandleBackupRestore(w http.ResponseWriter, r *http.Request) {
 	// Limit upload size to 100MB
@@ -76,7 +161,7 @@ func (app *Application) HandleBackupRestore(w http.ResponseWriter, r *http.Reque
 	defer file.Close()
 
 	// Save to temp file
-	tmpFile, err := os.CreateTemp("", "cheapskate-restore-*.db")
+	tmpFile, err := os.CreateTemp(app.Config.TempDir, "cheapskate-restore-*.db")
 	if err != nil {
 		templates.BackupRestoreError("Failed to process upload").Render(r.Context(), w)
 		return
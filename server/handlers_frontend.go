@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -33,135 +38,167 @@ func (app *Application) HandleHome(w http.ResponseWriter, r *http.Request) {
 
 const transactionsPageSize = 20
 
+// Transaction list orders accepted by ?sort=. Amount orders compare absolute
+// values, so the biggest expense or income comes first regardless of sign.
+const (
+	sortDateDesc   = "date_desc"
+	sortDateAsc    = "date_asc"
+	sortAmountDesc = "amount_desc"
+	sortAmountAsc  = "amount_asc"
+)
+
+// transactionSortParam validates a ?sort= value, defaulting to newest first.
+func transactionSortParam(v string) (string, error) {
+	switch v {
+	case "":
+		return sortDateDesc, nil
+	case sortDateDesc, sortDateAsc, sortAmountDesc, sortAmountAsc:
+		return v, nil
+	}
+	return "", fmt.Errorf("invalid sort %q", v)
+}
+
 func (app *Application) HandleDashboard(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Get year from query param, default to current year
-	yearParam := r.URL.Query().Get("year")
-	if yearParam == "" {
-		yearParam = fmt.Sprintf("%d", time.Now().Year())
+	switch r.URL.Query().Get("period") {
+	case "", "year":
+	case "week":
+		app.handleWeekDashboard(w, r)
+		return
+	default:
+		http.Error(w, "Invalid period", http.StatusBadRequest)
+		return
+	}
+
+	// Get fiscal year from query param, default to current fiscal year
+	yearParam, year, err := app.fiscalYearParam(r.URL.Query().Get("year"))
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	sort, err := transactionSortParam(r.URL.Query().Get("sort"))
+	if err != nil {
+		http.Error(w, "Invalid sort", http.StatusBadRequest)
+		return
 	}
 
 	// Check if we should show deleted transactions
 	showDeleted := r.URL.Query().Get("show_deleted") == "true"
 
-	// Get available years for navigation
-	years, err := app.Q.GetDistinctTransactionYearsWrapped(ctx)
+	// The mosaic can leave out income; the transaction list always shows everything
+	expensesOnly := r.URL.Query().Get("view") == "expenses"
+
+	// Get available years for navigation (always includes the current year)
+	years, err := app.fiscalYears(ctx)
 	if err != nil {
 		http.Error(w, "Failed to load years: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// If no transactions exist yet, add current year to list
-	currentYear := int64(time.Now().Year())
-	hasCurrentYear := false
-	for _, y := range years {
-		if y.Year == currentYear {
-			hasCurrentYear = true
-			break
-		}
-	}
-	if !hasCurrentYear {
-		years = append([]db.GetDistinctTransactionYearsRow{{Year: currentYear}}, years...)
+	// Fetch first page of transactions
+	txs, err := app.listFiscalYearTransactions(ctx, year, showDeleted, sort, transactionsPageSize, 0)
+	if err != nil {
+		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	var totalCount int64
+	// Get total count for pagination
+	totalCount, err := app.countFiscalYearTransactions(ctx, year, showDeleted)
+	if err != nil {
+		http.Error(w, "Failed to count transactions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
 
+	// Count the removed transactions included in the list
+	var removedCount int64
 	if showDeleted {
-		// Fetch with deleted transactions included
-		txsWithDeleted, err := app.Q.ListTransactionsByYearPaginatedWithDeleted(ctx, db.ListTransactionsByYearPaginatedWithDeletedParams{
-			Year:   yearParam,
-			Limit:  transactionsPageSize,
-			Offset: 0,
-		})
+		removedCount, err = app.countFiscalYearDeleted(ctx, year)
 		if err != nil {
-			http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Failed to count removed transactions: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
+	}
 
-		totalCount, err = app.Q.CountTransactionsByYearWithDeleted(ctx, yearParam)
-		if err != nil {
-			http.Error(w, "Failed to count transactions: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
+	// Fetch category totals for the mosaic
+	categoryTotals, err := app.fiscalYearCategoryTotals(ctx, year)
+	if err != nil {
+		http.Error(w, "Failed to load category totals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	hasMore := int64(len(txs)) < totalCount
+	templates.Dashboard(txs, categoryTotals, years, yearParam, sort, totalCount, removedCount, hasMore, showDeleted, expensesOnly).Render(ctx, w)
+}
+
+// handleWeekDashboard renders the dashboard for a single Monday-start week,
+// ?offset= weeks away from the current one.
+func (app *Application) handleWeekDashboard(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-		categoryTotals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	offset := 0
+	if v := r.URL.Query().Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
 		if err != nil {
-			http.Error(w, "Failed to load category totals: "+err.Error(), http.StatusInternalServerError)
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
 			return
 		}
-
-		// Convert WithDeleted rows to standard paginated rows for template reuse
-		txs := make([]db.ListTransactionsByYearPaginatedRow, len(txsWithDeleted))
-		for i, t := range txsWithDeleted {
-			txs[i] = db.ListTransactionsByYearPaginatedRow{
-				ID: t.ID, UserID: t.UserID, CategoryID: t.CategoryID,
-				Amount: t.Amount, Currency: t.Currency, Description: t.Description,
-				Date: t.Date, CreatedAt: t.CreatedAt, DeletedAt: t.DeletedAt,
-				CategoryName: t.CategoryName, CategoryIcon: t.CategoryIcon,
-				CategoryType: t.CategoryType, UserName: t.UserName,
-			}
-		}
-
-		hasMore := int64(len(txs)) < totalCount
-		templates.Dashboard(txs, categoryTotals, years, yearParam, totalCount, hasMore, showDeleted).Render(ctx, w)
-		return
+		offset = n
 	}
 
-	// Fetch first page of transactions (active only)
-	txs, err := app.Q.ListTransactionsByYearPaginated(ctx, db.ListTransactionsByYearPaginatedParams{
-		Year:   yearParam,
-		Limit:  transactionsPageSize,
-		Offset: 0,
-	})
+	weekStart, weekEnd := weekBounds(time.Now(), offset)
+	start, end := weekStart.Format("2006-01-02"), weekEnd.Format("2006-01-02")
+
+	totalCount, err := app.countRangeTransactions(ctx, start, end, false)
 	if err != nil {
-		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to count transactions: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Get total count for pagination
-	totalCount, err = app.Q.CountTransactionsByYear(ctx, yearParam)
+	// A week is small enough to show in full
+	txs, err := app.listRangeTransactions(ctx, start, end, false, sortDateDesc, totalCount, 0)
 	if err != nil {
-		http.Error(w, "Failed to count transactions: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// Fetch category totals for the mosaic
-	categoryTotals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	categoryTotals, err := app.rangeCategoryTotals(ctx, start, end)
 	if err != nil {
 		http.Error(w, "Failed to load category totals: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	hasMore := int64(len(txs)) < totalCount
-
-	templates.Dashboard(txs, categoryTotals, years, yearParam, totalCount, hasMore, showDeleted).Render(ctx, w)
+	templates.DashboardWeek(txs, categoryTotals, weekStart, offset).Render(ctx, w)
 }
 
 func (app *Application) HandleTransactionsPage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	yearParam := r.URL.Query().Get("year")
-	if yearParam == "" {
-		yearParam = fmt.Sprintf("%d", time.Now().Year())
+	yearParam, year, err := app.fiscalYearParam(r.URL.Query().Get("year"))
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	sort, err := transactionSortParam(r.URL.Query().Get("sort"))
+	if err != nil {
+		http.Error(w, "Invalid sort", http.StatusBadRequest)
+		return
 	}
 
 	offsetParam := r.URL.Query().Get("offset")
 	offset, _ := strconv.ParseInt(offsetParam, 10, 64)
 
 	// Fetch page of transactions
-	txs, err := app.Q.ListTransactionsByYearPaginated(ctx, db.ListTransactionsByYearPaginatedParams{
-		Year:   yearParam,
-		Limit:  transactionsPageSize,
-		Offset: offset,
-	})
+	txs, err := app.listFiscalYearTransactions(ctx, year, false, sort, transactionsPageSize, offset)
 	if err != nil {
 		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Get total count for pagination
-	totalCount, err := app.Q.CountTransactionsByYear(ctx, yearParam)
+	totalCount, err := app.countFiscalYearTransactions(ctx, year, false)
 	if err != nil {
 		http.Error(w, "Failed to count transactions: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -170,53 +207,97 @@ func (app *Application) HandleTransactionsPage(w http.ResponseWriter, r *http.Re
 	hasMore := offset+int64(len(txs)) < totalCount
 	nextOffset := offset + int64(len(txs))
 
-	templates.TransactionsList(txs, yearParam, nextOffset, hasMore).Render(ctx, w)
+	templates.TransactionsList(txs, yearParam, sort, nextOffset, hasMore).Render(ctx, w)
 }
 
 func (app *Application) HandleDashboardDetailed(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Get year from query param, default to current year
-	yearParam := r.URL.Query().Get("year")
-	if yearParam == "" {
-		yearParam = fmt.Sprintf("%d", time.Now().Year())
+	// Get fiscal year from query param, default to current fiscal year
+	yearParam, year, err := app.fiscalYearParam(r.URL.Query().Get("year"))
+	if err != nil {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
 	}
 
-	// Get available years for navigation
-	years, err := app.Q.GetDistinctTransactionYearsWrapped(ctx)
+	// Get available years for navigation (always includes the current year)
+	years, err := app.fiscalYears(ctx)
 	if err != nil {
 		http.Error(w, "Failed to load years: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	// If no transactions exist yet, add current year to list
-	currentYear := int64(time.Now().Year())
-	hasCurrentYear := false
-	for _, y := range years {
-		if y.Year == currentYear {
-			hasCurrentYear = true
-			break
-		}
-	}
-	if !hasCurrentYear {
-		years = append([]db.GetDistinctTransactionYearsRow{{Year: currentYear}}, years...)
-	}
-
 	// Fetch category totals for pie chart
-	categoryTotals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	categoryTotals, err := app.fiscalYearCategoryTotals(ctx, year)
 	if err != nil {
 		http.Error(w, "Failed to load category totals: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Fetch monthly totals for bar chart
-	monthlyTotals, err := app.Q.GetMonthlyTotalsByYear(ctx, yearParam)
+	monthlyTotals, err := app.fiscalYearMonthlyTotals(ctx, year)
 	if err != nil {
 		http.Error(w, "Failed to load monthly totals: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	templates.DashboardDetailed(categoryTotals, monthlyTotals, years, yearParam).Render(ctx, w)
+	// Optionally keep only the biggest categories, folding the rest into "Other"
+	if v := r.URL.Query().Get("top"); v != "" {
+		top, err := strconv.Atoi(v)
+		if err != nil || top < 1 {
+			http.Error(w, "Invalid top", http.StatusBadRequest)
+			return
+		}
+		categoryTotals = topCategoryTotals(categoryTotals, top)
+	}
+
+	templates.DashboardDetailed(categoryTotals, monthlyTotals, years, yearParam, year, app.fiscalStart()).Render(ctx, w)
+}
+
+// otherCategoryName labels the bucket topCategoryTotals folds small
+// categories into.
+const otherCategoryName = "Other"
+
+// topCategoryTotals keeps the n largest categories of each type and sums the
+// remaining ones of that type into a single "Other" row. Types are trimmed
+// separately so expenses never absorb income and vice versa.
+func topCategoryTotals(rows []db.GetCategoryTotalsByYearRow, n int) []db.GetCategoryTotalsByYearRow {
+	byType := map[string][]db.GetCategoryTotalsByYearRow{}
+	var types []string
+	for _, row := range rows {
+		if _, ok := byType[row.CategoryType]; !ok {
+			types = append(types, row.CategoryType)
+		}
+		byType[row.CategoryType] = append(byType[row.CategoryType], row)
+	}
+
+	result := make([]db.GetCategoryTotalsByYearRow, 0, len(rows))
+	for _, typ := range types {
+		group := byType[typ]
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].TotalAmount > group[j].TotalAmount
+		})
+		if len(group) <= n {
+			result = append(result, group...)
+			continue
+		}
+
+		result = append(result, group[:n]...)
+		other := db.GetCategoryTotalsByYearRow{
+			CategoryName:  otherCategoryName,
+			CategoryIcon:  sql.NullString{String: "📦", Valid: true},
+			CategoryType:  typ,
+			CategoryColor: sql.NullString{String: "#9CA3AF", Valid: true},
+		}
+		for _, row := range group[n:] {
+			other.TotalAmount += row.TotalAmount
+			other.TransactionCount += row.TransactionCount
+		}
+		if other.TotalAmount > 0 {
+			result = append(result, other)
+		}
+	}
+	return result
 }
 
 func (app *Application) HandleTransactionCreate(w http.ResponseWriter, r *http.Request) {
@@ -229,74 +310,395 @@ func (app *Application) HandleTransactionCreate(w http.ResponseWriter, r *http.R
 	}
 
 	// 1. Parse
-	parsed, err := ParseTransaction(input, app.CatConfig)
+	parsed, err := ParseTransaction(input, app.CatConfig, app.CurrencySymbols)
 	if err != nil {
 		templates.TransactionError("Could not understand that. Try '50 pizza'").Render(r.Context(), w)
 		return
 	}
+	if parsed.Amount == 0 && !app.Config.AllowZero {
+		templates.TransactionError("Amount must be greater than zero").Render(r.Context(), w)
+		return
+	}
 
-	// 2. Resolve Category
-	// For now, we query by name. If not found, try alternative names or use default.
-	var catID int64
-	var catName string
-	var catType string
-	cat, err := app.Q.GetCategoryByName(r.Context(), parsed.Category)
+	currency, cents, err := app.parsedCents(parsed)
 	if err != nil {
-		// Try alternative name for backwards compatibility
-		altName := parsed.Category
-		if parsed.Category == "Earned Income" {
-			altName = "Salary"
-		}
-		cat, err = app.Q.GetCategoryByName(r.Context(), altName)
+		templates.TransactionError(err.Error()).Render(r.Context(), w)
+		return
+	}
+
+	// 2. Resolve Category. An explicit "@Category" that matches nothing gets a
+	// suggestion instead of silently landing in the fallback category; with
+	// -strict-categories an inferred category that doesn't exist is an error.
+	var cat db.Category
+	if parsed.CategoryOverride != "" {
+		found, suggestion, err := app.lookupCategoryOverride(r.Context(), parsed.CategoryOverride)
 		if err != nil {
-			// Fallback to first category
-			cats, _ := app.Q.ListCategories(r.Context())
-			if len(cats) > 0 {
-				catID = cats[0].ID
-				catName = cats[0].Name
-				catType = cats[0].Type
-			} else {
-				catID = 1
-				catName = "Unknown"
-				catType = "expense"
+			templates.TransactionError("Failed to load categories: "+err.Error()).Render(r.Context(), w)
+			return
+		}
+		if found == nil {
+			if suggestion == "" {
+				templates.TransactionError(fmt.Sprintf("Unknown category %q", parsed.CategoryOverride)).Render(r.Context(), w)
+				return
 			}
-		} else {
-			catID = cat.ID
-			catName = cat.Name
-			catType = cat.Type
+			corrected := reOverride.ReplaceAllLiteralString(input, " @"+strings.ReplaceAll(suggestion, " ", "_"))
+			templates.CategorySuggestion(parsed.CategoryOverride, suggestion, strings.TrimSpace(corrected)).Render(r.Context(), w)
+			return
+		}
+		cat = *found
+	} else if app.Config.StrictCategories {
+		found, ok := app.findCategory(r.Context(), parsed.Category)
+		if !ok {
+			templates.TransactionError(fmt.Sprintf("Category %q does not exist", parsed.Category)).Render(r.Context(), w)
+			return
 		}
+		cat = found
 	} else {
-		catID = cat.ID
-		catName = cat.Name
-		catType = cat.Type
+		cat = app.resolveCategory(r.Context(), parsed.Category)
 	}
+	catID := cat.ID
+	catName := cat.Name
+	catType := cat.Type
 
 	// 3. User ID (Hardcoded for single user MVP/Monolith)
 	userID := int64(1)
 
 	// 4. Determine amount sign (expenses are negative, income is positive)
-	amount := parsed.Amount
+	amount := cents
 	if catType == "expense" {
 		amount = -amount
 	}
 
-	// 5. Insert
-	_, err = app.Q.CreateTransaction(r.Context(), db.CreateTransactionParams{
+	// 5. Large income is more likely a typo than a windfall, so it has to be
+	// confirmed before it inflates the income totals
+	if catType == "income" && app.Config.IncomeConfirmCents > 0 && cents > app.Config.IncomeConfirmCents && r.FormValue("confirm") != "true" {
+		templates.IncomeConfirmRequired(input, catName, app.formatCents(currency, cents)).Render(r.Context(), w)
+		return
+	}
+
+	// 6. Enforce the category budget (hard limits block unless overridden)
+	now := time.Now()
+	budgetWarning := ""
+	if catType == "expense" {
+		check, err := app.checkBudget(r.Context(), catID, cents, now)
+		if err != nil {
+			templates.TransactionError("Failed to check budget: "+err.Error()).Render(r.Context(), w)
+			return
+		}
+		if check.OverHard && r.FormValue("override") != "true" {
+			templates.BudgetHardLimitExceeded(input, catName, formatMoney(check.HardLimit)).Render(r.Context(), w)
+			return
+		}
+		if check.OverSoft {
+			budgetWarning = fmt.Sprintf("%s is over its monthly budget of %s", catName, formatMoney(check.Limit))
+			app.sendBudgetAlert(BudgetAlert{
+				Category:   catName,
+				Month:      now.UTC().Format("2006-01"),
+				SpentCents: check.Spent + cents,
+				LimitCents: check.Limit,
+			})
+		}
+	}
+
+	// 7. Insert, along with any #tags in the description. The category was
+	// inferred and the tags extracted from the full text above, so
+	// truncating only affects what gets stored.
+	tags := extractHashtags(parsed.Description)
+	parsed.Description = truncateDescription(parsed.Description, app.Config.MaxDescription)
+	dbTx, err := app.DB.BeginTx(r.Context(), nil)
+	if err != nil {
+		templates.TransactionError("Failed to save: "+err.Error()).Render(r.Context(), w)
+		return
+	}
+	defer dbTx.Rollback()
+	qtx := app.Q.WithTx(dbTx)
+
+	created, err := qtx.CreateTransaction(r.Context(), db.CreateTransactionParams{
 		UserID:      userID,
 		CategoryID:  catID,
 		Amount:      amount,
-		Currency:    "USD",
+		Currency:    currency,
 		Description: parsed.Description,
-		Date:        time.Now(),
+		Date:        now,
 	})
 	if err != nil {
 		templates.TransactionError("Failed to save: "+err.Error()).Render(r.Context(), w)
 		return
 	}
+	for _, tag := range tags {
+		if err := qtx.AddTransactionTag(r.Context(), db.AddTransactionTagParams{TransactionID: created.ID, Tag: tag}); err != nil {
+			templates.TransactionError("Failed to save tags: "+err.Error()).Render(r.Context(), w)
+			return
+		}
+	}
+	if err := dbTx.Commit(); err != nil {
+		templates.TransactionError("Failed to save: "+err.Error()).Render(r.Context(), w)
+		return
+	}
+	app.recordAudit(r.Context(), "create", created.ID, fmt.Sprintf("%d cents %q in %s", amount, parsed.Description, catName))
 
-	// 6. Render Success (display positive amount)
-	displayAmt := formatMoney(parsed.Amount)
+	// 8. Render Success (display the positive amount as stored)
+	displayAmt := app.formatCents(currency, cents)
 	templates.TransactionSuccess(displayAmt, parsed.Description, catName).Render(r.Context(), w)
+	if budgetWarning != "" {
+		templates.BudgetWarning(budgetWarning).Render(r.Context(), w)
+	}
+}
+
+// parsedCents returns the currency of a parsed entry, USD if none was given,
+// and its amount in cents. Amounts are parsed in the currency's own minor
+// units but stored, like every other amount, in cents, so an amount finer
+// than a cent is an error rather than silently rounded.
+func (app *Application) parsedCents(parsed ParsedTransaction) (string, int64, error) {
+	currency := "USD"
+	if parsed.Currency != "" {
+		currency = parsed.Currency
+	}
+	cents, ok := toCents(parsed.Amount, app.CurrencySymbols.Decimals(currency))
+	if !ok {
+		return "", 0, fmt.Errorf("%s amounts can only be saved to %d decimal places", currency, defaultCurrencyDecimals)
+	}
+	return currency, cents, nil
+}
+
+// formatCents writes a stored amount in cents in its currency's format.
+func (app *Application) formatCents(currency string, cents int64) string {
+	f := app.currencyFormat(currency)
+	return f.Format(fromCents(cents, f.Decimals))
+}
+
+// findCategory looks a category up by name, trying alternative names for
+// backwards compatibility. It reports false if none of them exist.
+func (app *Application) findCategory(ctx context.Context, name string) (db.Category, bool) {
+	cat, err := app.Q.GetCategoryByName(ctx, name)
+	if err == nil {
+		return cat, true
+	}
+
+	// Try alternative name for backwards compatibility
+	altName := name
+	if name == "Earned Income" {
+		altName = "Salary"
+	}
+	cat, err = app.Q.GetCategoryByName(ctx, altName)
+	if err == nil {
+		return cat, true
+	}
+	return db.Category{}, false
+}
+
+// resolveCategory looks a category up by name. If not found, it falls back to
+// the first category so that a transaction always has somewhere to land.
+func (app *Application) resolveCategory(ctx context.Context, name string) db.Category {
+	if cat, ok := app.findCategory(ctx, name); ok {
+		return cat
+	}
+
+	// Fallback to first category
+	cats, _ := app.Q.ListCategories(ctx)
+	if len(cats) > 0 {
+		return cats[0]
+	}
+	return db.Category{ID: 1, Name: "Unknown", Type: "expense"}
+}
+
+// lookupCategoryOverride resolves an explicit "@Category" name,
+// case-insensitively. When nothing matches it returns the closest existing
+// category name as a suggestion instead.
+func (app *Application) lookupCategoryOverride(ctx context.Context, name string) (*db.Category, string, error) {
+	cats, err := app.Q.ListCategories(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	names := make([]string, 0, len(cats))
+	for i := range cats {
+		if strings.EqualFold(cats[i].Name, name) {
+			return &cats[i], "", nil
+		}
+		names = append(names, cats[i].Name)
+	}
+	return nil, closestCategory(name, names), nil
+}
+
+// closestCategory returns the candidate with the smallest case-insensitive
+// Levenshtein distance to name, or "" if there are no candidates. Ties go to
+// the earlier candidate.
+func closestCategory(name string, candidates []string) string {
+	best := ""
+	bestDist := -1
+	target := strings.ToLower(name)
+	for _, c := range candidates {
+		d := levenshtein(target, strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between a and b, counting runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// ParsePreviewCategory is the resolved category shown in a parse preview
+type ParsePreviewCategory struct {
+	Name  string `json:"name"`
+	Icon  string `json:"icon"`
+	Color string `json:"color"`
+	Type  string `json:"type"`
+}
+
+// ParsePreviewResponse is the response for the parse preview endpoint
+type ParsePreviewResponse struct {
+	AmountCents int64                `json:"amount_cents"`
+	Description string               `json:"description"`
+	Category    ParsePreviewCategory `json:"category"`
+	Sign        string               `json:"sign"`
+}
+
+// ParsePreviewError is returned when the preview input cannot be parsed
+type ParsePreviewError struct {
+	Error string `json:"error"`
+}
+
+// HandleParsePreview parses the entry box input and resolves its category
+// without inserting anything, so the client can show a live preview.
+func (app *Application) HandleParsePreview(w http.ResponseWriter, r *http.Request) {
+	parsed, err := ParseTransaction(r.URL.Query().Get("input"), app.CatConfig, app.CurrencySymbols)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ParsePreviewError{Error: err.Error()})
+		return
+	}
+
+	cat := app.resolveCategory(r.Context(), parsed.Category)
+	sign := "-"
+	if cat.Type == "income" {
+		sign = "+"
+	}
+
+	_, cents, err := app.parsedCents(parsed)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(ParsePreviewError{Error: err.Error()})
+		return
+	}
+	resp := ParsePreviewResponse{
+		AmountCents: cents,
+		Description: parsed.Description,
+		Category: ParsePreviewCategory{
+			Name:  cat.Name,
+			Icon:  cat.Icon.String,
+			Color: cat.Color.String,
+			Type:  cat.Type,
+		},
+		Sign: sign,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// HandleCategorizeMatches lists every keyword a description matches, to
+// explain why it was categorized the way it was.
+func (app *Application) HandleCategorizeMatches(w http.ResponseWriter, r *http.Request) {
+	desc := r.URL.Query().Get("desc")
+	if desc == "" {
+		http.Error(w, "Missing desc", http.StatusBadRequest)
+		return
+	}
+
+	matches := []Match{}
+	if app.CatConfig != nil {
+		matches = app.CatConfig.AllMatches(desc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(matches)
+}
+
+// deletePolicy returns the configured delete policy, defaulting to soft.
+func (app *Application) deletePolicy() string {
+	if app.Config.DeletePolicy == deletePolicyHard {
+		return deletePolicyHard
+	}
+	return deletePolicySoft
+}
+
+// deleteTransaction removes a transaction according to the delete policy.
+// Soft deletes are recorded in the audit log under action; a hard delete
+// leaves nothing behind, including the receipt file.
+func (app *Application) deleteTransaction(ctx context.Context, id, userID int64, action, details string) error {
+	dbTx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer dbTx.Rollback()
+
+	receipt, err := app.removeTransaction(ctx, app.Q.WithTx(dbTx), id, userID)
+	if err != nil {
+		return err
+	}
+	if err := dbTx.Commit(); err != nil {
+		return err
+	}
+
+	app.removeReceipt(receipt)
+	if app.deletePolicy() == deletePolicySoft {
+		app.recordAudit(ctx, action, id, details)
+	}
+	return nil
+}
+
+// removeTransaction deletes a transaction through q, which may be bound to a
+// database transaction, according to the delete policy. A hard delete also
+// removes the transaction's tags, edit history and audit entries, and
+// returns its receipt file name for the caller to remove after committing.
+func (app *Application) removeTransaction(ctx context.Context, q *db.Queries, id, userID int64) (string, error) {
+	if app.deletePolicy() != deletePolicyHard {
+		return "", q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: id, UserID: userID})
+	}
+
+	tx, err := q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if err := q.DeleteTransactionTags(ctx, id); err != nil {
+		return "", err
+	}
+	if err := q.DeleteTransactionHistory(ctx, id); err != nil {
+		return "", err
+	}
+	if err := q.DeleteAuditEntriesForEntity(ctx, id); err != nil {
+		return "", err
+	}
+	if err := q.HardDeleteTransaction(ctx, db.HardDeleteTransactionParams{ID: id, UserID: userID}); err != nil {
+		return "", err
+	}
+	return tx.ReceiptPath.String, nil
 }
 
 func (app *Application) HandleTransactionDelete(w http.ResponseWriter, r *http.Request) {
@@ -313,12 +715,7 @@ func (app *Application) HandleTransactionDelete(w http.ResponseWriter, r *http.R
 	// User ID (hardcoded for single user MVP)
 	userID := int64(1)
 
-	// Soft delete transaction
-	err = app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{
-		ID:     id,
-		UserID: userID,
-	})
-	if err != nil {
+	if err := app.deleteTransaction(ctx, id, userID, "remove", ""); err != nil {
 		http.Error(w, "Failed to delete transaction: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -381,11 +778,7 @@ func (app *Application) HandleTransactionSoftDelete(w http.ResponseWriter, r *ht
 
 	userID := int64(1)
 
-	err = app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{
-		ID:     id,
-		UserID: userID,
-	})
-	if err != nil {
+	if err := app.deleteTransaction(ctx, id, userID, "remove", ""); err != nil {
 		http.Error(w, "Failed to remove transaction: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -393,42 +786,701 @@ func (app *Application) HandleTransactionSoftDelete(w http.ResponseWriter, r *ht
 	templates.TransactionRemoved().Render(ctx, w)
 }
 
-func formatMoney(cents int64) string {
-	return "$" + formatFloat(float64(cents)/100.0, 2)
-}
+// HandleTransactionTrash lists the most recently removed transactions so they
+// can be restored. Only soft deletes end up here; under the hard delete
+// policy the trash stays empty.
+func (app *Application) HandleTransactionTrash(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func formatFloat(f float64, prec int) string {
-	return strconv.FormatFloat(f, 'f', prec, 64)
+	txs, err := app.Q.ListRecentDeletedTransactions(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load removed transactions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templates.TrashList(txs).Render(ctx, w)
 }
 
-func (app *Application) HandleSettings(w http.ResponseWriter, r *http.Request) {
-	var mappings []templates.CategoryMapping
+// HandleUncategorizedTransactions lists transactions sitting in the fallback
+// category, typically left there by imports with unknown category names, so
+// they can be recategorized.
+func (app *Application) HandleUncategorizedTransactions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	defaultName := ""
 	if app.CatConfig != nil {
-		for _, cat := range app.CatConfig.Categories {
-			mappings = append(mappings, templates.CategoryMapping{
-				Name:     cat.Name,
-				Keywords: cat.Keywords,
-			})
-		}
+		defaultName = app.CatConfig.DefaultCategory
 	}
+	fallback := app.resolveCategory(ctx, defaultName)
 
-	lastBackup := getLastBackupTime()
-	lastBackupStr := ""
-	if !lastBackup.IsZero() {
-		lastBackupStr = lastBackup.UTC().Format(time.RFC3339)
+	rows, err := app.Q.ListTransactionsInCategory(ctx, fallback.Name)
+	if err != nil {
+		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
+		return
 	}
-	backup := templates.BackupStatus{
-		Enabled:      app.Config.BackupPath != "",
-		BackupPath:   app.Config.BackupPath,
-		LastBackupAt: lastBackupStr,
+
+	txs := make([]db.ListTransactionsByYearPaginatedRow, 0, len(rows))
+	for _, row := range rows {
+		txs = append(txs, db.ListTransactionsByYearPaginatedRow(row))
 	}
 
-	templates.Settings(mappings, backup).Render(r.Context(), w)
+	templates.UncategorizedList(txs, fallback.Name).Render(ctx, w)
 }
 
-func (app *Application) HandleExportCSV(w http.ResponseWriter, r *http.Request) {
+func (app *Application) HandleTransactionRestore(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	err = app.Q.RestoreTransaction(ctx, db.RestoreTransactionParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		http.Error(w, "Failed to restore transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	app.recordAudit(ctx, "restore", id, "")
+
+	templates.TransactionRestored().Render(ctx, w)
+}
+
+// HandleTransactionUndo removes the most recently created transaction,
+// giving a one-click undo right after a mistaken entry.
+func (app *Application) HandleTransactionUndo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userID := int64(1)
+
+	tx, err := app.Q.GetLatestCreatedTransaction(ctx, userID)
+	if err == sql.ErrNoRows {
+		templates.NothingToUndo().Render(ctx, w)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	err = app.deleteTransaction(ctx, tx.ID, userID, "undo", fmt.Sprintf("%d cents %q in %s", tx.Amount, tx.Description, tx.CategoryName))
+	if err != nil {
+		http.Error(w, "Failed to undo transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	amount := tx.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	templates.TransactionUndone(formatMoney(amount), tx.Description).Render(ctx, w)
+}
+
+// HandleTransactionRecategorize moves a single transaction to another category,
+// flipping the stored amount sign when the category type changes.
+func (app *Application) HandleTransactionRecategorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	// Accept a regular form field, or the answer to an hx-prompt
+	name := strings.TrimSpace(r.FormValue("category"))
+	if name == "" {
+		name = strings.TrimSpace(r.Header.Get("HX-Prompt"))
+	}
+	if name == "" {
+		http.Error(w, "Category is required", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	tx, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows || (err == nil && tx.DeletedAt.Valid) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cat := app.resolveCategory(ctx, name)
+
+	// Expenses are stored negative, income positive
+	amount := tx.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	if cat.Type == "expense" {
+		amount = -amount
+	}
+
+	err = app.Q.UpdateTransactionCategory(ctx, db.UpdateTransactionCategoryParams{
+		CategoryID: cat.ID,
+		Amount:     amount,
+		ID:         id,
+		UserID:     userID,
+	})
+	if err != nil {
+		http.Error(w, "Failed to update transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	app.recordAudit(ctx, "recategorize", id, tx.CategoryName+" -> "+cat.Name)
+	app.recordTransactionChange(ctx, id, "category_id", tx.CategoryID, cat.ID)
+	app.recordTransactionChange(ctx, id, "amount", tx.Amount, amount)
+
+	updated, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	templates.TransactionItem(transactionItemRow(updated)).Render(ctx, w)
+}
+
+// CategoryResetResponse reports how many transactions a category reset moved
+type CategoryResetResponse struct {
+	Moved int64  `json:"moved"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// HandleCategoryReset moves every live transaction in a category to the
+// default (fallback) category, clearing a miscategorized batch before
+// inference is re-run.
+func (app *Application) HandleCategoryReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	cats, err := app.Q.ListCategories(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load categories: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var source *db.Category
+	for i := range cats {
+		if cats[i].ID == id {
+			source = &cats[i]
+			break
+		}
+	}
+	if source == nil {
+		http.Error(w, "Category not found", http.StatusNotFound)
+		return
+	}
+
+	defaultName := ""
+	if app.CatConfig != nil {
+		defaultName = app.CatConfig.DefaultCategory
+	}
+	fallback := app.resolveCategory(ctx, defaultName)
+	if fallback.ID == source.ID {
+		http.Error(w, "Cannot reset the fallback category into itself", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	moved, err := app.Q.ReassignCategoryTransactions(ctx, db.ReassignCategoryTransactionsParams{
+		ToCategoryID:   fallback.ID,
+		ToType:         fallback.Type,
+		FromCategoryID: source.ID,
+		UserID:         userID,
+	})
+	if err != nil {
+		http.Error(w, "Failed to reset category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	app.recordAudit(ctx, "reset_category", source.ID, fmt.Sprintf("%d transactions %s -> %s", moved, source.Name, fallback.Name))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(CategoryResetResponse{Moved: moved, From: source.Name, To: fallback.Name})
+}
+
+// categoryPalette is cycled through to color categories created without
+// one, so neighbouring tiles on the mosaic stay distinguishable.
+var categoryPalette = []string{
+	"#E74C3C", "#3498DB", "#2ECC71", "#F39C12", "#9B59B6", "#1ABC9C",
+	"#E67E22", "#34495E", "#E91E63", "#00BCD4", "#8BC34A", "#795548",
+}
+
+// nextCategoryColor returns the palette color for a new category given how
+// many categories already exist.
+func nextCategoryColor(existingCount int) string {
+	return categoryPalette[existingCount%len(categoryPalette)]
+}
+
+// EnsureCategoryRequest describes a category that should exist
+type EnsureCategoryRequest struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Icon  string `json:"icon"`
+	Color string `json:"color"`
+}
+
+// HandleCategoryEnsure creates a category unless one with the same name
+// already exists, and returns whichever is stored. An existing category is
+// left untouched, so seeding scripts can call it repeatedly. Without a color
+// the category gets the next one from categoryPalette.
+func (app *Application) HandleCategoryEnsure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req EnsureCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		http.Error(w, "Category name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Type != "income" && req.Type != "expense" && req.Type != "transfer" {
+		http.Error(w, "Category type must be income, expense or transfer", http.StatusBadRequest)
+		return
+	}
+	if req.Icon == "" {
+		req.Icon = "📌"
+	}
+	if req.Color == "" {
+		cats, err := app.Q.ListCategories(ctx)
+		if err != nil {
+			http.Error(w, "Failed to load categories: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		req.Color = nextCategoryColor(len(cats))
+	}
+
+	res, err := app.DB.ExecContext(ctx,
+		`INSERT INTO categories (name, type, icon, color) SELECT ?, ?, ?, ? WHERE NOT EXISTS (SELECT 1 FROM categories WHERE name = ?)`,
+		req.Name, req.Type, req.Icon, req.Color, req.Name,
+	)
+	if err != nil {
+		http.Error(w, "Failed to ensure category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	created, _ := res.RowsAffected()
+
+	cat, err := app.Q.GetCategoryByName(ctx, req.Name)
+	if err != nil {
+		http.Error(w, "Failed to load category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if created > 0 {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(StorageCategory{
+		ID:    cat.ID,
+		Name:  cat.Name,
+		Type:  cat.Type,
+		Icon:  cat.Icon.String,
+		Color: cat.Color.String,
+	})
+}
+
+// HandleTransactionUpdateAmount corrects the amount of a single transaction.
+// The amount is entered as a positive value; the stored sign follows the
+// transaction's category type.
+func (app *Application) HandleTransactionUpdateAmount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	// Accept a regular form field, or the answer to an hx-prompt
+	raw := strings.TrimSpace(r.FormValue("amount"))
+	if raw == "" {
+		raw = strings.TrimSpace(r.Header.Get("HX-Prompt"))
+	}
+	cents, err := parseAmount(strings.TrimPrefix(raw, "$"))
+	if err != nil || cents < 0 {
+		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	tx, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows || (err == nil && tx.DeletedAt.Valid) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	amount := cents
+	if tx.CategoryType == "expense" {
+		amount = -amount
+	}
+
+	if amount != tx.Amount {
+		err = app.Q.UpdateTransactionAmount(ctx, db.UpdateTransactionAmountParams{
+			Amount: amount,
+			ID:     id,
+			UserID: userID,
+		})
+		if err != nil {
+			http.Error(w, "Failed to update transaction: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		app.recordTransactionChange(ctx, id, "amount", tx.Amount, amount)
+		tx.Amount = amount
+	}
+
+	templates.TransactionItem(transactionItemRow(tx)).Render(ctx, w)
+}
+
+// supportedCurrencies is the built-in allowlist of ISO 4217 codes a
+// transaction may use; -currency-symbols entries extend it.
+var supportedCurrencies = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "CHF": true,
+	"CAD": true, "AUD": true, "NZD": true, "CNY": true, "HKD": true,
+	"SGD": true, "SEK": true, "NOK": true, "DKK": true, "PLN": true,
+	"CZK": true, "HUF": true, "MXN": true, "BRL": true, "ARS": true,
+	"CLP": true, "COP": true, "INR": true, "KRW": true, "ZAR": true,
+	"TRY": true, "ILS": true, "AED": true, "KWD": true, "BHD": true,
+	"OMR": true,
+}
+
+// HandleTransactionUpdateCurrency changes the currency of an existing
+// transaction and returns the re-rendered row.
+func (app *Application) HandleTransactionUpdateCurrency(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(r.FormValue("currency")))
+	if !app.CurrencySymbols.Known(currency) {
+		http.Error(w, "Unsupported currency", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	tx, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows || (err == nil && tx.DeletedAt.Valid) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if currency != tx.Currency {
+		err = app.Q.UpdateTransactionCurrency(ctx, db.UpdateTransactionCurrencyParams{
+			Currency: currency,
+			ID:       id,
+			UserID:   userID,
+		})
+		if err != nil {
+			http.Error(w, "Failed to update transaction: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		tx.Currency = currency
+	}
+
+	templates.TransactionItem(transactionItemRow(tx)).Render(ctx, w)
+}
+
+// SplitRequest is the request body for splitting a transaction. Amounts are
+// positive cents; each split's sign follows its category type.
+type SplitRequest struct {
+	Splits []SplitPart `json:"splits"`
+}
+
+// SplitPart is one category share of a split transaction
+type SplitPart struct {
+	Category string `json:"category"`
+	Amount   int64  `json:"amount"`
+}
+
+// HandleTransactionSplit replaces a transaction with one transaction per
+// split. The splits must add up to the original amount; the original is
+// removed under the delete policy and the new rows keep its date and
+// description.
+func (app *Application) HandleTransactionSplit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	var req SplitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Splits) < 2 {
+		http.Error(w, "A split needs at least two parts", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	orig, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows || (err == nil && orig.DeletedAt.Valid) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	origAmount := orig.Amount
+	if origAmount < 0 {
+		origAmount = -origAmount
+	}
+	var sum int64
+	cats := make([]db.Category, len(req.Splits))
+	for i, part := range req.Splits {
+		if part.Amount <= 0 || strings.TrimSpace(part.Category) == "" {
+			http.Error(w, "Each split needs a category and a positive amount", http.StatusBadRequest)
+			return
+		}
+		sum += part.Amount
+		cats[i] = app.resolveCategory(ctx, strings.TrimSpace(part.Category))
+	}
+	if sum != origAmount {
+		http.Error(w, fmt.Sprintf("Split amounts total %s but the transaction is %s", formatMoney(sum), formatMoney(origAmount)), http.StatusBadRequest)
+		return
+	}
+
+	dbTx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "Failed to start split: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer dbTx.Rollback()
+	qtx := app.Q.WithTx(dbTx)
+
+	receipt, err := app.removeTransaction(ctx, qtx, id, userID)
+	if err != nil {
+		http.Error(w, "Failed to remove original transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	created := make([]StorageTransaction, 0, len(req.Splits))
+	for i, part := range req.Splits {
+		cat := cats[i]
+		amount := part.Amount
+		if cat.Type == "expense" {
+			amount = -amount
+		}
+
+		tx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      userID,
+			CategoryID:  cat.ID,
+			Amount:      amount,
+			Currency:    orig.Currency,
+			Description: fmt.Sprintf("%s (split %d/%d)", orig.Description, i+1, len(req.Splits)),
+			Date:        orig.Date,
+		})
+		if err != nil {
+			http.Error(w, "Failed to create split: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		createdAt := ""
+		if tx.CreatedAt.Valid {
+			createdAt = tx.CreatedAt.Time.UTC().Format(time.RFC3339)
+		}
+		created = append(created, StorageTransaction{
+			ID:           tx.ID,
+			Amount:       tx.Amount,
+			Currency:     tx.Currency,
+			Description:  tx.Description,
+			Date:         tx.Date.UTC().Format(time.RFC3339),
+			CategoryName: cat.Name,
+			CategoryType: cat.Type,
+			CreatedAt:    createdAt,
+		})
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		http.Error(w, "Failed to save split: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	app.removeReceipt(receipt)
+	if app.deletePolicy() == deletePolicySoft {
+		app.recordAudit(ctx, "split", id, fmt.Sprintf("into %d transactions", len(created)))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(created)
+}
+
+// BulkTagRequest is the body of a bulk tag request
+type BulkTagRequest struct {
+	IDs []int64 `json:"ids"`
+	Tag string  `json:"tag"`
+}
+
+// BulkTagResponse reports how many transactions gained the tag
+type BulkTagResponse struct {
+	Tagged int64 `json:"tagged"`
+}
+
+// HandleBulkTag adds one tag to many transactions. IDs that don't exist, are
+// removed or already carry the tag are skipped rather than failing the batch.
+func (app *Application) HandleBulkTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req BulkTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	tag := strings.TrimPrefix(strings.TrimSpace(req.Tag), "#")
+	if tag == "" {
+		http.Error(w, "Tag is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.IDs) == 0 {
+		http.Error(w, "At least one transaction ID is required", http.StatusBadRequest)
+		return
+	}
+
+	userID := int64(1)
+
+	dbTx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "Failed to start tagging: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer dbTx.Rollback()
+	qtx := app.Q.WithTx(dbTx)
+
+	var tagged []int64
+	for _, id := range req.IDs {
+		n, err := qtx.TagTransaction(ctx, db.TagTransactionParams{Tag: tag, ID: id, UserID: userID})
+		if err != nil {
+			http.Error(w, "Failed to tag transaction: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if n > 0 {
+			tagged = append(tagged, id)
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		http.Error(w, "Failed to save tags: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, id := range tagged {
+		app.recordAudit(ctx, "tag", id, "#"+tag)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(BulkTagResponse{Tagged: int64(len(tagged))})
+}
+
+// transactionItemRow adapts a single loaded transaction to the row type the
+// transaction list template renders.
+func transactionItemRow(tx db.GetTransactionByIDRow) db.ListTransactionsByYearPaginatedRow {
+	return db.ListTransactionsByYearPaginatedRow{
+		ID: tx.ID, UserID: tx.UserID, CategoryID: tx.CategoryID,
+		Amount: tx.Amount, Currency: tx.Currency, Description: tx.Description,
+		Date: tx.Date, CreatedAt: tx.CreatedAt, DeletedAt: tx.DeletedAt, ReceiptPath: tx.ReceiptPath,
+		CategoryName: tx.CategoryName, CategoryIcon: tx.CategoryIcon,
+		CategoryType: tx.CategoryType, UserName: tx.UserName,
+	}
+}
+
+func formatMoney(cents int64) string {
+	return "$" + formatFloat(float64(cents)/100.0, 2)
+}
+
+func formatFloat(f float64, prec int) string {
+	return strconv.FormatFloat(f, 'f', prec, 64)
+}
+
+func (app *Application) HandleSettings(w http.ResponseWriter, r *http.Request) {
+	var mappings []templates.CategoryMapping
+	if app.CatConfig != nil {
+		for _, cat := range app.CatConfig.Categories {
+			mappings = append(mappings, templates.CategoryMapping{
+				Name:     cat.Name,
+				Keywords: cat.Keywords,
+			})
+		}
+	}
+
+	lastBackup := getLastBackupTime()
+	lastBackupStr := ""
+	if !lastBackup.IsZero() {
+		lastBackupStr = lastBackup.UTC().Format(time.RFC3339)
+	}
+	backup := templates.BackupStatus{
+		Enabled:      app.Config.BackupPath != "",
+		BackupPath:   app.Config.BackupPath,
+		LastBackupAt: lastBackupStr,
+	}
+
+	templates.Settings(mappings, backup, app.wipePhrase()).Render(r.Context(), w)
+}
+
+// csvDateLayouts maps the ?date-format= names accepted by HandleExportCSV to
+// Go layouts.
+var csvDateLayouts = map[string]string{
+	"iso": "2006-01-02",
+	"us":  "01/02/2006",
+	"eu":  "02/01/2006",
+}
+
+// HandleExportCSV exports every transaction. Amounts are unsigned and the
+// Type column tells income from expense; ?signed=true emits expenses as
+// negative amounts so the column can be summed directly. Dates are ISO
+// unless ?date-format= asks for us (MM/DD/YYYY) or eu (DD/MM/YYYY).
+func (app *Application) HandleExportCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	signed := r.URL.Query().Get("signed") == "true"
+
+	dateFormat := r.URL.Query().Get("date-format")
+	if dateFormat == "" {
+		dateFormat = "iso"
+	}
+	dateLayout, ok := csvDateLayouts[dateFormat]
+	if !ok {
+		http.Error(w, "Invalid date-format: must be iso, us or eu", http.StatusBadRequest)
+		return
+	}
+
 	txs, err := app.Q.ListAllTransactionsForExport(ctx)
 	if err != nil {
 		http.Error(w, "Failed to load transactions: "+err.Error(), http.StatusInternalServerError)
@@ -442,33 +1494,175 @@ func (app *Application) HandleExportCSV(w http.ResponseWriter, r *http.Request)
 	defer writer.Flush()
 
 	// Header row
-	writer.Write([]string{"ID", "Date", "Description", "Category", "Type", "Amount", "Currency"})
+	writer.Write([]string{"ID", "Date", "Description", "Category", "Type", "Amount", "Currency", "Receipt"})
 
 	for _, t := range txs {
 		amount := float64(t.Amount) / 100.0
 		if amount < 0 {
 			amount = -amount
 		}
+		if signed && t.CategoryType == "expense" {
+			amount = -amount
+		}
 		writer.Write([]string{
 			strconv.FormatInt(t.ID, 10),
-			t.Date.Format("2006-01-02"),
+			t.Date.Format(dateLayout),
 			t.Description,
 			t.CategoryName,
 			t.CategoryType,
 			strconv.FormatFloat(amount, 'f', 2, 64),
 			t.Currency,
+			t.ReceiptPath.String,
+		})
+	}
+}
+
+// HandleExportCategorySummaryCSV exports one row per category with the
+// year's total and transaction count. Totals are positive magnitudes.
+// Unused categories are included unless ?nonzero=true leaves them out.
+func (app *Application) HandleExportCategorySummaryCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	totals, err := app.Q.GetCategoryTotalsByYear(ctx, yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load category totals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=cheapskate-category-summary-%s.csv", yearParam))
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"Category", "Type", "Total", "TransactionCount"})
+
+	nonzero := r.URL.Query().Get("nonzero") == "true"
+	for _, c := range totals {
+		if nonzero && c.TotalAmount == 0 && c.TransactionCount == 0 {
+			continue
+		}
+		writer.Write([]string{
+			c.CategoryName,
+			c.CategoryType,
+			strconv.FormatFloat(float64(c.TotalAmount)/100.0, 'f', 2, 64),
+			strconv.FormatInt(c.TransactionCount, 10),
+		})
+	}
+}
+
+// HandleExportMonthlyCSV exports income, expense and net per month of a year,
+// with a row for every month even when it has no transactions.
+func (app *Application) HandleExportMonthlyCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	yearParam, ok := exportYearParam(r)
+	if !ok {
+		http.Error(w, "Invalid year", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.GetMonthlyTotalsByYear(ctx, yearParam)
+	if err != nil {
+		http.Error(w, "Failed to load monthly totals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var income, expense [12]int64
+	for _, row := range rows {
+		if row.Month < 1 || row.Month > 12 {
+			continue
+		}
+		if row.CategoryType == "income" {
+			income[row.Month-1] += row.TotalAmount
+		} else {
+			expense[row.Month-1] += row.TotalAmount
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=cheapskate-monthly-%s.csv", yearParam))
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	writer.Write([]string{"Month", "Income", "Expense", "Net"})
+
+	for m := 0; m < 12; m++ {
+		writer.Write([]string{
+			fmt.Sprintf("%s-%02d", yearParam, m+1),
+			formatFloat(float64(income[m])/100.0, 2),
+			formatFloat(float64(expense[m])/100.0, 2),
+			formatFloat(float64(income[m]-expense[m])/100.0, 2),
 		})
 	}
 }
 
+// yearMonthParam reads ?year= and ?month=, each defaulting to its value at
+// now.
+func yearMonthParam(r *http.Request, now time.Time) (year, month int, err error) {
+	year, month = now.Year(), int(now.Month())
+	if v := r.URL.Query().Get("year"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 9999 {
+			return 0, 0, errors.New("Invalid year")
+		}
+		year = n
+	}
+	if v := r.URL.Query().Get("month"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 12 {
+			return 0, 0, errors.New("Invalid month")
+		}
+		month = n
+	}
+	return year, month, nil
+}
+
+// exportYearParam reads the four-digit ?year= of an export, defaulting to
+// the current year.
+func exportYearParam(r *http.Request) (string, bool) {
+	yearParam := r.URL.Query().Get("year")
+	if yearParam == "" {
+		return strconv.Itoa(time.Now().Year()), true
+	}
+	if _, err := strconv.Atoi(yearParam); err != nil || len(yearParam) != 4 {
+		return "", false
+	}
+	return yearParam, true
+}
+
+// wipePhrase returns the phrase that must be typed to wipe all data, falling
+// back to defaultWipePhrase when unset.
+func (app *Application) wipePhrase() string {
+	if app.Config.WipePhrase == "" {
+		return defaultWipePhrase
+	}
+	return app.Config.WipePhrase
+}
+
+// HandleWipeData deletes every transaction, but only when the confirm field
+// matches the configured wipe phrase exactly.
 func (app *Application) HandleWipeData(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	err := app.Q.DeleteAllTransactions(ctx)
+	phrase := app.wipePhrase()
+	if r.FormValue("confirm") != phrase {
+		templates.WipeConfirmRequired(phrase).Render(ctx, w)
+		return
+	}
+
+	count, err := app.Q.DeleteAllTransactions(ctx)
 	if err != nil {
 		templates.WipeError(err.Error()).Render(ctx, w)
 		return
 	}
 
-	templates.WipeSuccess().Render(ctx, w)
+	templates.WipeSuccess(count).Render(ctx, w)
 }
@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -39,7 +44,7 @@ func setupTestApp(t *testing.T) *Application {
 		CREATE TABLE categories (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT NOT NULL,
-			type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+			type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer')),
 			icon TEXT,
 			color TEXT
 		);
@@ -54,10 +59,48 @@ func setupTestApp(t *testing.T) *Application {
 			date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
 			deleted_at DATETIME DEFAULT NULL,
+			receipt_path TEXT DEFAULT NULL,
+			uid TEXT DEFAULT NULL,
 			FOREIGN KEY (user_id) REFERENCES users(id),
 			FOREIGN KEY (category_id) REFERENCES categories(id)
 		);
 
+		CREATE TABLE budgets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			category_id INTEGER NOT NULL UNIQUE,
+			limit_cents INTEGER NOT NULL,
+			hard_limit_cents INTEGER DEFAULT NULL,
+			FOREIGN KEY (category_id) REFERENCES categories(id)
+		);
+
+		CREATE TABLE audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			action TEXT NOT NULL,
+			entity_id INTEGER NOT NULL,
+			details TEXT NOT NULL DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE transaction_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			transaction_id INTEGER NOT NULL,
+			field TEXT NOT NULL,
+			old_value TEXT NOT NULL,
+			new_value TEXT NOT NULL,
+			changed_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE transaction_tags (
+			transaction_id INTEGER NOT NULL,
+			tag TEXT NOT NULL,
+			PRIMARY KEY (transaction_id, tag)
+		);
+
+		CREATE TABLE settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		);
+
 		INSERT INTO categories (name, type, icon, color) VALUES
 		('Food', 'expense', '🍔', '#FF5733'),
 		('Transport', 'expense', '🚕', '#33C1FF'),
@@ -246,6 +289,87 @@ func TestHandleTransactionCreate(t *testing.T) {
 	}
 }
 
+func TestHandleTransactionCreate_StrictCategories(t *testing.T) {
+	tests := []struct {
+		name         string
+		strict       bool
+		wantContains string
+		wantCreated  bool
+	}{
+		{name: "lenient falls back", strict: false, wantContains: "$40.00", wantCreated: true},
+		{name: "strict rejects", strict: true, wantContains: `Category &#34;Pets&#34; does not exist`, wantCreated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.StrictCategories = tt.strict
+			// Pets is mapped in the config but missing from the database
+			app.CatConfig.Categories = append(app.CatConfig.Categories, CategoryEntry{Name: "Pets", Keywords: []string{"vet"}})
+
+			form := url.Values{}
+			form.Add("input", "40 vet visit")
+			req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+
+			app.HandleTransactionCreate(rec, req)
+
+			if body := rec.Body.String(); !strings.Contains(body, tt.wantContains) {
+				t.Errorf("HandleTransactionCreate() body should contain %q, got: %s", tt.wantContains, body)
+			}
+			txs, err := app.Q.ListRecentTransactions(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to list transactions: %v", err)
+			}
+			if created := len(txs) > 0; created != tt.wantCreated {
+				t.Errorf("transaction created = %v, want %v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestHandleTransactionCreate_IncomeConfirm(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		confirm      string
+		wantContains string
+		wantCreated  bool
+	}{
+		{name: "large income asks first", input: "5000 salary", wantContains: "Yes, record it", wantCreated: false},
+		{name: "confirmed resubmit records", input: "5000 salary", confirm: "true", wantContains: "$5000.00", wantCreated: true},
+		{name: "income under the threshold records", input: "800 salary", wantContains: "$800.00", wantCreated: true},
+		{name: "large expense is not affected", input: "5000 rent", wantContains: "$5000.00", wantCreated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.IncomeConfirmCents = 100000
+
+			form := url.Values{"input": {tt.input}}
+			if tt.confirm != "" {
+				form.Set("confirm", tt.confirm)
+			}
+			rec := postTransaction(app, form)
+
+			if body := rec.Body.String(); !strings.Contains(body, tt.wantContains) {
+				t.Errorf("HandleTransactionCreate() body should contain %q, got: %s", tt.wantContains, body)
+			}
+			txs, err := app.Q.ListRecentTransactions(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to list transactions: %v", err)
+			}
+			if created := len(txs) > 0; created != tt.wantCreated {
+				t.Errorf("transaction created = %v, want %v", created, tt.wantCreated)
+			}
+		})
+	}
+}
+
 func TestHandleTransactionCreate_CategoryResolution(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -305,6 +429,181 @@ func TestHandleTransactionCreate_CategoryResolution(t *testing.T) {
 	}
 }
 
+func TestHandleTransactionCreate_CategoryOverride(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	post := func(input string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Add("input", input)
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		app.HandleTransactionCreate(rec, req)
+		return rec
+	}
+
+	t.Run("near miss suggests closest category", func(t *testing.T) {
+		rec := post("25 @Fod dinner")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionCreate() status = %d", rec.Code)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Did you mean Food?") {
+			t.Errorf("Expected suggestion for Food, got %s", body)
+		}
+		if !strings.Contains(body, "@Food dinner") {
+			t.Errorf("Expected confirm button to resubmit with @Food, got %s", body)
+		}
+
+		txs, err := app.Q.ListRecentTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to list transactions: %v", err)
+		}
+		if len(txs) != 0 {
+			t.Errorf("Expected no transaction to be saved, got %d", len(txs))
+		}
+	})
+
+	t.Run("suggestion is inserted literally", func(t *testing.T) {
+		if _, err := app.DB.Exec("INSERT INTO categories (name, type) VALUES ('Fun $1', 'expense')"); err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+		defer app.DB.Exec("DELETE FROM categories WHERE name = 'Fun $1'")
+
+		rec := post("25 @Fun$1x movie")
+		body := rec.Body.String()
+		if !strings.Contains(body, "@Fun_$1 movie") {
+			t.Errorf("Expected confirm button to resubmit with @Fun_$1, got %s", body)
+		}
+	})
+
+	t.Run("exact override is case-insensitive", func(t *testing.T) {
+		rec := post("25 @transport dinner")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionCreate() status = %d", rec.Code)
+		}
+		txs, err := app.Q.ListRecentTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to list transactions: %v", err)
+		}
+		if len(txs) != 1 {
+			t.Fatalf("Expected 1 transaction, got %d", len(txs))
+		}
+		if txs[0].CategoryName != "Transport" || txs[0].Description != "dinner" {
+			t.Errorf("Transaction = %q in %q, want \"dinner\" in Transport", txs[0].Description, txs[0].CategoryName)
+		}
+	})
+}
+
+func TestClosestCategory(t *testing.T) {
+	candidates := []string{"Food", "Transport", "Housing", "Earned Income"}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Fod", "Food"},
+		{"food", "Food"},
+		{"Trasnport", "Transport"},
+		{"housng", "Housing"},
+		{"Earned Incom", "Earned Income"},
+	}
+
+	for _, tt := range tests {
+		if got := closestCategory(tt.name, candidates); got != tt.want {
+			t.Errorf("closestCategory(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := closestCategory("Food", nil); got != "" {
+		t.Errorf("closestCategory with no candidates = %q, want empty", got)
+	}
+}
+
+func TestHandleTransactionCreate_MaxDescription(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.MaxDescription = 20
+
+	// "taxi" only appears past the cut, so inference must use the full text
+	form := url.Values{}
+	form.Add("input", "35 card purchase ref 0042 merchant city taxi")
+	req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	app.HandleTransactionCreate(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTransactionCreate() status = %d", rec.Code)
+	}
+
+	txs, err := app.Q.ListRecentTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list transactions: %v", err)
+	}
+	if len(txs) != 1 {
+		t.Fatalf("Expected 1 transaction, got %d", len(txs))
+	}
+	if txs[0].Description != "card purchase ref 0…" {
+		t.Errorf("Description = %q, want %q", txs[0].Description, "card purchase ref 0…")
+	}
+	if txs[0].CategoryName != "Transport" {
+		t.Errorf("Category = %q, want Transport", txs[0].CategoryName)
+	}
+}
+
+func TestHandleTransactionCreate_CurrencyPrecision(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	post := func(input string) string {
+		form := url.Values{}
+		form.Add("input", input)
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		app.HandleTransactionCreate(rec, req)
+		return rec.Body.String()
+	}
+
+	if body := post("1.250 USD taxi"); !strings.Contains(body, "Could not understand") {
+		t.Errorf("Expected USD amount with 3 decimals to be rejected, got %s", body)
+	}
+
+	if body := post("1.250 KWD taxi"); !strings.Contains(body, "1.250 KWD") {
+		t.Errorf("Confirmation should show the amount in KWD, got %s", body)
+	}
+	txs, err := app.Q.ListRecentTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list transactions: %v", err)
+	}
+	if len(txs) != 1 {
+		t.Fatalf("Expected 1 transaction, got %d", len(txs))
+	}
+	// Stored in cents like every other amount, so totals and display agree
+	if txs[0].Currency != "KWD" || txs[0].Amount != -125 {
+		t.Errorf("Transaction = %d %s, want -125 KWD", txs[0].Amount, txs[0].Currency)
+	}
+
+	// A third decimal that a cent cannot hold is refused, not rounded
+	if body := post("1.255 KWD taxi"); !strings.Contains(body, "only be saved to 2 decimal places") {
+		t.Errorf("Expected 1.255 KWD to be rejected, got %s", body)
+	}
+
+	if body := post("500 JPY ramen"); !strings.Contains(body, "500") {
+		t.Errorf("Confirmation should show the amount in JPY, got %s", body)
+	}
+	txs, err = app.Q.ListRecentTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list transactions: %v", err)
+	}
+	if len(txs) != 2 || txs[0].Currency != "JPY" || txs[0].Amount != -50000 {
+		t.Errorf("Transactions = %+v, want the JPY entry stored as -50000 cents", txs)
+	}
+}
+
 func TestHandleDashboardDetailed(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -464,6 +763,37 @@ func TestHandleDashboard_NoDuplicateCategories(t *testing.T) {
 	}
 }
 
+func TestHandleDashboard_ExpensesView(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -2500, "pizza", time.Now())
+
+	render := func(query string) string {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleDashboard(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleDashboard() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		return rec.Body.String()
+	}
+
+	if body := render(""); !strings.Contains(body, "Earned Income") {
+		t.Error("Default mosaic should include income categories")
+	}
+
+	body := render("?view=expenses")
+	if strings.Contains(body, "Earned Income") {
+		t.Error("Expenses view should leave income categories out of the mosaic")
+	}
+	for _, want := range []string{"Food", "Transport", "Housing", "pizza"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expenses view missing %q", want)
+		}
+	}
+}
+
 func TestHandleDashboard_IncomeAndExpense(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -696,6 +1026,94 @@ func TestHandleTransactionsPage_Pagination(t *testing.T) {
 	})
 }
 
+func TestHandleTransactionsPage_Sort(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	now := time.Now()
+	createTestTransaction(t, app, 1, -500, "Oldest small", now.Add(-2*time.Hour))
+	createTestTransaction(t, app, 1, -9000, "Middle large", now.Add(-time.Hour))
+	createTestTransaction(t, app, 4, 3000, "Newest income", now)
+
+	list := func(sort string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/transactions?sort="+sort, nil)
+		rec := httptest.NewRecorder()
+		app.HandleTransactionsPage(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionsPage(sort=%q) status = %d, want %d", sort, rec.Code, http.StatusOK)
+		}
+		return rec.Body.String()
+	}
+	assertOrder := func(body string, want ...string) {
+		t.Helper()
+		last := -1
+		for _, desc := range want {
+			i := strings.Index(body, desc)
+			if i == -1 {
+				t.Fatalf("%q missing from list", desc)
+			}
+			if i < last {
+				t.Errorf("%q appears out of order, want %v", desc, want)
+			}
+			last = i
+		}
+	}
+
+	assertOrder(list(""), "Newest income", "Middle large", "Oldest small")
+	assertOrder(list("date_asc"), "Oldest small", "Middle large", "Newest income")
+	assertOrder(list("amount_desc"), "Middle large", "Newest income", "Oldest small")
+	assertOrder(list("amount_asc"), "Oldest small", "Newest income", "Middle large")
+
+	// The dashboard's first page honours the same orders
+	req := httptest.NewRequest(http.MethodGet, "/dashboard?sort=amount_desc", nil)
+	rec := httptest.NewRecorder()
+	app.HandleDashboard(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleDashboard(sort=amount_desc) status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	assertOrder(rec.Body.String(), "Middle large", "Newest income", "Oldest small")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/transactions?sort=category", nil)
+	rec = httptest.NewRecorder()
+	app.HandleTransactionsPage(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleTransactionsPage(sort=category) status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestTransferRowsRenderAsTransfers(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	res, err := app.DB.Exec(`INSERT INTO categories (name, type, icon, color) VALUES ('Savings', 'transfer', '🏦', '#3498DB')`)
+	if err != nil {
+		t.Fatalf("Failed to create transfer category: %v", err)
+	}
+	catID, _ := res.LastInsertId()
+	createTestTransaction(t, app, catID, 5000, "Move to savings", time.Now())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTransactionsPage(rec, req)
+	body := rec.Body.String()
+	if !strings.Contains(body, "Move to savings") || !strings.Contains(body, "text-blue-600") {
+		t.Errorf("Transfer row should render in the transfer color, got %s", body)
+	}
+	if strings.Contains(body, "text-red-600") || strings.Contains(body, "-$50.00") {
+		t.Errorf("Transfer row should not render as an expense, got %s", body)
+	}
+
+	form := url.Values{"input": {"remove 50"}}
+	req = httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	app.HandleTransactionCreate(rec, req)
+	body = rec.Body.String()
+	if !strings.Contains(body, "Transfer") || strings.Contains(body, "Expense") {
+		t.Errorf("Removal candidates should label the row a transfer, got %s", body)
+	}
+}
+
 func TestHandleDashboardDetailed_YearFilter(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -825,6 +1243,88 @@ func TestHandleDashboardDetailed_CategoryTotals(t *testing.T) {
 	}
 }
 
+func TestHandleDashboardDetailed_TopCategories(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	for _, name := range []string{"Fun", "Health", "Pets"} {
+		if _, err := app.DB.Exec("INSERT INTO categories (name, type, icon) VALUES (?, 'expense', '🏷️')", name); err != nil {
+			t.Fatalf("Failed to create category %s: %v", name, err)
+		}
+	}
+
+	// Six expense categories: Food, Transport, Housing, Fun, Health, Pets
+	date := time.Date(time.Now().Year(), 6, 15, 10, 0, 0, 0, time.UTC)
+	amounts := map[int64]int64{1: -60000, 2: -50000, 3: -40000, 5: -3000, 6: -2000, 7: -1000}
+	for catID, amount := range amounts {
+		createTestTransaction(t, app, catID, amount, fmt.Sprintf("expense %d", catID), date)
+	}
+
+	t.Run("helper keeps three plus Other", func(t *testing.T) {
+		rows, err := app.fiscalYearCategoryTotals(context.Background(), date.Year())
+		if err != nil {
+			t.Fatalf("Failed to load category totals: %v", err)
+		}
+		var expenses []db.GetCategoryTotalsByYearRow
+		for _, row := range topCategoryTotals(rows, 3) {
+			if row.CategoryType == "expense" {
+				expenses = append(expenses, row)
+			}
+		}
+
+		var names []string
+		for _, row := range expenses {
+			names = append(names, row.CategoryName)
+		}
+		want := []string{"Food", "Transport", "Housing", "Other"}
+		if !slices.Equal(names, want) {
+			t.Fatalf("Expense categories = %v, want %v", names, want)
+		}
+		other := expenses[3]
+		if other.TotalAmount != 6000 || other.TransactionCount != 3 {
+			t.Errorf("Other = %d over %d transactions, want 6000 over 3", other.TotalAmount, other.TransactionCount)
+		}
+	})
+
+	t.Run("handler applies top", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/detailed?top=3", nil)
+		rec := httptest.NewRecorder()
+		app.HandleDashboardDetailed(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		body := rec.Body.String()
+		if !strings.Contains(body, "Other") {
+			t.Error("Expected an aggregated Other category")
+		}
+		if strings.Contains(body, "Pets") {
+			t.Error("Categories outside the top 3 should be folded into Other")
+		}
+	})
+
+	t.Run("default is unchanged", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/detailed", nil)
+		rec := httptest.NewRecorder()
+		app.HandleDashboardDetailed(rec, req)
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "Pets") || strings.Contains(body, "Other") {
+			t.Error("Without top every category should be shown and none aggregated")
+		}
+	})
+
+	t.Run("invalid top", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/dashboard/detailed?top=0", nil)
+		rec := httptest.NewRecorder()
+		app.HandleDashboardDetailed(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
+
 func TestHandleDashboardDetailed_MonthlyTotals(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -1108,6 +1608,203 @@ func TestHandleExportCSV(t *testing.T) {
 	})
 }
 
+func TestHandleExportCSV_Signed(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -2500, "pizza", date)
+	createTestTransaction(t, app, 4, 300000, "salary", date)
+
+	amounts := func(query string) map[string]string {
+		req := httptest.NewRequest(http.MethodGet, "/api/export/csv"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleExportCSV(rec, req)
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+		got := make(map[string]string)
+		for _, rec := range records[1:] {
+			got[rec[2]] = rec[5]
+		}
+		return got
+	}
+
+	if got := amounts(""); got["pizza"] != "25.00" || got["salary"] != "3000.00" {
+		t.Errorf("default export amounts = %v, want unsigned", got)
+	}
+	if got := amounts("?signed=true"); got["pizza"] != "-25.00" || got["salary"] != "3000.00" {
+		t.Errorf("signed export amounts = %v, want negative expense and positive income", got)
+	}
+}
+
+func TestHandleExportCSV_DateFormat(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -2500, "pizza", time.Date(2025, 6, 15, 10, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantDate   string
+	}{
+		{"", http.StatusOK, "2025-06-15"},
+		{"?date-format=iso", http.StatusOK, "2025-06-15"},
+		{"?date-format=us", http.StatusOK, "06/15/2025"},
+		{"?date-format=eu", http.StatusOK, "15/06/2025"},
+		{"?date-format=klingon", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/export/csv"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.HandleExportCSV(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("HandleExportCSV() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			records, err := csv.NewReader(rec.Body).ReadAll()
+			if err != nil {
+				t.Fatalf("Failed to parse CSV: %v", err)
+			}
+			if len(records) != 2 || records[1][1] != tt.wantDate {
+				t.Errorf("Date column = %v, want %q", records, tt.wantDate)
+			}
+		})
+	}
+}
+
+func TestHandleExportCategorySummaryCSV(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2025, 4, 10, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -2500, "groceries", date)
+	createTestTransaction(t, app, 1, -1250, "pizza", date)
+	createTestTransaction(t, app, 2, -800, "bus", date)
+	createTestTransaction(t, app, 4, 300000, "salary", date)
+	// Other years are excluded
+	createTestTransaction(t, app, 1, -9900, "old groceries", time.Date(2024, 4, 10, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/export/category-summary.csv?year=2025", nil)
+	rec := httptest.NewRecorder()
+	app.HandleExportCategorySummaryCSV(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleExportCategorySummaryCSV() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv" {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != "Category,Type,Total,TransactionCount" {
+		t.Fatalf("Unexpected header: %v", records)
+	}
+
+	got := make(map[string][]string)
+	for _, row := range records[1:] {
+		got[row[0]] = row[1:]
+	}
+	want := map[string][]string{
+		"Food":          {"expense", "37.50", "2"},
+		"Transport":     {"expense", "8.00", "1"},
+		"Housing":       {"expense", "0.00", "0"},
+		"Earned Income": {"income", "3000.00", "1"},
+	}
+	for name, w := range want {
+		if strings.Join(got[name], ",") != strings.Join(w, ",") {
+			t.Errorf("Row %s = %v, want %v", name, got[name], w)
+		}
+	}
+
+	t.Run("nonzero omits unused categories", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/export/category-summary.csv?year=2025&nonzero=true", nil)
+		rec := httptest.NewRecorder()
+		app.HandleExportCategorySummaryCSV(rec, req)
+
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("Failed to parse CSV: %v", err)
+		}
+		var names []string
+		for _, row := range records[1:] {
+			names = append(names, row[0])
+		}
+		if len(names) != 3 || slices.Contains(names, "Housing") {
+			t.Errorf("Categories = %v, want Food, Transport and Earned Income only", names)
+		}
+	})
+
+	t.Run("invalid year", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/export/category-summary.csv?year=abc", nil)
+		rec := httptest.NewRecorder()
+		app.HandleExportCategorySummaryCSV(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestHandleExportMonthlyCSV(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 4, 300000, "salary", time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -12550, "groceries", time.Date(2025, 1, 5, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 3, -150000, "rent", time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -999, "other year", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/export/monthly.csv?year=2025", nil)
+	rec := httptest.NewRecorder()
+	app.HandleExportMonthlyCSV(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleExportMonthlyCSV() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 13 {
+		t.Fatalf("Got %d rows, want header plus 12 months", len(records))
+	}
+	if strings.Join(records[0], ",") != "Month,Income,Expense,Net" {
+		t.Errorf("Header = %v", records[0])
+	}
+
+	want := map[string]string{
+		"2025-01": "3000.00,125.50,2874.50",
+		"2025-02": "0.00,0.00,0.00",
+		"2025-03": "0.00,1500.00,-1500.00",
+	}
+	for i, row := range records[1:] {
+		if wantMonth := fmt.Sprintf("2025-%02d", i+1); row[0] != wantMonth {
+			t.Errorf("Row %d month = %q, want %q", i+1, row[0], wantMonth)
+		}
+		income, _ := strconv.ParseFloat(row[1], 64)
+		expense, _ := strconv.ParseFloat(row[2], 64)
+		net, _ := strconv.ParseFloat(row[3], 64)
+		if fmt.Sprintf("%.2f", income-expense) != fmt.Sprintf("%.2f", net) {
+			t.Errorf("%s net = %s, want income - expense", row[0], row[3])
+		}
+		if w, ok := want[row[0]]; ok && strings.Join(row[1:], ",") != w {
+			t.Errorf("%s = %v, want %s", row[0], row[1:], w)
+		}
+	}
+}
+
 func TestHandleWipeData(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -1147,8 +1844,14 @@ func TestHandleWipeData(t *testing.T) {
 		t.Fatalf("Expected 2 transactions, got %d", len(txs))
 	}
 
+	// A soft-deleted row is removed by the wipe too, so it counts
+	trashed := createTestTransaction(t, app, 1, 900, "Test trashed", time.Now())
+	if err := app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: trashed.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to soft-delete transaction: %v", err)
+	}
+
 	// Wipe data
-	req := httptest.NewRequest(http.MethodDelete, "/api/data", nil)
+	req := httptest.NewRequest(http.MethodDelete, "/api/data?confirm=WIPE", nil)
 	rec := httptest.NewRecorder()
 
 	app.HandleWipeData(rec, req)
@@ -1161,6 +1864,9 @@ func TestHandleWipeData(t *testing.T) {
 	if !strings.Contains(body, "deleted") {
 		t.Error("HandleWipeData() response should confirm deletion")
 	}
+	if !strings.Contains(body, "Deleted 3 transactions") {
+		t.Errorf("HandleWipeData() response should report the deleted count, got: %s", body)
+	}
 
 	// Verify transactions are gone
 	txs, err = app.Q.ListRecentTransactions(ctx)
@@ -1172,6 +1878,49 @@ func TestHandleWipeData(t *testing.T) {
 	}
 }
 
+func TestHandleWipeData_ConfirmPhrase(t *testing.T) {
+	tests := []struct {
+		name       string
+		phrase     string
+		confirm    string
+		wantWiped  bool
+		wantPrompt string
+	}{
+		{"default phrase", "", "WIPE", true, ""},
+		{"default phrase missing", "", "", false, "WIPE"},
+		{"default phrase wrong case", "", "wipe", false, "WIPE"},
+		{"custom phrase", "burn it all", "burn it all", true, ""},
+		{"custom phrase rejects default", "burn it all", "WIPE", false, "burn it all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.WipePhrase = tt.phrase
+			createTestTransaction(t, app, 1, -2500, "pizza", time.Now())
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/data?"+url.Values{"confirm": {tt.confirm}}.Encode(), nil)
+			rec := httptest.NewRecorder()
+			app.HandleWipeData(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("HandleWipeData() status = %d", rec.Code)
+			}
+			count, err := app.Q.CountAllTransactions(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to count transactions: %v", err)
+			}
+			if wiped := count == 0; wiped != tt.wantWiped {
+				t.Errorf("wiped = %v, want %v", wiped, tt.wantWiped)
+			}
+			if tt.wantPrompt != "" && !strings.Contains(rec.Body.String(), tt.wantPrompt) {
+				t.Errorf("Expected response to name the phrase %q, got %s", tt.wantPrompt, rec.Body.String())
+			}
+		})
+	}
+}
+
 func TestHandleTransactionCreate_AmountConversion(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -1282,6 +2031,120 @@ func TestHandleTransactionDelete_SoftDelete(t *testing.T) {
 	}
 }
 
+func TestHandleTransactionDelete_Policy(t *testing.T) {
+	tests := []struct {
+		policy    string
+		wantCount int64
+	}{
+		{policy: deletePolicySoft, wantCount: 1},
+		{policy: deletePolicyHard, wantCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.DeletePolicy = tt.policy
+
+			date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+			tx := createTestTransaction(t, app, 1, -2500, "test pizza", date)
+
+			req := withIDParam(httptest.NewRequest(http.MethodDelete, "/api/transaction/"+strconv.FormatInt(tx.ID, 10), nil), tx.ID)
+			rec := httptest.NewRecorder()
+			app.HandleTransactionDelete(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("HandleTransactionDelete() status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			count, err := app.Q.CountTransactionsByYearWithDeleted(context.Background(), "2024")
+			if err != nil {
+				t.Fatalf("CountTransactionsByYearWithDeleted() error = %v", err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("with-deleted count under %s policy = %d, want %d", tt.policy, count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestDeleteTransaction_HardRemovesDependents(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.DeletePolicy = deletePolicyHard
+	app.Config.UploadsDir = t.TempDir()
+	ctx := context.Background()
+
+	count := func(query string, id int64) int {
+		t.Helper()
+		var n int
+		if err := app.DB.QueryRow(query, id).Scan(&n); err != nil {
+			t.Fatalf("Failed to count %q: %v", query, err)
+		}
+		return n
+	}
+	seed := func(desc string) (db.Transaction, string) {
+		t.Helper()
+		tx := createTestTransaction(t, app, 1, -2500, desc, time.Now())
+		receipt := fmt.Sprintf("receipt-%d.png", tx.ID)
+		if err := os.WriteFile(filepath.Join(app.Config.UploadsDir, receipt), []byte("png"), 0644); err != nil {
+			t.Fatalf("Failed to write receipt: %v", err)
+		}
+		if err := app.Q.SetTransactionReceipt(ctx, db.SetTransactionReceiptParams{ReceiptPath: sql.NullString{String: receipt, Valid: true}, ID: tx.ID, UserID: 1}); err != nil {
+			t.Fatalf("SetTransactionReceipt() error = %v", err)
+		}
+		if err := app.Q.AddTransactionTag(ctx, db.AddTransactionTagParams{TransactionID: tx.ID, Tag: "trip"}); err != nil {
+			t.Fatalf("AddTransactionTag() error = %v", err)
+		}
+		app.recordTransactionChange(ctx, tx.ID, "amount", -2000, -2500)
+		app.recordAudit(ctx, "create", tx.ID, "")
+		return tx, receipt
+	}
+	assertGone := func(tx db.Transaction, receipt string) {
+		t.Helper()
+		if n := count("SELECT COUNT(*) FROM transactions WHERE id = ?", tx.ID); n != 0 {
+			t.Errorf("transaction %d still has %d rows", tx.ID, n)
+		}
+		for _, query := range []string{
+			"SELECT COUNT(*) FROM transaction_tags WHERE transaction_id = ?",
+			"SELECT COUNT(*) FROM transaction_history WHERE transaction_id = ?",
+			"SELECT COUNT(*) FROM audit_log WHERE entity_id = ?",
+		} {
+			if n := count(query, tx.ID); n != 0 {
+				t.Errorf("%s = %d after hard delete, want 0", query, n)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(app.Config.UploadsDir, receipt)); !os.IsNotExist(err) {
+			t.Errorf("receipt %s should be removed, stat error = %v", receipt, err)
+		}
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		tx, receipt := seed("deleted")
+		req := withIDParam(httptest.NewRequest(http.MethodDelete, "/api/transaction/"+strconv.FormatInt(tx.ID, 10), nil), tx.ID)
+		app.HandleTransactionDelete(httptest.NewRecorder(), req)
+		assertGone(tx, receipt)
+	})
+
+	t.Run("undo", func(t *testing.T) {
+		tx, receipt := seed("undone")
+		app.HandleTransactionUndo(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/transaction/undo", nil))
+		assertGone(tx, receipt)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		tx, receipt := seed("split")
+		body := `{"splits": [{"category": "Food", "amount": 1000}, {"category": "Transport", "amount": 1500}]}`
+		req := withIDParam(httptest.NewRequest(http.MethodPost, "/api/transaction/"+strconv.FormatInt(tx.ID, 10)+"/split", strings.NewReader(body)), tx.ID)
+		rec := httptest.NewRecorder()
+		app.HandleTransactionSplit(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionSplit() status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		assertGone(tx, receipt)
+	})
+}
+
 func TestHandleTransactionCreate_RemoveCommand(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -1337,6 +2200,46 @@ func TestHandleTransactionCreate_RemoveCommand(t *testing.T) {
 			t.Error("Should show no matching transactions message")
 		}
 	})
+
+	t.Run("remove command matches income and expense of the same magnitude", func(t *testing.T) {
+		for _, tx := range []struct {
+			categoryID int64
+			amount     int64
+			desc       string
+		}{
+			{categoryID: 1, amount: -20000, desc: "groceries haul"},
+			{categoryID: 4, amount: 20000, desc: "freelance gig"},
+		} {
+			_, err := app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
+				UserID:      1,
+				CategoryID:  tx.categoryID,
+				Amount:      tx.amount,
+				Currency:    "USD",
+				Description: tx.desc,
+				Date:        time.Now(),
+			})
+			if err != nil {
+				t.Fatalf("Failed to create transaction: %v", err)
+			}
+		}
+
+		form := url.Values{"input": {"remove 200"}}
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		app.HandleTransactionCreate(rec, req)
+
+		body := rec.Body.String()
+		if !strings.Contains(body, "Found 2 transaction(s)") {
+			t.Errorf("Remove search should find both transactions, got: %s", body)
+		}
+		for _, want := range []string{"groceries haul", "freelance gig", "Expense", "Income"} {
+			if !strings.Contains(body, want) {
+				t.Errorf("Remove candidates should contain %q", want)
+			}
+		}
+	})
 }
 
 func TestHandleDashboard_ShowDeleted(t *testing.T) {
@@ -1397,5 +2300,918 @@ func TestHandleDashboard_ShowDeleted(t *testing.T) {
 		if !strings.Contains(body, "removed") {
 			t.Error("Deleted transaction should show 'removed' label")
 		}
+		if !strings.Contains(body, "1 removed") {
+			t.Error("Header should count the removed transactions")
+		}
+	})
+}
+
+func TestHandleCategoryReset(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 1, -1500, "mystery charge", date)
+	createTestTransaction(t, app, 1, -2500, "another charge", date)
+	createTestTransaction(t, app, 2, -700, "bus", date)
+	removed := createTestTransaction(t, app, 1, -900, "removed charge", date)
+	if err := app.Q.SoftDeleteTransaction(context.Background(), db.SoftDeleteTransactionParams{ID: removed.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to soft-delete: %v", err)
+	}
+
+	reset := func(id int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/categories/%d/reset", id), nil)
+		rec := httptest.NewRecorder()
+		app.HandleCategoryReset(rec, withIDParam(req, id))
+		return rec
+	}
+
+	rec := reset(1)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleCategoryReset() status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var resp CategoryResetResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp != (CategoryResetResponse{Moved: 2, From: "Food", To: "Housing"}) {
+		t.Errorf("HandleCategoryReset() = %+v, want 2 moved Food -> Housing", resp)
+	}
+
+	totals, err := app.Q.GetCategoryTotalsByYear(context.Background(), "2025")
+	if err != nil {
+		t.Fatalf("Failed to get totals: %v", err)
+	}
+	for _, ct := range totals {
+		switch ct.CategoryName {
+		case "Food":
+			if ct.TransactionCount != 0 {
+				t.Errorf("Food still has %d transactions, want 0", ct.TransactionCount)
+			}
+		case "Housing":
+			if ct.TransactionCount != 2 || ct.TotalAmount != 4000 {
+				t.Errorf("Housing = %d transactions / %d cents, want 2 / 4000", ct.TransactionCount, ct.TotalAmount)
+			}
+		case "Transport":
+			if ct.TransactionCount != 1 {
+				t.Errorf("Transport has %d transactions, want 1", ct.TransactionCount)
+			}
+		}
+	}
+
+	t.Run("refuses to reset the fallback category", func(t *testing.T) {
+		if rec := reset(3); rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		if rec := reset(999); rec.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+
+func TestHandleCategoryEnsure(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	ensure := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/categories/ensure", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		app.HandleCategoryEnsure(rec, req)
+		return rec
+	}
+
+	body := `{"name": "Pets", "type": "expense", "icon": "🐾", "color": "#795548"}`
+	first := ensure(body)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first HandleCategoryEnsure() status = %d, body = %s", first.Code, first.Body.String())
+	}
+	var created StorageCategory
+	if err := json.NewDecoder(first.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	second := ensure(body)
+	if second.Code != http.StatusOK {
+		t.Fatalf("second HandleCategoryEnsure() status = %d, body = %s", second.Code, second.Body.String())
+	}
+	var existing StorageCategory
+	if err := json.NewDecoder(second.Body).Decode(&existing); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if existing != created {
+		t.Errorf("second HandleCategoryEnsure() = %+v, want %+v", existing, created)
+	}
+
+	cats, err := app.Q.ListCategories(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list categories: %v", err)
+	}
+	count := 0
+	for _, c := range cats {
+		if c.Name == "Pets" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("found %d Pets categories, want 1", count)
+	}
+
+	if rec := ensure(`{"name": "Lottery", "type": "windfall"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid type status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNextCategoryColor(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := range categoryPalette {
+		color := nextCategoryColor(i)
+		if seen[color] {
+			t.Errorf("nextCategoryColor(%d) = %s, already used by an earlier count", i, color)
+		}
+		seen[color] = true
+	}
+	if got, want := nextCategoryColor(len(categoryPalette)), nextCategoryColor(0); got != want {
+		t.Errorf("nextCategoryColor(%d) = %s, want the palette to wrap to %s", len(categoryPalette), got, want)
+	}
+}
+
+func TestHandleCategoryEnsure_AssignsPaletteColors(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	colors := make(map[string]string)
+	for _, name := range []string{"Pets", "Garden", "Hobbies"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/categories/ensure", strings.NewReader(`{"name": "`+name+`", "type": "expense"}`))
+		rec := httptest.NewRecorder()
+		app.HandleCategoryEnsure(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("HandleCategoryEnsure(%s) status = %d, body = %s", name, rec.Code, rec.Body.String())
+		}
+		var cat StorageCategory
+		if err := json.NewDecoder(rec.Body).Decode(&cat); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		for other, color := range colors {
+			if color == cat.Color {
+				t.Errorf("%s got %s, the same color as %s", name, cat.Color, other)
+			}
+		}
+		colors[name] = cat.Color
+	}
+
+	// The four seeded categories come first, so Pets takes the fifth color
+	if colors["Pets"] != nextCategoryColor(4) {
+		t.Errorf("Pets color = %s, want %s", colors["Pets"], nextCategoryColor(4))
+	}
+}
+
+func TestHandleCategoryEnsure_TransferExcludedFromTotals(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/categories/ensure", strings.NewReader(`{"name": "Savings", "type": "transfer"}`))
+	rec := httptest.NewRecorder()
+	app.HandleCategoryEnsure(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("HandleCategoryEnsure() status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var savings StorageCategory
+	if err := json.NewDecoder(rec.Body).Decode(&savings); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	date := time.Date(2024, 4, 5, 12, 0, 0, 0, time.UTC)
+	createTestTransaction(t, app, 4, 500000, "salary", date)
+	createTestTransaction(t, app, 1, -2000, "groceries", date)
+	createTestTransaction(t, app, savings.ID, 100000, "move to savings", date)
+
+	monthly, err := app.Q.GetMonthlyTotalsByYear(context.Background(), "2024")
+	if err != nil {
+		t.Fatalf("GetMonthlyTotalsByYear() error = %v", err)
+	}
+	totals := map[string]int64{}
+	for _, m := range monthly {
+		totals[m.CategoryType] += m.TotalAmount
+	}
+	if len(totals) != 2 || totals["income"] != 500000 || totals["expense"] != 2000 {
+		t.Errorf("GetMonthlyTotalsByYear() totals = %v, want income 500000 and expense 2000 only", totals)
+	}
+
+	categories, err := app.Q.GetCategoryTotalsByYear(context.Background(), "2024")
+	if err != nil {
+		t.Fatalf("GetCategoryTotalsByYear() error = %v", err)
+	}
+	for _, c := range categories {
+		if c.CategoryType == "transfer" {
+			t.Errorf("GetCategoryTotalsByYear() included transfer category %q", c.CategoryName)
+		}
+	}
+}
+
+func TestHandleBulkTag(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2024, 8, 3, 12, 0, 0, 0, time.UTC)
+	flight := createTestTransaction(t, app, 2, -30000, "flight", date)
+	hotel := createTestTransaction(t, app, 3, -45000, "hotel", date)
+	dinner := createTestTransaction(t, app, 1, -6000, "dinner", date)
+	createTestTransaction(t, app, 1, -1500, "lunch at home", date)
+
+	bulkTag := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/transactions/tag", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		app.HandleBulkTag(rec, req)
+		return rec
+	}
+
+	body := fmt.Sprintf(`{"ids": [%d, %d, %d, 9999], "tag": "#vacation"}`, flight.ID, hotel.ID, dinner.ID)
+	rec := bulkTag(body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleBulkTag() status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	var resp BulkTagResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Tagged != 3 {
+		t.Errorf("HandleBulkTag() tagged %d, want 3", resp.Tagged)
+	}
+
+	// Tagging again skips the duplicates
+	rec = bulkTag(body)
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Tagged != 0 {
+		t.Errorf("second HandleBulkTag() tagged %d, want 0", resp.Tagged)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/tags?year=2024", nil)
+	rec = httptest.NewRecorder()
+	app.HandleTagTotals(rec, req)
+	var totals []TagTotal
+	if err := json.NewDecoder(rec.Body).Decode(&totals); err != nil {
+		t.Fatalf("Failed to decode tag totals: %v", err)
+	}
+	want := TagTotal{Tag: "vacation", TransactionCount: 3, TotalCents: 81000}
+	if len(totals) != 1 || totals[0] != want {
+		t.Errorf("HandleTagTotals() = %+v, want [%+v]", totals, want)
+	}
+
+	if rec := bulkTag(`{"ids": [1], "tag": "  "}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("empty tag status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleTransactionUndo(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	undo := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction/undo", nil)
+		rec := httptest.NewRecorder()
+		app.HandleTransactionUndo(rec, req)
+		return rec
+	}
+
+	t.Run("nothing to undo", func(t *testing.T) {
+		rec := undo()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionUndo() status = %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "Nothing to undo") {
+			t.Errorf("Expected friendly no-op message, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("removes the latest transaction", func(t *testing.T) {
+		first := createTestTransaction(t, app, 1, -800, "coffee", time.Now())
+		latest := createTestTransaction(t, app, 1, -1250, "pizza", time.Now().AddDate(0, 0, -3))
+
+		rec := undo()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionUndo() status = %d", rec.Code)
+		}
+		if !strings.Contains(rec.Body.String(), "Undid: $12.50 pizza") {
+			t.Errorf("Expected undo summary, got %s", rec.Body.String())
+		}
+
+		ctx := context.Background()
+		got, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: latest.ID, UserID: 1})
+		if err != nil {
+			t.Fatalf("Failed to get transaction: %v", err)
+		}
+		if !got.DeletedAt.Valid {
+			t.Error("Latest transaction should be soft-deleted")
+		}
+		got, err = app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: first.ID, UserID: 1})
+		if err != nil {
+			t.Fatalf("Failed to get transaction: %v", err)
+		}
+		if got.DeletedAt.Valid {
+			t.Error("Earlier transaction should not be deleted")
+		}
+	})
+}
+
+func TestHandleTransactionTrash(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	ctx := context.Background()
+	kept := createTestTransaction(t, app, 1, -500, "bagel", time.Now())
+	older := createTestTransaction(t, app, 1, -700, "taxi", time.Now())
+	newer := createTestTransaction(t, app, 2, -900, "parking", time.Now())
+
+	for _, tx := range []db.Transaction{older, newer} {
+		if err := app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: tx.ID, UserID: 1}); err != nil {
+			t.Fatalf("Failed to soft delete transaction: %v", err)
+		}
+	}
+	if _, err := app.DB.Exec("UPDATE transactions SET deleted_at = ? WHERE id = ?", time.Now().Add(-time.Hour), older.ID); err != nil {
+		t.Fatalf("Failed to backdate deletion: %v", err)
+	}
+
+	deleted, err := app.Q.ListRecentDeletedTransactions(ctx)
+	if err != nil {
+		t.Fatalf("ListRecentDeletedTransactions() error = %v", err)
+	}
+	if len(deleted) != 2 || deleted[0].ID != newer.ID || deleted[1].ID != older.ID {
+		t.Fatalf("Expected [%d %d] newest-deleted first, got %+v", newer.ID, older.ID, deleted)
+	}
+
+	recent, err := app.Q.ListRecentTransactions(ctx)
+	if err != nil {
+		t.Fatalf("ListRecentTransactions() error = %v", err)
+	}
+	if len(recent) != 1 || recent[0].ID != kept.ID {
+		t.Errorf("Active list should only contain %d, got %+v", kept.ID, recent)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/trash", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTransactionTrash(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTransactionTrash() status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"taxi", "parking", fmt.Sprintf("/api/transaction/%d/restore", older.ID)} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Trash listing missing %q", want)
+		}
+	}
+	if strings.Contains(body, "bagel") {
+		t.Error("Trash listing should not include active transactions")
+	}
+	if strings.Index(body, "parking") > strings.Index(body, "taxi") {
+		t.Error("Most recently deleted transaction should be listed first")
+	}
+
+	req = withIDParam(httptest.NewRequest(http.MethodPost, "/api/transaction/1/restore", nil), older.ID)
+	rec = httptest.NewRecorder()
+	app.HandleTransactionRestore(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTransactionRestore() status = %d", rec.Code)
+	}
+	got, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: older.ID, UserID: 1})
+	if err != nil {
+		t.Fatalf("Failed to get transaction: %v", err)
+	}
+	if got.DeletedAt.Valid {
+		t.Error("Restored transaction should no longer be deleted")
+	}
+}
+
+func TestHandleUncategorizedTransactions(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fallback := createTestTransaction(t, app, 3, -4200, "mystery import", date)
+	createTestTransaction(t, app, 1, -1300, "sushi", date)
+
+	rows, err := app.Q.ListTransactionsInCategory(context.Background(), app.CatConfig.DefaultCategory)
+	if err != nil {
+		t.Fatalf("ListTransactionsInCategory() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].ID != fallback.ID {
+		t.Fatalf("Expected only transaction %d in the fallback category, got %+v", fallback.ID, rows)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/uncategorized", nil)
+	rec := httptest.NewRecorder()
+	app.HandleUncategorizedTransactions(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleUncategorizedTransactions() status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "mystery import") {
+		t.Error("Expected the fallback transaction to be listed")
+	}
+	if !strings.Contains(body, fmt.Sprintf("/api/transaction/%d/category", fallback.ID)) {
+		t.Error("Expected a recategorize control for the fallback transaction")
+	}
+	if strings.Contains(body, "sushi") {
+		t.Error("Transactions in specific categories should not be listed")
+	}
+}
+
+func TestHandleTransactionRecategorize(t *testing.T) {
+	recategorize := func(app *Application, id string, form url.Values) *httptest.ResponseRecorder {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", id)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction/"+id+"/category", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		app.HandleTransactionRecategorize(rec, req)
+		return rec
+	}
+
+	t.Run("moving expense to income flips the sign", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		ctx := context.Background()
+		tx, err := app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  1, // Food
+			Amount:      -20000,
+			Currency:    "USD",
+			Description: "Refund from store",
+			Date:        time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+
+		rec := recategorize(app, strconv.FormatInt(tx.ID, 10), url.Values{"category": {"Earned Income"}})
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionRecategorize() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if !strings.Contains(rec.Body.String(), "Earned Income") {
+			t.Error("Response should render the row with the new category")
+		}
+
+		updated, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: tx.ID, UserID: 1})
+		if err != nil {
+			t.Fatalf("Failed to load transaction: %v", err)
+		}
+		if updated.CategoryName != "Earned Income" {
+			t.Errorf("CategoryName = %q, want %q", updated.CategoryName, "Earned Income")
+		}
+		if updated.Amount != 20000 {
+			t.Errorf("Amount = %d, want 20000", updated.Amount)
+		}
+	})
+
+	t.Run("unknown category uses fallback", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		ctx := context.Background()
+		tx, err := app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      1,
+			CategoryID:  4, // Earned Income
+			Amount:      5000,
+			Currency:    "USD",
+			Description: "Misfiled",
+			Date:        time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+
+		rec := recategorize(app, strconv.FormatInt(tx.ID, 10), url.Values{"category": {"Nonexistent"}})
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionRecategorize() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		updated, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: tx.ID, UserID: 1})
+		if err != nil {
+			t.Fatalf("Failed to load transaction: %v", err)
+		}
+		if updated.CategoryType != "expense" || updated.Amount != -5000 {
+			t.Errorf("Expected fallback expense category with amount -5000, got %s %d", updated.CategoryType, updated.Amount)
+		}
+	})
+
+	t.Run("missing category", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		rec := recategorize(app, "1", url.Values{})
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("HandleTransactionRecategorize() status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("transaction not found", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		rec := recategorize(app, "999", url.Values{"category": {"Food"}})
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("HandleTransactionRecategorize() status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestDisplayAbs(t *testing.T) {
+	tests := []struct {
+		name       string
+		displayAbs bool
+		wantSigned bool
+	}{
+		{name: "default shows expenses as negative", displayAbs: false, wantSigned: true},
+		{name: "display-abs shows expenses as positive", displayAbs: true, wantSigned: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.DisplayAbs = tt.displayAbs
+
+			// Income keeps the balance from also reading "-$25.00"
+			for _, input := range []string{"100 salary", "25 pizza"} {
+				form := url.Values{"input": {input}}
+				req := httptest.NewRequest(http.MethodPost, "/api/transaction", strings.NewReader(form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				app.withDisplayOptions(http.HandlerFunc(app.HandleTransactionCreate)).ServeHTTP(httptest.NewRecorder(), req)
+			}
+
+			// Storage always stays signed
+			var amount int64
+			if err := app.DB.QueryRow("SELECT amount FROM transactions WHERE description = 'pizza'").Scan(&amount); err != nil {
+				t.Fatalf("Failed to read stored amount: %v", err)
+			}
+			if amount != -2500 {
+				t.Errorf("Stored amount = %d, want -2500", amount)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+			rec := httptest.NewRecorder()
+			app.withDisplayOptions(http.HandlerFunc(app.HandleDashboard)).ServeHTTP(rec, req)
+
+			body := rec.Body.String()
+			if !strings.Contains(body, "$25.00") {
+				t.Fatal("Dashboard should show the transaction amount")
+			}
+			if got := strings.Contains(body, "-$25.00"); got != tt.wantSigned {
+				t.Errorf("Dashboard contains \"-$25.00\" = %v, want %v", got, tt.wantSigned)
+			}
+		})
+	}
+}
+
+func TestDisplayRoundingDollars(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.DisplayRounding = "dollars"
+
+	createTestTransaction(t, app, 1, -1250, "pizza", time.Now())
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	rec := httptest.NewRecorder()
+	app.withDisplayOptions(http.HandlerFunc(app.HandleDashboard)).ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if !strings.Contains(body, "-$13") {
+		t.Error("Dashboard should show the amount rounded to \"-$13\"")
+	}
+	if strings.Contains(body, "12.50") {
+		t.Error("Dashboard should not show cents in dollars mode")
+	}
+
+	// Exports always keep cents
+	req = httptest.NewRequest(http.MethodGet, "/api/export/csv", nil)
+	rec = httptest.NewRecorder()
+	app.withDisplayOptions(http.HandlerFunc(app.HandleExportCSV)).ServeHTTP(rec, req)
+
+	if !strings.Contains(rec.Body.String(), ",12.50,") {
+		t.Errorf("CSV export should keep cents, got:\n%s", rec.Body.String())
+	}
+}
+
+func TestHandleCategorizeMatches(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	t.Run("ambiguous description", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/categorize/matches?desc="+url.QueryEscape("Uber Eats delivery"), nil)
+		rec := httptest.NewRecorder()
+		app.HandleCategorizeMatches(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var matches []Match
+		if err := json.NewDecoder(rec.Body).Decode(&matches); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		for _, want := range []Match{
+			{Category: "Food", Keyword: "uber eats"},
+			{Category: "Food", Keyword: "delivery"},
+			{Category: "Transport", Keyword: "uber"},
+		} {
+			if !slices.Contains(matches, want) {
+				t.Errorf("Matches %v missing %v", matches, want)
+			}
+		}
+	})
+
+	t.Run("missing desc", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/categorize/matches", nil)
+		rec := httptest.NewRecorder()
+		app.HandleCategorizeMatches(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestHandleParsePreview(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	preview := func(input string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/parse-preview?input="+url.QueryEscape(input), nil)
+		rec := httptest.NewRecorder()
+		app.HandleParsePreview(rec, req)
+		return rec
+	}
+
+	t.Run("valid expense", func(t *testing.T) {
+		rec := preview("50 pizza")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		var resp ParsePreviewResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.AmountCents != 5000 {
+			t.Errorf("AmountCents = %d, want 5000", resp.AmountCents)
+		}
+		if resp.Description != "pizza" {
+			t.Errorf("Description = %q, want %q", resp.Description, "pizza")
+		}
+		want := ParsePreviewCategory{Name: "Food", Icon: "🍔", Color: "#FF5733", Type: "expense"}
+		if resp.Category != want {
+			t.Errorf("Category = %+v, want %+v", resp.Category, want)
+		}
+		if resp.Sign != "-" {
+			t.Errorf("Sign = %q, want %q", resp.Sign, "-")
+		}
+	})
+
+	t.Run("income input", func(t *testing.T) {
+		rec := preview("3000 salary")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", rec.Code, http.StatusOK)
+		}
+
+		var resp ParsePreviewResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Category.Type != "income" {
+			t.Errorf("Category.Type = %q, want income", resp.Category.Type)
+		}
+		if resp.Sign != "+" {
+			t.Errorf("Sign = %q, want %q", resp.Sign, "+")
+		}
+	})
+
+	t.Run("unparseable input", func(t *testing.T) {
+		rec := preview("pizza")
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("Status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+
+		var resp ParsePreviewError
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode error response: %v", err)
+		}
+		if resp.Error == "" {
+			t.Error("Error response should include a message")
+		}
+
+		var count int
+		app.DB.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count)
+		if count != 0 {
+			t.Errorf("Preview should not insert transactions, found %d", count)
+		}
+	})
+}
+
+func TestHandleTransactionCreate_ZeroAmount(t *testing.T) {
+	tests := []struct {
+		name       string
+		allowZero  bool
+		wantInsert bool
+	}{
+		{name: "rejected by default", allowZero: false, wantInsert: false},
+		{name: "accepted with allow-zero", allowZero: true, wantInsert: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := setupTestApp(t)
+			defer cleanupTestApp(t, app)
+			app.Config.AllowZero = tt.allowZero
+
+			rec := postTransaction(app, url.Values{"input": {"0 free sample"}})
+			body := rec.Body.String()
+
+			var count int
+			app.DB.QueryRow("SELECT COUNT(*) FROM transactions").Scan(&count)
+			if got := count == 1; got != tt.wantInsert {
+				t.Errorf("Inserted = %v, want %v (count %d)", got, tt.wantInsert, count)
+			}
+			if got := strings.Contains(body, "greater than zero"); got == tt.wantInsert {
+				t.Errorf("Error fragment shown = %v, want %v: %s", got, !tt.wantInsert, body)
+			}
+		})
+	}
+}
+
+func TestHandleTransactionSplit(t *testing.T) {
+	split := func(app *Application, id int64, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction/1/split", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", strconv.FormatInt(id, 10))
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		rec := httptest.NewRecorder()
+		app.HandleTransactionSplit(rec, req)
+		return rec
+	}
+
+	t.Run("splits into one transaction per category", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		date := time.Date(2024, 4, 2, 10, 0, 0, 0, time.UTC)
+		orig, err := app.Q.CreateTransaction(context.Background(), db.CreateTransactionParams{
+			UserID: 1, CategoryID: 1, Amount: -10000, Currency: "USD", Description: "supermarket", Date: date,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+
+		rec := split(app, orig.ID, `{"splits":[{"category":"Food","amount":6000},{"category":"Housing","amount":4000}]}`)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+
+		var created []StorageTransaction
+		if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(created) != 2 {
+			t.Fatalf("Expected 2 created transactions, got %d", len(created))
+		}
+		if created[0].CategoryName != "Food" || created[0].Amount != -6000 {
+			t.Errorf("First split = %+v, want Food -6000", created[0])
+		}
+		if created[1].CategoryName != "Housing" || created[1].Amount != -4000 {
+			t.Errorf("Second split = %+v, want Housing -4000", created[1])
+		}
+
+		var deleted sql.NullTime
+		app.DB.QueryRow("SELECT deleted_at FROM transactions WHERE id = ?", orig.ID).Scan(&deleted)
+		if !deleted.Valid {
+			t.Error("Original transaction should be soft-deleted")
+		}
+
+		rows, err := app.DB.Query("SELECT description, date FROM transactions WHERE deleted_at IS NULL ORDER BY id")
+		if err != nil {
+			t.Fatalf("Failed to query transactions: %v", err)
+		}
+		defer rows.Close()
+		count := 0
+		for rows.Next() {
+			var desc string
+			var txDate time.Time
+			rows.Scan(&desc, &txDate)
+			if !strings.HasPrefix(desc, "supermarket") {
+				t.Errorf("Split description = %q, want it to keep the original", desc)
+			}
+			if !txDate.Equal(date) {
+				t.Errorf("Split date = %v, want %v", txDate, date)
+			}
+			count++
+		}
+		if count != 2 {
+			t.Errorf("Expected 2 active transactions, got %d", count)
+		}
+	})
+
+	t.Run("rejects splits that do not sum to the original", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		orig, err := app.Q.CreateTransaction(context.Background(), db.CreateTransactionParams{
+			UserID: 1, CategoryID: 1, Amount: -10000, Currency: "USD", Description: "supermarket", Date: time.Now(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to create transaction: %v", err)
+		}
+
+		rec := split(app, orig.ID, `{"splits":[{"category":"Food","amount":6000},{"category":"Housing","amount":3000}]}`)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		if !strings.Contains(rec.Body.String(), "$90.00") || !strings.Contains(rec.Body.String(), "$100.00") {
+			t.Errorf("Error should state both totals, got %q", rec.Body.String())
+		}
+
+		var count int
+		app.DB.QueryRow("SELECT COUNT(*) FROM transactions WHERE deleted_at IS NULL").Scan(&count)
+		if count != 1 {
+			t.Errorf("Rejected split should leave the original untouched, found %d active transactions", count)
+		}
+	})
+}
+
+func TestHandleTransactionUpdateCurrency(t *testing.T) {
+	updateCurrency := func(app *Application, id int64, currency string) *httptest.ResponseRecorder {
+		form := url.Values{"currency": {currency}}
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction/1/currency", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		app.HandleTransactionUpdateCurrency(rec, withIDParam(req, id))
+		return rec
+	}
+
+	t.Run("updated currency appears in storage export", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		tx := createTestTransaction(t, app, 1, -2500, "croissant", time.Now())
+
+		rec := updateCurrency(app, tx.ID, "eur")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleTransactionUpdateCurrency() status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "croissant") {
+			t.Error("Response should render the updated row")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/storage/export", nil)
+		rec = httptest.NewRecorder()
+		app.HandleStorageExport(rec, req)
+
+		var resp StorageExportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode export: %v", err)
+		}
+		if len(resp.Transactions) != 1 {
+			t.Fatalf("Transactions count = %d, want 1", len(resp.Transactions))
+		}
+		if resp.Transactions[0].Currency != "EUR" {
+			t.Errorf("Exported currency = %q, want %q", resp.Transactions[0].Currency, "EUR")
+		}
+	})
+
+	t.Run("rejects unknown currency", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		tx := createTestTransaction(t, app, 1, -2500, "croissant", time.Now())
+
+		rec := updateCurrency(app, tx.ID, "XYZ")
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("HandleTransactionUpdateCurrency() status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+
+		var stored string
+		app.DB.QueryRow("SELECT currency FROM transactions WHERE id = ?", tx.ID).Scan(&stored)
+		if stored != "USD" {
+			t.Errorf("Stored currency = %q, want %q", stored, "USD")
+		}
+	})
+
+	t.Run("missing transaction", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		rec := updateCurrency(app, 999, "EUR")
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("HandleTransactionUpdateCurrency() status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
 	})
 }
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// HealthResponse is the body returned by the liveness and readiness probes
+type HealthResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// HandleLivez reports that the process is up. It deliberately does not touch
+// the database so a slow or locked DB never gets the process restarted.
+func (app *Application) HandleLivez(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// HandleReadyz reports whether the server can take traffic: the database must
+// be reachable and the schema applied.
+func (app *Application) HandleReadyz(w http.ResponseWriter, r *http.Request) {
+	if err := app.DB.PingContext(r.Context()); err != nil {
+		writeHealth(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Error: "database unreachable"})
+		return
+	}
+	if !app.schemaInitialized() {
+		writeHealth(w, http.StatusServiceUnavailable, HealthResponse{Status: "unavailable", Error: "schema not initialized"})
+		return
+	}
+	writeHealth(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func writeHealth(w http.ResponseWriter, status int, resp HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestHandleLivez(t *testing.T) {
+	// Liveness must not depend on the database at all
+	app := &Application{}
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	rec := httptest.NewRecorder()
+	app.HandleLivez(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("HandleLivez() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var resp HealthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Status != "ok" {
+		t.Errorf("Status = %q, want ok", resp.Status)
+	}
+}
+
+func TestHandleReadyz(t *testing.T) {
+	projectRoot := findProjectRoot(t)
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(projectRoot); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer dbConn.Close()
+	// Every connection to :memory: is a separate database
+	dbConn.SetMaxOpenConns(1)
+
+	app := &Application{DB: dbConn, Q: db.New(dbConn)}
+
+	probe := func() (int, HealthResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		rec := httptest.NewRecorder()
+		app.HandleReadyz(rec, req)
+		var resp HealthResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return rec.Code, resp
+	}
+
+	code, resp := probe()
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Before ensureSchema status = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if resp.Error != "schema not initialized" {
+		t.Errorf("Before ensureSchema error = %q, want %q", resp.Error, "schema not initialized")
+	}
+
+	if err := app.ensureSchema(); err != nil {
+		t.Fatalf("ensureSchema() error = %v", err)
+	}
+
+	code, resp = probe()
+	if code != http.StatusOK || resp.Status != "ok" {
+		t.Errorf("After ensureSchema = %d %+v, want 200 ok", code, resp)
+	}
+
+	dbConn.Close()
+	if code, _ := probe(); code != http.StatusServiceUnavailable {
+		t.Errorf("With closed database status = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	"github.com/go-chi/chi/v5"
+)
+
+// TransactionChange is a single recorded edit in the history response
+type TransactionChange struct {
+	Field     string `json:"field"`
+	OldValue  string `json:"old_value"`
+	NewValue  string `json:"new_value"`
+	ChangedAt string `json:"changed_at"`
+}
+
+// recordTransactionChange appends an edit to a transaction's history. No-op
+// saves where the value did not change are skipped.
+func (app *Application) recordTransactionChange(ctx context.Context, txID int64, field string, oldValue, newValue int64) {
+	if oldValue == newValue {
+		return
+	}
+	err := app.Q.CreateTransactionHistory(ctx, db.CreateTransactionHistoryParams{
+		TransactionID: txID,
+		Field:         field,
+		OldValue:      strconv.FormatInt(oldValue, 10),
+		NewValue:      strconv.FormatInt(newValue, 10),
+	})
+	if err != nil {
+		log.Printf("Transaction history write failed (%d %s): %v", txID, field, err)
+	}
+}
+
+// HandleTransactionHistory returns the edit trail of a transaction, oldest first.
+func (app *Application) HandleTransactionHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.ListTransactionHistory(ctx, id)
+	if err != nil {
+		http.Error(w, "Failed to load transaction history", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]TransactionChange, 0, len(rows))
+	for _, row := range rows {
+		changedAt := ""
+		if row.ChangedAt.Valid {
+			changedAt = row.ChangedAt.Time.UTC().Format(time.RFC3339)
+		}
+		resp = append(resp, TransactionChange{
+			Field:     row.Field,
+			OldValue:  row.OldValue,
+			NewValue:  row.NewValue,
+			ChangedAt: changedAt,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ActivityEntry is a transaction created or removed on the requested day
+type ActivityEntry struct {
+	Action       string `json:"action"`
+	ID           int64  `json:"id"`
+	Amount       int64  `json:"amount"`
+	Description  string `json:"description"`
+	Date         string `json:"date"`
+	CategoryName string `json:"category_name"`
+	At           string `json:"at"`
+}
+
+// HandleTransactionActivity lists transactions entered or removed on
+// ?date=YYYY-MM-DD (UTC, default today), keyed on when the change happened
+// rather than the transaction date so backdated entries still show up. A
+// transaction both created and removed that day appears once per action.
+func (app *Application) HandleTransactionActivity(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	day := r.URL.Query().Get("date")
+	if day == "" {
+		day = time.Now().UTC().Format("2006-01-02")
+	} else if _, err := time.Parse("2006-01-02", day); err != nil {
+		http.Error(w, "Invalid date: use YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+
+	rows, err := app.Q.ListTransactionActivity(ctx, day)
+	if err != nil {
+		http.Error(w, "Failed to load activity", http.StatusInternalServerError)
+		return
+	}
+
+	resp := []ActivityEntry{}
+	for _, row := range rows {
+		entry := ActivityEntry{
+			ID:           row.ID,
+			Amount:       row.Amount,
+			Description:  row.Description,
+			Date:         row.Date.UTC().Format(time.RFC3339),
+			CategoryName: row.CategoryName,
+		}
+		if row.CreatedAt.Valid && row.CreatedAt.Time.UTC().Format("2006-01-02") == day {
+			entry.Action = "created"
+			entry.At = row.CreatedAt.Time.UTC().Format(time.RFC3339)
+			resp = append(resp, entry)
+		}
+		if row.DeletedAt.Valid && row.DeletedAt.Time.UTC().Format("2006-01-02") == day {
+			entry.Action = "deleted"
+			entry.At = row.DeletedAt.Time.UTC().Format(time.RFC3339)
+			resp = append(resp, entry)
+		}
+	}
+	sort.SliceStable(resp, func(i, j int) bool { return resp[i].At < resp[j].At })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
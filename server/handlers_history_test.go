@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	"github.com/go-chi/chi/v5"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// withIDParam attaches a chi {id} URL param to the request.
+func withIDParam(req *http.Request, id int64) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", strconv.FormatInt(id, 10))
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHandleTransactionUpdateAmount_History(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	tx := createTestTransaction(t, app, 1, -2500, "pizza", time.Now())
+
+	editAmount := func(amount string) *httptest.ResponseRecorder {
+		form := url.Values{"amount": {amount}}
+		req := httptest.NewRequest(http.MethodPost, "/api/transaction/1/amount", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		app.HandleTransactionUpdateAmount(rec, withIDParam(req, tx.ID))
+		return rec
+	}
+
+	for _, amount := range []string{"30", "40.50", "40.50"} {
+		if rec := editAmount(amount); rec.Code != http.StatusOK {
+			t.Fatalf("Editing amount to %s: status = %d, body = %s", amount, rec.Code, rec.Body.String())
+		}
+	}
+
+	var stored int64
+	app.DB.QueryRow("SELECT amount FROM transactions WHERE id = ?", tx.ID).Scan(&stored)
+	if stored != -4050 {
+		t.Errorf("Stored amount = %d, want -4050", stored)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transaction/1/history", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTransactionHistory(rec, withIDParam(req, tx.ID))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTransactionHistory() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var history []TransactionChange
+	if err := json.NewDecoder(rec.Body).Decode(&history); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	// The repeated 40.50 save is a no-op and must not be recorded
+	want := []TransactionChange{
+		{Field: "amount", OldValue: "-2500", NewValue: "-3000"},
+		{Field: "amount", OldValue: "-3000", NewValue: "-4050"},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("Expected %d history rows, got %d: %+v", len(want), len(history), history)
+	}
+	for i := range want {
+		got := history[i]
+		if got.Field != want[i].Field || got.OldValue != want[i].OldValue || got.NewValue != want[i].NewValue {
+			t.Errorf("history[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestHandleTransactionUpdateAmount_Invalid(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	tx := createTestTransaction(t, app, 1, -2500, "pizza", time.Now())
+
+	form := url.Values{"amount": {"lots"}}
+	req := httptest.NewRequest(http.MethodPost, "/api/transaction/1/amount", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	app.HandleTransactionUpdateAmount(rec, withIDParam(req, tx.ID))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestHandleTransactionActivity(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	ctx := context.Background()
+
+	// Entered today but dated last year
+	backdated := createTestTransaction(t, app, 1, -4200, "old receipt", time.Now().AddDate(-1, 0, 0))
+
+	// Entered yesterday, removed today
+	removed := createTestTransaction(t, app, 2, -1500, "taxi", time.Now().AddDate(0, 0, -1))
+	if _, err := app.DB.Exec("UPDATE transactions SET created_at = datetime('now', '-1 day') WHERE id = ?", removed.ID); err != nil {
+		t.Fatalf("Failed to backdate created_at: %v", err)
+	}
+	if err := app.Q.SoftDeleteTransaction(ctx, db.SoftDeleteTransactionParams{ID: removed.ID, UserID: 1}); err != nil {
+		t.Fatalf("Failed to soft delete: %v", err)
+	}
+
+	// Entered yesterday and untouched since
+	old := createTestTransaction(t, app, 3, -90000, "rent", time.Now())
+	if _, err := app.DB.Exec("UPDATE transactions SET created_at = datetime('now', '-1 day') WHERE id = ?", old.ID); err != nil {
+		t.Fatalf("Failed to backdate created_at: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/activity", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTransactionActivity(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleTransactionActivity() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var entries []ActivityEntry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	got := map[int64]string{}
+	for _, e := range entries {
+		got[e.ID] = e.Action
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 activity entries, got %d: %+v", len(entries), entries)
+	}
+	if got[backdated.ID] != "created" {
+		t.Errorf("Backdated transaction action = %q, want %q", got[backdated.ID], "created")
+	}
+	if got[removed.ID] != "deleted" {
+		t.Errorf("Removed transaction action = %q, want %q", got[removed.ID], "deleted")
+	}
+	if _, ok := got[old.ID]; ok {
+		t.Errorf("Transaction entered yesterday should not appear in today's activity")
+	}
+}
+
+func TestHandleTransactionActivity_InvalidDate(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transactions/activity?date=15-10-2026", nil)
+	rec := httptest.NewRecorder()
+	app.HandleTransactionActivity(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
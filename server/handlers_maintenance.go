@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReindexResponse reports how long each maintenance step took.
+type ReindexResponse struct {
+	AnalyzeMs int64 `json:"analyze_ms"`
+	ReindexMs int64 `json:"reindex_ms"`
+	TotalMs   int64 `json:"total_ms"`
+}
+
+// HandleMaintenanceReindex refreshes the query planner statistics and
+// rebuilds all indexes. Useful after a backup restore has replaced the
+// database contents underneath the running process.
+func (app *Application) HandleMaintenanceReindex(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	start := time.Now()
+
+	if _, err := app.DB.ExecContext(ctx, "ANALYZE"); err != nil {
+		log.Printf("Maintenance ANALYZE failed: %v", err)
+		http.Error(w, "Failed to analyze database", http.StatusInternalServerError)
+		return
+	}
+	analyzed := time.Now()
+
+	if _, err := app.DB.ExecContext(ctx, "REINDEX"); err != nil {
+		log.Printf("Maintenance REINDEX failed: %v", err)
+		http.Error(w, "Failed to rebuild indexes", http.StatusInternalServerError)
+		return
+	}
+	done := time.Now()
+
+	resp := ReindexResponse{
+		AnalyzeMs: analyzed.Sub(start).Milliseconds(),
+		ReindexMs: done.Sub(analyzed).Milliseconds(),
+		TotalMs:   done.Sub(start).Milliseconds(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SeedResponse reports what re-running the seed created.
+type SeedResponse struct {
+	UsersCreated      int64    `json:"users_created"`
+	CategoriesCreated []string `json:"categories_created"`
+}
+
+// seedMu keeps concurrent seed requests from both seeing an empty users
+// table and creating the default user twice.
+var seedMu sync.Mutex
+
+// HandleMaintenanceSeed re-runs ensureSeed without a restart, e.g. after a
+// backup restore brought in a database without the default user. Seeding
+// only fills in what is missing, so running it again creates nothing.
+func (app *Application) HandleMaintenanceSeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	seedMu.Lock()
+	defer seedMu.Unlock()
+
+	var usersBefore int64
+	if err := app.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&usersBefore); err != nil {
+		http.Error(w, "Failed to count users", http.StatusInternalServerError)
+		return
+	}
+	catsBefore, err := app.Q.ListCategories(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load categories", http.StatusInternalServerError)
+		return
+	}
+
+	if err := app.ensureSeed(); err != nil {
+		log.Printf("Maintenance seed failed: %v", err)
+		http.Error(w, "Failed to seed database", http.StatusInternalServerError)
+		return
+	}
+
+	var usersAfter int64
+	if err := app.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&usersAfter); err != nil {
+		http.Error(w, "Failed to count users", http.StatusInternalServerError)
+		return
+	}
+	catsAfter, err := app.Q.ListCategories(ctx)
+	if err != nil {
+		http.Error(w, "Failed to load categories", http.StatusInternalServerError)
+		return
+	}
+
+	existing := make(map[int64]bool, len(catsBefore))
+	for _, c := range catsBefore {
+		existing[c.ID] = true
+	}
+	resp := SeedResponse{UsersCreated: usersAfter - usersBefore, CategoriesCreated: []string{}}
+	for _, c := range catsAfter {
+		if !existing[c.ID] {
+			resp.CategoriesCreated = append(resp.CategoriesCreated, c.Name)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DuplicateGroup is a set of transactions sharing an amount and description.
+type DuplicateGroup struct {
+	Amount         int64   `json:"amount"`
+	Description    string  `json:"description"`
+	Count          int64   `json:"count"`
+	TransactionIDs []int64 `json:"transaction_ids"`
+}
+
+// HandleMaintenanceDuplicates lists groups of transactions with the same
+// amount and description, for reviewing which copies to remove. Nothing is
+// deleted here; dates are ignored so repeats split across days show up too.
+func (app *Application) HandleMaintenanceDuplicates(w http.ResponseWriter, r *http.Request) {
+	rows, err := app.Q.FindDuplicateGroups(r.Context())
+	if err != nil {
+		http.Error(w, "Failed to find duplicates", http.StatusInternalServerError)
+		return
+	}
+
+	groups := make([]DuplicateGroup, 0, len(rows))
+	for _, row := range rows {
+		ids := make([]int64, 0, row.TransactionCount)
+		for _, s := range strings.Split(row.TransactionIds, ",") {
+			id, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				http.Error(w, "Failed to read duplicate ids", http.StatusInternalServerError)
+				return
+			}
+			ids = append(ids, id)
+		}
+		groups = append(groups, DuplicateGroup{
+			Amount:         row.Amount,
+			Description:    row.Description,
+			Count:          row.TransactionCount,
+			TransactionIDs: ids,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+// ConfigResponse is the effective, non-secret configuration of the running
+// server. Anything sensitive must stay out of this struct.
+type ConfigResponse struct {
+	Port            int    `json:"port"`
+	DBPath          string `json:"db_path"`
+	PageSize        int    `json:"page_size"`
+	BackupEnabled   bool   `json:"backup_enabled"`
+	BackupInterval  int    `json:"backup_interval_minutes"`
+	DefaultCategory string `json:"default_category"`
+	SignConvention  string `json:"sign_convention"`
+	DisplayRounding string `json:"display_rounding"`
+	FiscalStart     int    `json:"fiscal_start"`
+	ImportMaxRows   int    `json:"import_max_rows"`
+}
+
+// HandleConfig returns the effective configuration so a deployment can be
+// checked without reading the startup logs.
+func (app *Application) HandleConfig(w http.ResponseWriter, r *http.Request) {
+	signConvention := "signed"
+	if app.Config.DisplayAbs {
+		signConvention = "absolute"
+	}
+	defaultCategory := ""
+	if app.CatConfig != nil {
+		defaultCategory = app.CatConfig.DefaultCategory
+	}
+
+	resp := ConfigResponse{
+		Port:            app.Config.Port,
+		DBPath:          app.Config.DBPath,
+		PageSize:        transactionsPageSize,
+		BackupEnabled:   app.Config.BackupPath != "",
+		BackupInterval:  int(app.backupInterval().Minutes()),
+		DefaultCategory: defaultCategory,
+		SignConvention:  signConvention,
+		DisplayRounding: app.Config.DisplayRounding,
+		FiscalStart:     app.fiscalStart(),
+		ImportMaxRows:   app.importMaxRows(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// VersionResponse identifies the running build
+type VersionResponse struct {
+	Version   string `json:"version"`
+	GoVersion string `json:"go_version"`
+	BuiltAt   string `json:"built_at"`
+}
+
+// HandleVersion returns the build information, to confirm a deploy updated.
+func (app *Application) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	resp := VersionResponse{
+		Version:   Version,
+		GoVersion: runtime.Version(),
+		BuiltAt:   BuildTime,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestHandleMaintenanceReindex(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -1200, "lunch", time.Now())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/maintenance/reindex", nil)
+	rec := httptest.NewRecorder()
+	app.HandleMaintenanceReindex(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleMaintenanceReindex() status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp ReindexResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.TotalMs < resp.AnalyzeMs || resp.TotalMs < resp.ReindexMs {
+		t.Errorf("TotalMs = %d, want at least AnalyzeMs (%d) and ReindexMs (%d)", resp.TotalMs, resp.AnalyzeMs, resp.ReindexMs)
+	}
+
+	// Queries must keep working against the rebuilt indexes
+	count, err := app.Q.CountAllTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("CountAllTransactions() after reindex error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountAllTransactions() after reindex = %d, want 1", count)
+	}
+}
+
+func TestHandleMaintenanceSeed(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	// A restored database without the default user
+	if _, err := app.DB.Exec("DELETE FROM users"); err != nil {
+		t.Fatalf("Failed to delete users: %v", err)
+	}
+
+	seed := func() SeedResponse {
+		req := httptest.NewRequest(http.MethodPost, "/api/maintenance/seed", nil)
+		rec := httptest.NewRecorder()
+		app.HandleMaintenanceSeed(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleMaintenanceSeed() status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var resp SeedResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	if resp := seed(); resp.UsersCreated != 1 {
+		t.Errorf("UsersCreated = %d, want 1", resp.UsersCreated)
+	}
+	var name string
+	if err := app.DB.QueryRow("SELECT name FROM users").Scan(&name); err != nil {
+		t.Fatalf("Default user was not created: %v", err)
+	}
+	if name != defaultSeedName {
+		t.Errorf("Seeded user name = %q, want %q", name, defaultSeedName)
+	}
+
+	if resp := seed(); resp.UsersCreated != 0 || len(resp.CategoriesCreated) != 0 {
+		t.Errorf("Second seed = %+v, want nothing created", resp)
+	}
+}
+
+func TestHandleMaintenanceDuplicates(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	first := createTestTransaction(t, app, 1, -1200, "lunch", time.Now())
+	second := createTestTransaction(t, app, 1, -1200, "lunch", time.Now().AddDate(0, 0, -1))
+	createTestTransaction(t, app, 1, -1300, "lunch", time.Now())
+	createTestTransaction(t, app, 1, -1200, "dinner", time.Now())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/maintenance/duplicates", nil)
+	rec := httptest.NewRecorder()
+	app.HandleMaintenanceDuplicates(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleMaintenanceDuplicates() status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var groups []DuplicateGroup
+	if err := json.NewDecoder(rec.Body).Decode(&groups); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("HandleMaintenanceDuplicates() = %+v, want 1 group", groups)
+	}
+	g := groups[0]
+	if g.Amount != -1200 || g.Description != "lunch" || g.Count != 2 {
+		t.Errorf("group = %+v, want 2 x -1200 lunch", g)
+	}
+	if len(g.TransactionIDs) != 2 || g.TransactionIDs[0] != first.ID || g.TransactionIDs[1] != second.ID {
+		t.Errorf("TransactionIDs = %v, want [%d %d]", g.TransactionIDs, first.ID, second.ID)
+	}
+}
+
+func TestHandleConfig(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.Port = 9090
+	app.Config.BackupPath = "/var/backups/cheapskate"
+	app.Config.BackupInterval = 15
+	app.Config.DisplayAbs = true
+
+	req := httptest.NewRequest(http.MethodGet, "/api/config", nil)
+	rec := httptest.NewRecorder()
+	app.HandleConfig(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleConfig() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var raw map[string]any
+	if err := json.NewDecoder(rec.Body).Decode(&raw); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if raw["page_size"] != float64(transactionsPageSize) {
+		t.Errorf("page_size = %v, want %d", raw["page_size"], transactionsPageSize)
+	}
+	if raw["port"] != float64(9090) {
+		t.Errorf("port = %v, want 9090", raw["port"])
+	}
+	if raw["backup_enabled"] != true || raw["backup_interval_minutes"] != float64(15) {
+		t.Errorf("backup = %v every %v, want enabled every 15", raw["backup_enabled"], raw["backup_interval_minutes"])
+	}
+	if raw["sign_convention"] != "absolute" {
+		t.Errorf("sign_convention = %v, want absolute", raw["sign_convention"])
+	}
+
+	for key := range raw {
+		for _, secret := range []string{"password", "secret", "token", "api_key"} {
+			if strings.Contains(key, secret) {
+				t.Errorf("Response exposes secret-looking field %q", key)
+			}
+		}
+	}
+}
+
+func TestHandleVersion(t *testing.T) {
+	app := &Application{}
+
+	original := Version
+	Version = "1.2.3-test"
+	defer func() { Version = original }()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/version", nil)
+	rec := httptest.NewRecorder()
+	app.HandleVersion(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleVersion() status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var resp VersionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Version != "1.2.3-test" {
+		t.Errorf("Version = %q, want %q", resp.Version, "1.2.3-test")
+	}
+	if !strings.HasPrefix(resp.GoVersion, "go") {
+		t.Errorf("GoVersion = %q, want a go version string", resp.GoVersion)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	"github.com/go-chi/chi/v5"
+)
+
+// maxReceiptSize caps receipt uploads at 10MB
+const maxReceiptSize = 10 << 20
+
+// ReceiptUploadResponse is the response for a successful receipt upload
+type ReceiptUploadResponse struct {
+	ReceiptPath string `json:"receipt_path"`
+}
+
+// HandleReceiptUpload stores an image receipt for a transaction under the
+// uploads directory and records its file name on the transaction.
+func (app *Application) HandleReceiptUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxReceiptSize)
+
+	file, _, err := r.FormFile("receipt")
+	if err != nil {
+		http.Error(w, "No receipt file provided", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// Sniff the content rather than trusting the client's Content-Type
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		http.Error(w, "Failed to read receipt", http.StatusBadRequest)
+		return
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !strings.HasPrefix(contentType, "image/") {
+		http.Error(w, "Receipt must be an image", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	userID := int64(1)
+
+	tx, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: userID})
+	if err == sql.ErrNoRows || (err == nil && tx.DeletedAt.Valid) {
+		http.Error(w, "Transaction not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := os.MkdirAll(app.Config.UploadsDir, 0755); err != nil {
+		http.Error(w, "Failed to prepare uploads directory", http.StatusInternalServerError)
+		return
+	}
+
+	ext := ""
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		ext = exts[0]
+	}
+	name := fmt.Sprintf("receipt-%d-%d%s", id, time.Now().UnixNano(), ext)
+	destPath := filepath.Join(app.Config.UploadsDir, name)
+
+	dest, err := os.Create(destPath)
+	if err != nil {
+		http.Error(w, "Failed to save receipt", http.StatusInternalServerError)
+		return
+	}
+	_, err = dest.Write(head[:n])
+	if err == nil {
+		_, err = io.Copy(dest, file)
+	}
+	dest.Close()
+	if err != nil {
+		os.Remove(destPath)
+		http.Error(w, "Failed to save receipt: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err = app.Q.SetTransactionReceipt(ctx, db.SetTransactionReceiptParams{
+		ReceiptPath: sql.NullString{String: name, Valid: true},
+		ID:          id,
+		UserID:      userID,
+	})
+	if err != nil {
+		os.Remove(destPath)
+		http.Error(w, "Failed to record receipt: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Replace any previous receipt
+	if tx.ReceiptPath.String != name {
+		app.removeReceipt(tx.ReceiptPath.String)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ReceiptUploadResponse{ReceiptPath: name})
+}
+
+// removeReceipt deletes a receipt file from the uploads directory. An empty
+// name is a no-op and failures are only logged, since the database no longer
+// refers to the file.
+func (app *Application) removeReceipt(name string) {
+	if name == "" {
+		return
+	}
+	if err := os.Remove(filepath.Join(app.Config.UploadsDir, name)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to remove receipt %s: %v", name, err)
+	}
+}
+
+// HandleReceiptDownload serves the receipt attached to a transaction.
+func (app *Application) HandleReceiptDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid transaction ID", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := app.Q.GetTransactionByID(ctx, db.GetTransactionByIDParams{ID: id, UserID: int64(1)})
+	if err == sql.ErrNoRows || (err == nil && !tx.ReceiptPath.Valid) {
+		http.Error(w, "Receipt not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to load transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.ServeFile(w, r, filepath.Join(app.Config.UploadsDir, filepath.Base(tx.ReceiptPath.String)))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// pngBytes is enough of a PNG file for content sniffing
+var pngBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01")
+
+// newReceiptRequest builds a multipart upload of content as the receipt field.
+func newReceiptRequest(t *testing.T, txID int64, filename string, content []byte) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	part, err := mw.CreateFormFile("receipt", filename)
+	if err != nil {
+		t.Fatalf("Failed to create form file: %v", err)
+	}
+	part.Write(content)
+	mw.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/transaction/1/receipt", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return withIDParam(req, txID)
+}
+
+func TestHandleReceiptUpload(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.UploadsDir = t.TempDir()
+
+	tx := createTestTransaction(t, app, 1, -2500, "groceries", time.Now())
+
+	t.Run("upload and retrieve image", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		app.HandleReceiptUpload(rec, newReceiptRequest(t, tx.ID, "receipt.png", pngBytes))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleReceiptUpload() status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		var resp ReceiptUploadResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if !strings.HasSuffix(resp.ReceiptPath, ".png") {
+			t.Errorf("ReceiptPath = %q, want a .png file name", resp.ReceiptPath)
+		}
+		if _, err := os.Stat(filepath.Join(app.Config.UploadsDir, resp.ReceiptPath)); err != nil {
+			t.Errorf("Receipt file not stored: %v", err)
+		}
+
+		var stored string
+		app.DB.QueryRow("SELECT receipt_path FROM transactions WHERE id = ?", tx.ID).Scan(&stored)
+		if stored != resp.ReceiptPath {
+			t.Errorf("Stored receipt_path = %q, want %q", stored, resp.ReceiptPath)
+		}
+
+		req := withIDParam(httptest.NewRequest(http.MethodGet, "/api/transaction/1/receipt", nil), tx.ID)
+		rec = httptest.NewRecorder()
+		app.HandleReceiptDownload(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleReceiptDownload() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if !bytes.Equal(rec.Body.Bytes(), pngBytes) {
+			t.Error("Downloaded receipt does not match the upload")
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("Content-Type = %q, want image/png", ct)
+		}
+	})
+
+	t.Run("reject non-image", func(t *testing.T) {
+		other := createTestTransaction(t, app, 1, -1000, "snack", time.Now())
+
+		rec := httptest.NewRecorder()
+		app.HandleReceiptUpload(rec, newReceiptRequest(t, other.ID, "receipt.png", []byte("just some text, not an image")))
+
+		if rec.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("HandleReceiptUpload() status = %d, want %d", rec.Code, http.StatusUnsupportedMediaType)
+		}
+		var stored *string
+		app.DB.QueryRow("SELECT receipt_path FROM transactions WHERE id = ?", other.ID).Scan(&stored)
+		if stored != nil {
+			t.Errorf("Rejected upload should not record a receipt, got %q", *stored)
+		}
+	})
+
+	t.Run("missing receipt returns 404", func(t *testing.T) {
+		other := createTestTransaction(t, app, 1, -1000, "no receipt", time.Now())
+
+		req := withIDParam(httptest.NewRequest(http.MethodGet, "/api/transaction/1/receipt", nil), other.ID)
+		rec := httptest.NewRecorder()
+		app.HandleReceiptDownload(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("HandleReceiptDownload() status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
@@ -1,15 +1,32 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
 )
 
+// defaultImportMaxRows caps how many transactions a single storage import
+// may contain when -import-max-rows is not set.
+const defaultImportMaxRows = 100000
+
+// defaultDateFormats are the layouts tried when importing dates, as given to
+// -date-formats: full RFC 3339 timestamps and bare dates.
+const defaultDateFormats = time.RFC3339 + ";" + time.DateOnly
+
 // StorageTransaction represents a transaction in the storage JSON format
 type StorageTransaction struct {
 	ID           int64  `json:"id"`
@@ -20,6 +37,8 @@ type StorageTransaction struct {
 	CategoryName string `json:"category_name"`
 	CategoryType string `json:"category_type"`
 	CreatedAt    string `json:"created_at"`
+	ReceiptPath  string `json:"receipt_path,omitempty"`
+	UID          string `json:"uid,omitempty"`
 }
 
 // StorageCategory represents a category in the storage JSON format
@@ -77,8 +96,25 @@ func (app *Application) HandleStorageStatus(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(resp)
 }
 
+// asciiIconPlaceholder replaces non-ASCII category icons in an ?ascii=true
+// export.
+const asciiIconPlaceholder = "*"
+
+// asciiIcon returns icon unchanged if it is plain ASCII, otherwise the
+// placeholder. Emoji icons trip up spreadsheet tools with encoding issues.
+func asciiIcon(icon string) string {
+	for i := 0; i < len(icon); i++ {
+		if icon[i] >= utf8.RuneSelf {
+			return asciiIconPlaceholder
+		}
+	}
+	return icon
+}
+
 // HandleStorageExport returns all transactions and categories for a given year
-// as JSON, for the client to store in IndexedDB.
+// as JSON, for the client to store in IndexedDB. The JSON is compact unless
+// ?pretty=true asks for it indented, e.g. for a readable download, and
+// ?ascii=true replaces emoji category icons with an ASCII placeholder.
 func (app *Application) HandleStorageExport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -109,6 +145,8 @@ func (app *Application) HandleStorageExport(w http.ResponseWriter, r *http.Reque
 			CategoryName: tx.CategoryName,
 			CategoryType: tx.CategoryType,
 			CreatedAt:    createdAt,
+			ReceiptPath:  tx.ReceiptPath.String,
+			UID:          tx.Uid.String,
 		})
 	}
 
@@ -119,12 +157,16 @@ func (app *Application) HandleStorageExport(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	ascii := r.URL.Query().Get("ascii") == "true"
 	categories := make([]StorageCategory, 0, len(catRows))
 	for _, cat := range catRows {
 		icon := ""
 		if cat.Icon.Valid {
 			icon = cat.Icon.String
 		}
+		if ascii {
+			icon = asciiIcon(icon)
+		}
 		color := ""
 		if cat.Color.Valid {
 			color = cat.Color.String
@@ -146,85 +188,385 @@ func (app *Application) HandleStorageExport(w http.ResponseWriter, r *http.Reque
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	enc := json.NewEncoder(w)
+	if r.URL.Query().Get("pretty") == "true" {
+		enc.SetIndent("", "  ")
+	}
+	enc.Encode(resp)
+}
+
+// importMaxRows returns the configured storage import row cap, falling back
+// to defaultImportMaxRows when unset.
+func (app *Application) importMaxRows() int {
+	if app.Config.ImportMaxRows < 1 {
+		return defaultImportMaxRows
+	}
+	return app.Config.ImportMaxRows
+}
+
+// importWorkers returns how many goroutines a storage import uses, falling
+// back to a single worker when unset.
+func (app *Application) importWorkers() int {
+	if app.Config.ImportWorkers < 1 {
+		return 1
+	}
+	return app.Config.ImportWorkers
+}
+
+// importDateLayouts returns the configured import date layouts in the order
+// they should be tried. RFC 3339 and bare dates are always tried last, even
+// when -date-formats leaves them out.
+func (app *Application) importDateLayouts() []string {
+	var layouts []string
+	seen := make(map[string]bool)
+	for _, layout := range strings.Split(app.Config.DateFormats+";"+defaultDateFormats, ";") {
+		if layout = strings.TrimSpace(layout); layout != "" && !seen[layout] {
+			seen[layout] = true
+			layouts = append(layouts, layout)
+		}
+	}
+	return layouts
+}
+
+// parseImportDate parses s with the first layout that accepts it. Layouts
+// without a zone are read as UTC.
+func parseImportDate(s string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date %q matches none of the layouts %q", s, layouts)
 }
 
 // HandleStorageImport accepts transactions from IndexedDB and imports them
 // into the SQLite database. Used to reconstruct data after DB deletion.
 func (app *Application) HandleStorageImport(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	var req StorageImportRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	// Check if DB already has transactions - avoid duplicate imports
+	app.runStorageImport(r.Context(), w, req)
+}
+
+// runStorageImport imports req and writes the resulting counts, the part of
+// a storage import shared by every way the payload can arrive.
+func (app *Application) runStorageImport(ctx context.Context, w http.ResponseWriter, req StorageImportRequest) {
+	// Reject oversized payloads before touching the database
+	if maxRows := app.importMaxRows(); len(req.Transactions) > maxRows {
+		http.Error(w, fmt.Sprintf("Import has %d transactions, more than the limit of %d", len(req.Transactions), maxRows), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// If the DB already has transactions this is a merge: only transactions
+	// with a uid the DB has not seen are imported, everything else is skipped
 	count, err := app.Q.CountAllTransactions(ctx)
 	if err != nil {
 		http.Error(w, "Failed to check transaction count", http.StatusInternalServerError)
 		return
 	}
-	if count > 0 {
-		resp := StorageImportResponse{Imported: 0, Skipped: len(req.Transactions), Errors: 0}
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
-		return
+	merging := count > 0
+
+	layouts := app.importDateLayouts()
+	workers := min(app.importWorkers(), max(len(req.Transactions), 1))
+
+	// Each worker keeps its own tally so no counter is shared, and the uid
+	// claims make sure duplicate uids within one payload are imported once.
+	claims := &importClaims{claimed: map[string]bool{}}
+	counts := make([]importCounts, workers)
+	jobs := make(chan StorageTransaction)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func(c *importCounts) {
+			defer wg.Done()
+			for storageTx := range jobs {
+				switch app.importStorageTransaction(ctx, claims, storageTx, merging, layouts) {
+				case importResultImported:
+					c.imported++
+				case importResultError:
+					c.errors++
+				}
+			}
+		}(&counts[i])
+	}
+	for _, storageTx := range req.Transactions {
+		jobs <- storageTx
 	}
+	close(jobs)
+	wg.Wait()
 
-	userID := int64(1)
 	imported := 0
-	skipped := 0
 	errors := 0
+	for _, c := range counts {
+		imported += c.imported
+		errors += c.errors
+	}
+	skipped := len(req.Transactions) - imported - errors
 
-	for _, storageTx := range req.Transactions {
-		// Resolve category by name
-		cat, err := app.Q.GetCategoryByName(ctx, storageTx.CategoryName)
+	resp := StorageImportResponse{
+		Imported: imported,
+		Skipped:  skipped,
+		Errors:   errors,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type importResult int
+
+const (
+	importResultImported importResult = iota
+	importResultSkipped
+	importResultError
+)
+
+// importCounts is one import worker's tally.
+type importCounts struct {
+	imported int
+	errors   int
+}
+
+// importClaims hands each transaction of an import to at most one worker,
+// keyed by uid or, for rows without one, by amount, description and date.
+type importClaims struct {
+	mu      sync.Mutex
+	claimed map[string]bool
+}
+
+// claim reports whether key is new to both the database, according to
+// count, and this import, and if so reserves it for the caller. Only this
+// check is serialized; the insert that follows runs without the lock.
+func (c *importClaims) claim(key string, count func() (int64, error)) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.claimed[key] {
+		return false, nil
+	}
+	seen, err := count()
+	if err != nil || seen > 0 {
+		return false, err
+	}
+	c.claimed[key] = true
+	return true, nil
+}
+
+// release gives up a claimed key whose row failed to import, so a later
+// duplicate may still land.
+func (c *importClaims) release(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.claimed, key)
+}
+
+// importStorageTransaction imports a single storage transaction. Rows with
+// a uid are deduped by it; when merging, rows without one (exports made
+// before uids existed) fall back to matching amount, description and date,
+// and get a fresh uid when imported.
+func (app *Application) importStorageTransaction(ctx context.Context, claims *importClaims, storageTx StorageTransaction, merging bool, layouts []string) (result importResult) {
+	userID := int64(1)
+	description := truncateDescription(storageTx.Description, app.Config.MaxDescription)
+
+	uid := sql.NullString{String: storageTx.UID, Valid: storageTx.UID != ""}
+	var key string
+	var count func() (int64, error)
+	switch {
+	case uid.Valid:
+		key = "uid:" + storageTx.UID
+		count = func() (int64, error) { return app.Q.CountTransactionsByUID(ctx, uid) }
+	case merging:
+		txDate, err := parseImportDate(storageTx.Date, layouts)
 		if err != nil {
-			// Try to find a fallback category
-			cats, catErr := app.Q.ListCategories(ctx)
-			if catErr != nil || len(cats) == 0 {
-				log.Printf("Storage import: could not resolve category %q: %v", storageTx.CategoryName, err)
-				errors++
-				continue
+			log.Printf("Storage import: %v", err)
+			return importResultError
+		}
+		params := db.CountTransactionsByContentParams{Amount: storageTx.Amount, Description: description, Date: txDate}
+		key = fmt.Sprintf("content:%d|%s|%s", params.Amount, params.Description, txDate.UTC().Format(time.RFC3339Nano))
+		count = func() (int64, error) { return app.Q.CountTransactionsByContent(ctx, params) }
+	}
+	if key != "" {
+		claimed, err := claims.claim(key, count)
+		if err != nil {
+			log.Printf("Storage import: could not check for duplicates of %q: %v", storageTx.Description, err)
+			return importResultError
+		}
+		if !claimed {
+			return importResultSkipped
+		}
+		defer func() {
+			if result == importResultError {
+				claims.release(key)
 			}
-			cat = cats[0]
+		}()
+	}
+
+	// Resolve category by name
+	cat, err := app.Q.GetCategoryByName(ctx, storageTx.CategoryName)
+	if err != nil {
+		// Try to find a fallback category
+		cats, catErr := app.Q.ListCategories(ctx)
+		if catErr != nil || len(cats) == 0 {
+			log.Printf("Storage import: could not resolve category %q: %v", storageTx.CategoryName, err)
+			return importResultError
 		}
+		cat = cats[0]
+	}
 
-		// Parse date
-		txDate, err := time.Parse(time.RFC3339, storageTx.Date)
-		if err != nil {
-			log.Printf("Storage import: could not parse date %q: %v", storageTx.Date, err)
-			errors++
-			continue
+	txDate, err := parseImportDate(storageTx.Date, layouts)
+	if err != nil {
+		log.Printf("Storage import: %v", err)
+		return importResultError
+	}
+	if app.Config.RequireDescription && strings.TrimSpace(storageTx.Description) == "" {
+		log.Printf("Storage import: transaction %q has no description", storageTx.UID)
+		return importResultError
+	}
+
+	_, err = app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
+		UserID:      userID,
+		CategoryID:  cat.ID,
+		Amount:      storageTx.Amount,
+		Currency:    storageTx.Currency,
+		Description: description,
+		Date:        txDate,
+		Uid:         uid,
+	})
+	if err != nil {
+		log.Printf("Storage import: failed to create transaction: %v", err)
+		return importResultError
+	}
+	return importResultImported
+}
+
+// Limits on fetching an export for /api/storage/import-url.
+const (
+	importURLTimeout  = 30 * time.Second
+	importURLMaxBytes = 32 << 20
+)
+
+// ImportURLRequest names an export to fetch and import
+type ImportURLRequest struct {
+	URL string `json:"url"`
+}
+
+// importURLAllowed reports whether an export may be fetched from u: only
+// http and https, and only the -import-url-hosts hosts when any are set.
+func (app *Application) importURLAllowed(u *url.URL) bool {
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	return app.Config.ImportURLHosts == "" || app.importHostListed(u.Hostname())
+}
+
+// importHostListed reports whether host is named in -import-url-hosts.
+func (app *Application) importHostListed(host string) bool {
+	for _, listed := range strings.Split(app.Config.ImportURLHosts, ",") {
+		if listed = strings.TrimSpace(listed); listed != "" && strings.EqualFold(listed, host) {
+			return true
 		}
+	}
+	return false
+}
 
-		_, err = app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
-			UserID:      userID,
-			CategoryID:  cat.ID,
-			Amount:      storageTx.Amount,
-			Currency:    storageTx.Currency,
-			Description: storageTx.Description,
-			Date:        txDate,
-		})
+// importDialer connects only to public addresses. The check runs on the
+// resolved address, so a public name pointing at loopback, a private
+// network or the link-local metadata service is refused too.
+var importDialer = &net.Dialer{
+	Timeout: importURLTimeout,
+	Control: func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
 		if err != nil {
-			log.Printf("Storage import: failed to create transaction: %v", err)
-			errors++
-			continue
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+			return fmt.Errorf("connection to %s is not allowed", host)
 		}
+		return nil
+	},
+}
+
+// publicIP reports whether ip is routable on the internet as far as the
+// standard library can tell.
+func publicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsMulticast()
+}
 
-		imported++
+// importDial dials an export host, holding it to importDialer unless it is
+// named in -import-url-hosts.
+func (app *Application) importDial(ctx context.Context, network, addr string) (net.Conn, error) {
+	if host, _, err := net.SplitHostPort(addr); err == nil && app.importHostListed(host) {
+		return (&net.Dialer{Timeout: importURLTimeout}).DialContext(ctx, network, addr)
 	}
+	return importDialer.DialContext(ctx, network, addr)
+}
 
-	skipped = len(req.Transactions) - imported - errors
+// HandleStorageImportURL fetches a storage export from a URL and imports it
+// exactly like HandleStorageImport, so automation can pull backups from
+// another instance. Redirects are checked against the same rules, and only
+// allowlisted hosts may resolve to a non-public address.
+func (app *Application) HandleStorageImportURL(w http.ResponseWriter, r *http.Request) {
+	var req ImportURLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
 
-	resp := StorageImportResponse{
-		Imported: imported,
-		Skipped:  skipped,
-		Errors:   errors,
+	u, err := url.Parse(req.URL)
+	if err != nil || !app.importURLAllowed(u) {
+		http.Error(w, "URL must be http or https on an allowed host", http.StatusBadRequest)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
+	client := &http.Client{
+		Timeout:   importURLTimeout,
+		Transport: &http.Transport{DialContext: app.importDial},
+		CheckRedirect: func(next *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			if !app.importURLAllowed(next.URL) {
+				return fmt.Errorf("redirect to %s is not allowed", next.URL.Redacted())
+			}
+			return nil
+		},
+	}
+	fetchReq, err := http.NewRequestWithContext(r.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		http.Error(w, "Invalid URL", http.StatusBadRequest)
+		return
+	}
+	resp, err := client.Do(fetchReq)
+	if err != nil {
+		log.Printf("Storage import: fetching %s failed: %v", u.Redacted(), err)
+		http.Error(w, "Failed to fetch export", http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		log.Printf("Storage import: fetching %s returned %s", u.Redacted(), resp.Status)
+		http.Error(w, "Failed to fetch export", http.StatusBadGateway)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, importURLMaxBytes+1))
+	if err != nil {
+		http.Error(w, "Failed to read export", http.StatusBadGateway)
+		return
+	}
+	if len(body) > importURLMaxBytes {
+		http.Error(w, fmt.Sprintf("Export is larger than %d bytes", importURLMaxBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var export StorageImportRequest
+	if err := json.Unmarshal(body, &export); err != nil {
+		http.Error(w, "Export is not valid JSON", http.StatusBadGateway)
+		return
+	}
+
+	app.runStorageImport(r.Context(), w, export)
 }
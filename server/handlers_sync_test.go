@@ -5,8 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -292,19 +294,19 @@ func TestHandleStorageImport(t *testing.T) {
 		}
 	})
 
-	t.Run("skips import when database already has transactions", func(t *testing.T) {
+	t.Run("skips transactions the database already has", func(t *testing.T) {
 		app := setupTestApp(t)
 		defer cleanupTestApp(t, app)
 
-		// Pre-populate with a transaction
+		// Pre-populate with the transaction the import repeats
 		ctx := context.Background()
 		_, err := app.Q.CreateTransaction(ctx, db.CreateTransactionParams{
 			UserID:      1,
 			CategoryID:  1,
-			Amount:      -1000,
+			Amount:      -5000,
 			Currency:    "USD",
-			Description: "Existing transaction",
-			Date:        time.Now(),
+			Description: "Should be skipped",
+			Date:        time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC),
 		})
 		if err != nil {
 			t.Fatalf("Failed to create existing transaction: %v", err)
@@ -769,6 +771,71 @@ func TestHandleStorageExport_ContentType(t *testing.T) {
 	}
 }
 
+func TestHandleStorageExport_Pretty(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -2500, "pizza", time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC))
+
+	export := func(query string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/storage/export?year=2026"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleStorageExport(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleStorageExport() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		return rec.Body.String()
+	}
+
+	compact := export("")
+	if strings.Contains(strings.TrimSuffix(compact, "\n"), "\n") {
+		t.Errorf("Default export should be compact, got:\n%s", compact)
+	}
+
+	pretty := export("&pretty=true")
+	if !strings.Contains(pretty, "\n  \"transactions\": [") {
+		t.Errorf("Pretty export should be indented, got:\n%s", pretty)
+	}
+	var resp StorageExportResponse
+	if err := json.Unmarshal([]byte(pretty), &resp); err != nil || len(resp.Transactions) != 1 {
+		t.Errorf("Pretty export should decode to the same data: err=%v, transactions=%d", err, len(resp.Transactions))
+	}
+}
+
+func TestHandleStorageExport_ASCII(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	if _, err := app.DB.Exec(`INSERT INTO categories (name, type, icon, color) VALUES ('Misc', 'expense', 'M', '#95A5A6')`); err != nil {
+		t.Fatalf("Failed to create category: %v", err)
+	}
+
+	icons := func(query string) map[string]string {
+		req := httptest.NewRequest(http.MethodGet, "/api/storage/export?year=2026"+query, nil)
+		rec := httptest.NewRecorder()
+		app.HandleStorageExport(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleStorageExport() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var resp StorageExportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		got := map[string]string{}
+		for _, c := range resp.Categories {
+			got[c.Name] = c.Icon
+		}
+		return got
+	}
+
+	if got := icons(""); got["Food"] != "🍔" || got["Misc"] != "M" {
+		t.Errorf("Default export icons = %v, want emoji preserved", got)
+	}
+	if got := icons("&ascii=true"); got["Food"] != asciiIconPlaceholder || got["Misc"] != "M" {
+		t.Errorf("ASCII export icons = %v, want emoji replaced and ASCII kept", got)
+	}
+}
+
 func TestHandleStorageImport_MultipleCategories(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -912,6 +979,428 @@ func TestHandleStorageImport_LargeImport(t *testing.T) {
 	}
 }
 
+func TestHandleStorageImport_MaxRows(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.ImportMaxRows = 3
+
+	transactions := make([]StorageTransaction, 4)
+	for i := range transactions {
+		transactions[i] = StorageTransaction{
+			ID:           int64(i + 1),
+			Amount:       -100,
+			Currency:     "USD",
+			Description:  fmt.Sprintf("Over cap item %d", i+1),
+			Date:         "2026-01-10T10:00:00Z",
+			CategoryName: "Food",
+			CategoryType: "expense",
+		}
+	}
+
+	body, _ := json.Marshal(StorageImportRequest{Transactions: transactions})
+	req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	app.HandleStorageImport(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+	if !strings.Contains(rec.Body.String(), "limit of 3") {
+		t.Errorf("Expected body to mention the limit, got %q", rec.Body.String())
+	}
+
+	count, err := app.Q.CountAllTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to count: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Transaction count = %d, want 0", count)
+	}
+}
+
+func TestHandleStorageImport_Workers(t *testing.T) {
+	// Run with -race: several workers import concurrently and the summary
+	// must still add up
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.DB.SetMaxOpenConns(1)
+	app.Config.ImportWorkers = 8
+
+	const total = 600
+	transactions := make([]StorageTransaction, total)
+	wantErrors, wantSkipped := 0, 0
+	for i := range transactions {
+		date := "2026-01-10T10:00:00Z"
+		uid := fmt.Sprintf("worker-uid-%d", i)
+		switch {
+		case i%50 == 0:
+			date = "not-a-date"
+			wantErrors++
+		case i%40 == 2:
+			// Repeats the previous row's uid, so only one of them lands
+			uid = fmt.Sprintf("worker-uid-%d", i-1)
+			wantSkipped++
+		}
+		transactions[i] = StorageTransaction{
+			ID:           int64(i + 1),
+			Amount:       -100,
+			Currency:     "USD",
+			Description:  fmt.Sprintf("Worker item %d", i),
+			Date:         date,
+			CategoryName: "Food",
+			CategoryType: "expense",
+			UID:          uid,
+		}
+	}
+	wantImported := total - wantErrors - wantSkipped
+
+	body, _ := json.Marshal(StorageImportRequest{Transactions: transactions})
+	req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	app.HandleStorageImport(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	var resp StorageImportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Imported != wantImported || resp.Skipped != wantSkipped || resp.Errors != wantErrors {
+		t.Errorf("Summary = %+v, want imported=%d skipped=%d errors=%d", resp, wantImported, wantSkipped, wantErrors)
+	}
+
+	count, err := app.Q.CountAllTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to count: %v", err)
+	}
+	if count != int64(wantImported) {
+		t.Errorf("Transaction count = %d, want %d", count, wantImported)
+	}
+}
+
+func TestHandleStorageImport_FailedRowReleasesUID(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	// The first copy fails, so the second copy of the uid must still land
+	transactions := []StorageTransaction{
+		{ID: 1, Amount: -100, Currency: "USD", Description: "Broken", Date: "not-a-date", CategoryName: "Food", CategoryType: "expense", UID: "retry-uid"},
+		{ID: 2, Amount: -100, Currency: "USD", Description: "Fixed", Date: "2026-01-10T10:00:00Z", CategoryName: "Food", CategoryType: "expense", UID: "retry-uid"},
+	}
+	body, _ := json.Marshal(StorageImportRequest{Transactions: transactions})
+	req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	app.HandleStorageImport(rec, req)
+
+	var resp StorageImportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Imported != 1 || resp.Skipped != 0 || resp.Errors != 1 {
+		t.Errorf("Summary = %+v, want imported=1 skipped=0 errors=1", resp)
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestHandleStorage_UIDRoundTrip(t *testing.T) {
+	export := func(app *Application) StorageExportResponse {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/storage/export?year=2026", nil)
+		rec := httptest.NewRecorder()
+		app.HandleStorageExport(rec, req)
+		var resp StorageExportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode export: %v", err)
+		}
+		return resp
+	}
+	importTxs := func(app *Application, txs []StorageTransaction) StorageImportResponse {
+		t.Helper()
+		body, _ := json.Marshal(StorageImportRequest{Transactions: txs})
+		req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		app.HandleStorageImport(rec, req)
+		var resp StorageImportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode import: %v", err)
+		}
+		return resp
+	}
+
+	src := setupTestApp(t)
+	defer cleanupTestApp(t, src)
+	createTestTransaction(t, src, 1, -1250, "pizza", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, src, 2, -300, "bus", time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC))
+
+	exported := export(src).Transactions
+	if len(exported) != 2 {
+		t.Fatalf("Exported %d transactions, want 2", len(exported))
+	}
+	uids := make(map[string]bool)
+	for _, tx := range exported {
+		if tx.UID == "" {
+			t.Fatalf("Exported transaction %d has no uid", tx.ID)
+		}
+		uids[tx.UID] = true
+	}
+	if len(uids) != 2 {
+		t.Fatalf("Expected distinct uids, got %v", uids)
+	}
+
+	dst := setupTestApp(t)
+	defer cleanupTestApp(t, dst)
+	if resp := importTxs(dst, exported); resp.Imported != 2 {
+		t.Fatalf("Imported = %d, want 2", resp.Imported)
+	}
+	for _, tx := range export(dst).Transactions {
+		if !uids[tx.UID] {
+			t.Errorf("Re-exported uid %q was not preserved", tx.UID)
+		}
+	}
+
+	t.Run("merge import dedupes by uid", func(t *testing.T) {
+		extra := StorageTransaction{
+			Amount:       -999,
+			Currency:     "USD",
+			Description:  "from another device",
+			Date:         "2026-02-03T12:00:00Z",
+			CategoryName: "Food",
+			CategoryType: "expense",
+			UID:          "4b6e2c1a-0d3f-4e5a-9b7c-1f2e3d4c5b6a",
+		}
+		resp := importTxs(dst, append(exported, extra))
+		if resp.Imported != 1 || resp.Skipped != 2 {
+			t.Errorf("Merge import = %+v, want 1 imported and 2 skipped", resp)
+		}
+		count, err := dst.Q.CountAllTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to count: %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Transaction count = %d, want 3", count)
+		}
+	})
+
+	t.Run("backup JSON export merges back", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/backup/json", nil)
+		rec := httptest.NewRecorder()
+		src.HandleBackupJSON(rec, req)
+		var backup StorageExportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&backup); err != nil {
+			t.Fatalf("Failed to decode backup export: %v", err)
+		}
+		for _, tx := range backup.Transactions {
+			if !uids[tx.UID] {
+				t.Errorf("Backup export uid %q, want one of %v", tx.UID, uids)
+			}
+		}
+
+		// dst already holds both rows, so merging the backup adds nothing
+		if resp := importTxs(dst, backup.Transactions); resp.Imported != 0 || resp.Skipped != 2 {
+			t.Errorf("Merge import of backup = %+v, want 0 imported and 2 skipped", resp)
+		}
+
+		// A database missing one of them gets exactly that one back
+		partial := setupTestApp(t)
+		defer cleanupTestApp(t, partial)
+		if resp := importTxs(partial, backup.Transactions[:1]); resp.Imported != 1 {
+			t.Fatalf("Seed import = %+v, want 1 imported", resp)
+		}
+		if resp := importTxs(partial, backup.Transactions); resp.Imported != 1 || resp.Skipped != 1 {
+			t.Errorf("Merge import of backup = %+v, want 1 imported and 1 skipped", resp)
+		}
+	})
+}
+
+func TestHandleStorageImport_MergeLegacyPayload(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	// Stored in local time, so the match must not depend on the zone
+	createTestTransaction(t, app, 1, -1250, "pizza", time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC).Local())
+
+	// An export from before uids existed: one row already here, one new
+	// row, and the new row repeated
+	legacy := []StorageTransaction{
+		{ID: 1, Amount: -1250, Currency: "USD", Description: "pizza", Date: "2026-02-01T12:00:00Z", CategoryName: "Food", CategoryType: "expense"},
+		{ID: 2, Amount: -300, Currency: "USD", Description: "bus", Date: "2026-02-02T08:00:00Z", CategoryName: "Transport", CategoryType: "expense"},
+		{ID: 3, Amount: -300, Currency: "USD", Description: "bus", Date: "2026-02-02T08:00:00Z", CategoryName: "Transport", CategoryType: "expense"},
+	}
+	body, _ := json.Marshal(StorageImportRequest{Transactions: legacy})
+	req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	app.HandleStorageImport(rec, req)
+
+	var resp StorageImportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Imported != 1 || resp.Skipped != 2 || resp.Errors != 0 {
+		t.Errorf("Merge import = %+v, want 1 imported and 2 skipped", resp)
+	}
+
+	txs, err := app.Q.ListAllTransactionsForExport(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to list transactions: %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("Transaction count = %d, want 2", len(txs))
+	}
+	for _, tx := range txs {
+		if !tx.Uid.Valid || tx.Uid.String == "" {
+			t.Errorf("Transaction %q has no uid", tx.Description)
+		}
+	}
+}
+
+func TestHandleStorageImport_DateFormats(t *testing.T) {
+	importDate := func(app *Application, date string) StorageImportResponse {
+		t.Helper()
+		body, _ := json.Marshal(StorageImportRequest{Transactions: []StorageTransaction{{
+			Amount:       -1500,
+			Currency:     "USD",
+			Description:  "bank export",
+			Date:         date,
+			CategoryName: "Food",
+			CategoryType: "expense",
+		}}})
+		req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		app.HandleStorageImport(rec, req)
+		var resp StorageImportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("day-first date fails with default layouts", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		if resp := importDate(app, "15/01/2026"); resp.Errors != 1 || resp.Imported != 0 {
+			t.Errorf("Import = %+v, want 1 error", resp)
+		}
+	})
+
+	t.Run("date-only string is imported without config", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		if resp := importDate(app, "2026-01-15"); resp.Imported != 1 || resp.Errors != 0 {
+			t.Errorf("Import = %+v, want 1 imported and no errors", resp)
+		}
+	})
+
+	t.Run("date-only fallback survives custom layouts", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.DateFormats = "02/01/2006"
+
+		if resp := importDate(app, "2026-01-15"); resp.Imported != 1 || resp.Errors != 0 {
+			t.Errorf("Import = %+v, want 1 imported and no errors", resp)
+		}
+	})
+
+	t.Run("added layout is tried in order", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.DateFormats = defaultDateFormats + ";02/01/2006"
+
+		if resp := importDate(app, "15/01/2026"); resp.Imported != 1 {
+			t.Fatalf("Import = %+v, want 1 imported", resp)
+		}
+		txs, err := app.Q.ListRecentTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to list transactions: %v", err)
+		}
+		want := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+		if len(txs) != 1 || !txs[0].Date.Equal(want) {
+			t.Errorf("Imported date = %v, want %v", txs, want)
+		}
+	})
+}
+
+func TestHandleStorageImport_RequireDescription(t *testing.T) {
+	importBlank := func(app *Application) StorageImportResponse {
+		t.Helper()
+		body, _ := json.Marshal(StorageImportRequest{Transactions: []StorageTransaction{
+			{Amount: -1500, Currency: "USD", Description: "  ", Date: "2026-01-15", CategoryName: "Food", CategoryType: "expense"},
+			{Amount: -800, Currency: "USD", Description: "coffee", Date: "2026-01-15", CategoryName: "Food", CategoryType: "expense"},
+		}})
+		req := httptest.NewRequest(http.MethodPost, "/api/storage/import", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		app.HandleStorageImport(rec, req)
+		var resp StorageImportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	t.Run("blank description is imported by default", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		if resp := importBlank(app); resp.Imported != 2 || resp.Errors != 0 {
+			t.Errorf("Import = %+v, want 2 imported and no errors", resp)
+		}
+	})
+
+	t.Run("blank description is an error when required", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.RequireDescription = true
+
+		if resp := importBlank(app); resp.Imported != 1 || resp.Errors != 1 {
+			t.Errorf("Import = %+v, want 1 imported and 1 error", resp)
+		}
+		count, err := app.Q.CountAllTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to count transactions: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("CountAllTransactions() = %d, want 1", count)
+		}
+	})
+}
+
 func TestHandleStorageImport_ContentType(t *testing.T) {
 	app := setupTestApp(t)
 	defer cleanupTestApp(t, app)
@@ -1080,3 +1569,108 @@ func TestStorageRoundTrip(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleStorageImportURL(t *testing.T) {
+	export, _ := json.Marshal(StorageExportResponse{
+		Transactions: []StorageTransaction{
+			{Amount: -1500, Currency: "USD", Description: "remote lunch", Date: "2026-01-15T12:00:00Z", CategoryName: "Food", CategoryType: "expense", UID: "remote-1"},
+			{Amount: -800, Currency: "USD", Description: "remote bus", Date: "2026-01-16T08:00:00Z", CategoryName: "Transport", CategoryType: "expense", UID: "remote-2"},
+		},
+		Year: "2026",
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/export.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(export)
+	}))
+	defer srv.Close()
+
+	importURL := func(app *Application, rawURL string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(ImportURLRequest{URL: rawURL})
+		req := httptest.NewRequest(http.MethodPost, "/api/storage/import-url", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		app.HandleStorageImportURL(rec, req)
+		return rec
+	}
+
+	t.Run("imports the fetched export", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.ImportURLHosts = "127.0.0.1"
+
+		rec := importURL(app, srv.URL+"/export.json")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleStorageImportURL() status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var resp StorageImportResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if resp.Imported != 2 || resp.Errors != 0 {
+			t.Errorf("Import = %+v, want 2 imported and no errors", resp)
+		}
+	})
+
+	t.Run("allowlisted host is fetched", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.ImportURLHosts = "backups.example.com, 127.0.0.1"
+
+		if rec := importURL(app, srv.URL+"/export.json"); rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+	})
+
+	t.Run("host outside the allowlist is rejected", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.ImportURLHosts = "backups.example.com"
+
+		if rec := importURL(app, srv.URL+"/export.json"); rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("non-http scheme is rejected", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		if rec := importURL(app, "file:///etc/passwd"); rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("failed fetch is a bad gateway", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+		app.Config.ImportURLHosts = "127.0.0.1"
+
+		rec := importURL(app, srv.URL+"/missing.json")
+		if rec.Code != http.StatusBadGateway {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadGateway)
+		}
+		if strings.Contains(rec.Body.String(), "404") {
+			t.Errorf("body = %q, should not relay the upstream status", rec.Body.String())
+		}
+	})
+
+	t.Run("loopback is refused unless allowlisted", func(t *testing.T) {
+		app := setupTestApp(t)
+		defer cleanupTestApp(t, app)
+
+		if rec := importURL(app, srv.URL+"/export.json"); rec.Code != http.StatusBadGateway {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusBadGateway)
+		}
+		count, err := app.Q.CountAllTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("Failed to count: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("Transaction count = %d, want 0", count)
+		}
+	})
+}
@@ -9,9 +9,12 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
 	"strings"
 	"syscall"
+	"time"
 
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/client/templates"
 	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -19,29 +22,150 @@ import (
 )
 
 type Config struct {
-	Port           int
-	DBPath         string
-	CategoriesPath string
-	BackupPath     string
-	BackupInterval int
+	Port               int
+	DBPath             string
+	CategoriesPath     string
+	BackupPath         string
+	BackupInterval     int
+	DisplayAbs         bool
+	AllowZero          bool
+	IncomeConfirmCents int64
+	OpeningBalance     int64
+	AuditRetentionDays int
+	UploadsDir         string
+	LegacySalary       bool
+	SeedName           string
+	SeedEmail          string
+	BackupBase         string
+	TempDir            string
+	DisplayRounding    string
+	FiscalStart        int
+	ImportMaxRows      int
+	ImportWorkers      int
+	RequireDescription bool
+	ImportURLHosts     string
+	MaxDescription     int
+	DateFormats        string
+	WipePhrase         string
+	DeletePolicy       string
+	CurrencySymbols    string
+	AlertWebhook       string
+	AlertAttempts      int
+	AlertBackoff       time.Duration
+	StrictCategories   bool
+	MigrateOnly        bool
 }
 
+// defaultWipePhrase must be typed to confirm wiping all data when
+// -wipe-phrase is not set.
+const defaultWipePhrase = "WIPE"
+
+// Delete policies accepted by -delete-policy. Soft deletes keep the row with
+// deleted_at set, which the show_deleted toggle and the trash rely on; hard
+// deletes remove it for good, so neither has anything to show.
+const (
+	deletePolicySoft = "soft"
+	deletePolicyHard = "hard"
+)
+
+// Default identity for the user created on first run.
+const (
+	defaultSeedName  = "CapCJ"
+	defaultSeedEmail = "capcj@example.com"
+)
+
+// Build information, set at link time with
+// -ldflags "-X main.Version=... -X main.BuildTime=...".
+var (
+	Version   = "dev"
+	BuildTime = ""
+)
+
 type Application struct {
 	Config    Config
 	DB        *sql.DB
 	Q         *db.Queries
 	CatConfig *CategoryConfig
+	// CurrencySymbols overrides how amounts in a currency are written
+	CurrencySymbols CurrencyTable
 }
 
 func main() {
 	var cfg Config
 	flag.IntVar(&cfg.Port, "port", 8080, "HTTP server port")
 	flag.StringVar(&cfg.DBPath, "db", "cheapskate.db", "Path to SQLite database")
-	flag.StringVar(&cfg.CategoriesPath, "categories", "categories.json", "Path to category mappings config file")
+	flag.StringVar(&cfg.CategoriesPath, "categories", "", "Path to category mappings config file (searched for in $XDG_CONFIG_HOME/cheapskate and the working directory if empty or missing)")
 	flag.StringVar(&cfg.BackupPath, "backup-path", "", "Directory for automatic backups (disabled if empty)")
 	flag.IntVar(&cfg.BackupInterval, "backup-interval", 30, "Backup interval in minutes")
+	flag.BoolVar(&cfg.DisplayAbs, "display-abs", false, "Display expense amounts as positive magnitudes")
+	flag.BoolVar(&cfg.AllowZero, "allow-zero", false, "Accept zero-amount transactions")
+	flag.Int64Var(&cfg.OpeningBalance, "opening-balance", 0, "Balance in cents before the first transaction, used by running-balance analytics until set through the API")
+	flag.Int64Var(&cfg.IncomeConfirmCents, "income-confirm", 0, "Ask for confirmation before recording income above this many cents (0 never asks)")
+	flag.IntVar(&cfg.AuditRetentionDays, "audit-retention-days", 90, "Days to keep audit log entries (0 keeps them forever)")
+	flag.StringVar(&cfg.UploadsDir, "uploads-dir", "uploads", "Directory for uploaded receipt files")
+	flag.BoolVar(&cfg.LegacySalary, "legacy-salary", true, "Ensure the legacy Salary income category exists")
+	flag.StringVar(&cfg.SeedName, "seed-name", defaultSeedName, "Name of the default user created on an empty database")
+	flag.StringVar(&cfg.SeedEmail, "seed-email", defaultSeedEmail, "Email of the default user created on an empty database")
+	flag.StringVar(&cfg.BackupBase, "backup-base", "", "Base directory the backup path must stay within (unrestricted if empty)")
+	flag.StringVar(&cfg.TempDir, "temp-dir", "", "Directory for temporary backup files (system default if empty)")
+	flag.StringVar(&cfg.DisplayRounding, "display-rounding", "cents", "Display amounts to the cent (cents) or rounded to whole dollars (dollars)")
+	flag.IntVar(&cfg.FiscalStart, "fiscal-start", 1, "First month (1-12) of the fiscal year used by the dashboard")
+	flag.IntVar(&cfg.MaxDescription, "max-description", 0, "Truncate stored descriptions to this many characters (0 is unlimited)")
+	flag.StringVar(&cfg.DateFormats, "date-formats", defaultDateFormats, "Semicolon-separated Go time layouts tried in order when importing dates")
+	flag.IntVar(&cfg.ImportMaxRows, "import-max-rows", defaultImportMaxRows, "Maximum number of transactions accepted by a storage import")
+	flag.IntVar(&cfg.ImportWorkers, "import-workers", 1, "Number of workers processing a storage import concurrently")
+	flag.StringVar(&cfg.ImportURLHosts, "import-url-hosts", "", "Comma-separated hosts /api/storage/import-url may fetch from, including private addresses (any public host if empty)")
+	flag.BoolVar(&cfg.RequireDescription, "require-description", false, "Count imported transactions with a blank description as errors instead of inserting them")
+	flag.StringVar(&cfg.WipePhrase, "wipe-phrase", defaultWipePhrase, "Phrase that must be typed to confirm wiping all data")
+	flag.StringVar(&cfg.DeletePolicy, "delete-policy", deletePolicySoft, "How transactions are deleted: soft (restorable, shown by show_deleted and the trash) or hard (permanent)")
+	flag.StringVar(&cfg.CurrencySymbols, "currency-symbols", "", "Path to a JSON file overriding currency symbols and decimals (built-in formats if empty)")
+	flag.StringVar(&cfg.AlertWebhook, "alert-webhook", "", "URL that receives a JSON POST when an expense goes over its category budget (disabled if empty)")
+	flag.IntVar(&cfg.AlertAttempts, "alert-attempts", defaultAlertAttempts, "Times a budget alert is posted before giving up")
+	flag.DurationVar(&cfg.AlertBackoff, "alert-backoff", defaultAlertBackoff, "Delay before the first budget alert retry, doubled for each later retry")
+	flag.BoolVar(&cfg.StrictCategories, "strict-categories", false, "Reject transactions whose inferred category does not exist instead of falling back to the first category")
+	flag.BoolVar(&cfg.MigrateOnly, "migrate", false, "Apply pending schema migrations and exit")
 	flag.Parse()
 
+	if cfg.DisplayRounding != "cents" && cfg.DisplayRounding != "dollars" {
+		log.Fatalf("Invalid -display-rounding %q: must be cents or dollars", cfg.DisplayRounding)
+	}
+	if cfg.FiscalStart < 1 || cfg.FiscalStart > 12 {
+		log.Fatalf("Invalid -fiscal-start %d: must be a month from 1 to 12", cfg.FiscalStart)
+	}
+
+	if cfg.MaxDescription < 0 {
+		log.Fatalf("Invalid -max-description %d: must not be negative", cfg.MaxDescription)
+	}
+	if cfg.IncomeConfirmCents < 0 {
+		log.Fatalf("Invalid -income-confirm %d: must not be negative", cfg.IncomeConfirmCents)
+	}
+	if cfg.ImportMaxRows < 1 {
+		log.Fatalf("Invalid -import-max-rows %d: must be at least 1", cfg.ImportMaxRows)
+	}
+	if cfg.ImportWorkers < 1 {
+		log.Fatalf("Invalid -import-workers %d: must be at least 1", cfg.ImportWorkers)
+	}
+	if cfg.WipePhrase == "" {
+		log.Fatal("Invalid -wipe-phrase: must not be empty")
+	}
+	if cfg.DeletePolicy != deletePolicySoft && cfg.DeletePolicy != deletePolicyHard {
+		log.Fatalf("Invalid -delete-policy %q: must be soft or hard", cfg.DeletePolicy)
+	}
+	if cfg.AlertAttempts < 1 {
+		log.Fatalf("Invalid -alert-attempts %d: must be at least 1", cfg.AlertAttempts)
+	}
+	if cfg.AlertBackoff < 0 {
+		log.Fatalf("Invalid -alert-backoff %s: must not be negative", cfg.AlertBackoff)
+	}
+
+	if cfg.BackupPath != "" {
+		if err := validateBackupPath(cfg.BackupBase, cfg.BackupPath); err != nil {
+			log.Fatalf("Invalid backup path: %v", err)
+		}
+	}
+
+	log.Printf("Cheapskate %s (%s)", Version, runtime.Version())
+
 	// Initialize Database
 	dbConn, err := sql.Open("sqlite3", cfg.DBPath)
 	if err != nil {
@@ -57,18 +181,35 @@ func main() {
 	queries := db.New(dbConn)
 
 	// Load category mappings
-	catConfig := LoadCategoryConfig(cfg.CategoriesPath)
+	catConfig := ResolveCategoryConfig(cfg.CategoriesPath)
+
+	var currencySymbols CurrencyTable
+	if cfg.CurrencySymbols != "" {
+		currencySymbols, err = LoadCurrencySymbols(cfg.CurrencySymbols)
+		if err != nil {
+			log.Fatalf("Invalid -currency-symbols: %v", err)
+		}
+		log.Printf("Loaded %d currency formats from %s", len(currencySymbols), cfg.CurrencySymbols)
+	}
 
 	app := &Application{
-		Config:    cfg,
-		DB:        dbConn,
-		Q:         queries,
-		CatConfig: catConfig,
+		Config:          cfg,
+		DB:              dbConn,
+		Q:               queries,
+		CatConfig:       catConfig,
+		CurrencySymbols: currencySymbols,
 	}
 
 	// Apply migrations
+	if cfg.MigrateOnly {
+		if err := app.ensureSchema(); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		log.Println("Schema is up to date")
+		return
+	}
 	if err := app.ensureSchema(); err != nil {
-		log.Printf("Warning: Failed to ensure schema: %v", err)
+		log.Fatalf("Migration failed: %v", err)
 	}
 
 	// Seed Data
@@ -82,11 +223,15 @@ func main() {
 	if cfg.BackupPath != "" {
 		go app.startBackupLoop(ctx)
 	}
+	if cfg.AuditRetentionDays > 0 {
+		go app.startAuditCleanupLoop(ctx)
+	}
 
 	// Setup Router
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	r.Use(app.withDisplayOptions)
 
 	// Static Files
 	fileServer(r, "/assets", http.Dir("client/assets"))
@@ -102,17 +247,49 @@ func main() {
 	}
 }
 
+// initialSchemaVersion is the migration holding the schema every database
+// had before migrations were tracked.
+const initialSchemaVersion = 1
+
+// ensureSchema applies pending embedded migrations. A database created before
+// migrations were tracked is first upgraded to the initial schema, then that
+// migration is recorded as applied rather than run again.
 func (app *Application) ensureSchema() error {
-	schema, err := os.ReadFile("server/db/schema.sql")
+	ctx := context.Background()
+
+	migrations, err := db.Migrations()
 	if err != nil {
-		return fmt.Errorf("could not read schema: %w", err)
+		return fmt.Errorf("could not load migrations: %w", err)
 	}
-	_, err = app.DB.Exec(string(schema))
-	if err != nil {
-		// Just log, as it fails if table exists
-		log.Printf("Schema exec: %v", err)
+
+	if app.schemaInitialized() && !app.migrationsTracked() {
+		if err := db.UpgradeLegacy(ctx, app.DB); err != nil {
+			return fmt.Errorf("could not upgrade legacy schema: %w", err)
+		}
+		if err := db.Baseline(ctx, app.DB, migrations, initialSchemaVersion); err != nil {
+			return fmt.Errorf("could not baseline migrations: %w", err)
+		}
 	}
-	return nil
+
+	applied, err := db.Migrate(ctx, app.DB, migrations)
+	if applied > 0 {
+		log.Printf("Applied %d schema migration(s)", applied)
+	}
+	return err
+}
+
+// migrationsTracked reports whether any migration has been recorded.
+func (app *Application) migrationsTracked() bool {
+	var count int
+	err := app.DB.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
+	return err == nil && count > 0
+}
+
+// schemaInitialized reports whether the core transactions table exists.
+func (app *Application) schemaInitialized() bool {
+	var name string
+	err := app.DB.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name='transactions'").Scan(&name)
+	return err == nil
 }
 
 func (app *Application) ensureSeed() error {
@@ -122,36 +299,38 @@ func (app *Application) ensureSeed() error {
 		return err // Table might not exist yet if schema failed completely
 	}
 	if count == 0 {
+		name, email := app.Config.SeedName, app.Config.SeedEmail
+		if name == "" {
+			name = defaultSeedName
+		}
+		if email == "" {
+			email = defaultSeedEmail
+		}
 		log.Println("Seeding default user...")
-		_, err := app.DB.Exec("INSERT INTO users (name, email) VALUES ('CapCJ', 'capcj@example.com')")
+		_, err := app.DB.Exec("INSERT INTO users (name, email) VALUES (?, ?)", name, email)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Add deleted_at column if it doesn't exist (migration for soft delete)
-	_, err = app.DB.Exec(`ALTER TABLE transactions ADD COLUMN deleted_at DATETIME DEFAULT NULL`)
-	if err != nil {
-		// Column likely already exists, ignore error
-		log.Printf("Schema migration (deleted_at): %v", err)
-	}
-
 	// Ensure income categories have correct type (fixes old databases with Salary as expense)
 	_, err = app.DB.Exec(`UPDATE categories SET type = 'income' WHERE name IN ('Salary', 'Earned Income') AND type != 'income'`)
 	if err != nil {
 		log.Printf("Warning: Could not fix category types: %v", err)
 	}
 
-	// Ensure Salary category exists for backwards compatibility (only if not already present)
-	_, err = app.DB.Exec(`INSERT INTO categories (name, type, icon, color) SELECT 'Salary', 'income', '💰', '#2ECC71' WHERE NOT EXISTS (SELECT 1 FROM categories WHERE name = 'Salary')`)
-	if err != nil {
-		log.Printf("Warning: Could not ensure Salary category: %v", err)
-	}
+	if app.Config.LegacySalary {
+		// Ensure Salary category exists for backwards compatibility (only if not already present)
+		_, err = app.DB.Exec(`INSERT INTO categories (name, type, icon, color) SELECT 'Salary', 'income', '💰', '#2ECC71' WHERE NOT EXISTS (SELECT 1 FROM categories WHERE name = 'Salary')`)
+		if err != nil {
+			log.Printf("Warning: Could not ensure Salary category: %v", err)
+		}
 
-	// Clean up duplicate Salary categories created by previous bug (keep only the lowest ID)
-	_, err = app.DB.Exec(`DELETE FROM categories WHERE name = 'Salary' AND id != (SELECT MIN(id) FROM categories WHERE name = 'Salary')`)
-	if err != nil {
-		log.Printf("Warning: Could not clean up duplicate Salary categories: %v", err)
+		// Clean up duplicate Salary categories created by previous bug (keep only the lowest ID)
+		_, err = app.DB.Exec(`DELETE FROM categories WHERE name = 'Salary' AND id != (SELECT MIN(id) FROM categories WHERE name = 'Salary')`)
+		if err != nil {
+			log.Printf("Warning: Could not clean up duplicate Salary categories: %v", err)
+		}
 	}
 
 	// Ensure all categories referenced by the category config exist in the database
@@ -220,6 +399,17 @@ func (app *Application) ensureCategoriesFromConfig() {
 	}
 }
 
+// withDisplayOptions makes the configured display preferences available to templates.
+func (app *Application) withDisplayOptions(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := templates.WithDisplayOptions(r.Context(), templates.DisplayOptions{
+			AbsoluteExpenses: app.Config.DisplayAbs,
+			RoundDollars:     app.Config.DisplayRounding == "dollars",
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func fileServer(r chi.Router, path string, root http.FileSystem) {
 	if code := path[len(path)-1]; code != '/' {
 		path += "/"
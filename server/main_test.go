@@ -11,8 +11,7 @@ import (
 )
 
 func TestEnsureSchema(t *testing.T) {
-	// This test requires the schema.sql file to exist
-	// We need to run from the project root or adjust the working directory
+	// Run from the project root, as the server normally is
 
 	// Find the project root by looking for go.mod
 	projectRoot := findProjectRoot(t)
@@ -21,7 +20,7 @@ func TestEnsureSchema(t *testing.T) {
 		t.Fatalf("Failed to get working directory: %v", err)
 	}
 
-	// Change to project root for schema file access
+	// Change to project root
 	if err := os.Chdir(projectRoot); err != nil {
 		t.Fatalf("Failed to change directory: %v", err)
 	}
@@ -120,6 +119,63 @@ func TestEnsureSchema(t *testing.T) {
 	})
 }
 
+func TestEnsureSchema_OutsideRepoTree(t *testing.T) {
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	// Migrations are embedded, so the working directory does not matter
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	t.Run("succeeds when database is already initialized", func(t *testing.T) {
+		dbConn, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		defer dbConn.Close()
+
+		_, err = dbConn.Exec(`CREATE TABLE transactions (id INTEGER PRIMARY KEY);
+			CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT NOT NULL, type TEXT NOT NULL, icon TEXT, color TEXT)`)
+		if err != nil {
+			t.Fatalf("Failed to pre-initialize database: %v", err)
+		}
+
+		app := &Application{
+			DB: dbConn,
+			Q:  db.New(dbConn),
+		}
+
+		if err := app.ensureSchema(); err != nil {
+			t.Errorf("ensureSchema() error = %v, want nil for initialized database", err)
+		}
+	})
+
+	t.Run("creates schema on an empty database", func(t *testing.T) {
+		dbConn, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		defer dbConn.Close()
+		dbConn.SetMaxOpenConns(1)
+
+		app := &Application{
+			DB: dbConn,
+			Q:  db.New(dbConn),
+		}
+
+		if err := app.ensureSchema(); err != nil {
+			t.Fatalf("ensureSchema() error = %v", err)
+		}
+		if !app.schemaInitialized() {
+			t.Error("ensureSchema() should create the schema from embedded migrations")
+		}
+	})
+}
+
 func TestEnsureSeed(t *testing.T) {
 	projectRoot := findProjectRoot(t)
 	originalWd, err := os.Getwd()
@@ -179,6 +235,42 @@ func TestEnsureSeed(t *testing.T) {
 		}
 	})
 
+	t.Run("uses configured seed user details", func(t *testing.T) {
+		dbConn, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open database: %v", err)
+		}
+		defer dbConn.Close()
+
+		app := &Application{
+			DB:     dbConn,
+			Q:      db.New(dbConn),
+			Config: Config{SeedName: "Alice", SeedEmail: "alice@example.org"},
+		}
+
+		err = app.ensureSchema()
+		if err != nil {
+			t.Fatalf("ensureSchema() error = %v", err)
+		}
+
+		err = app.ensureSeed()
+		if err != nil {
+			t.Fatalf("ensureSeed() error = %v", err)
+		}
+
+		var name, email string
+		err = dbConn.QueryRow("SELECT name, email FROM users WHERE id = 1").Scan(&name, &email)
+		if err != nil {
+			t.Fatalf("Failed to get user: %v", err)
+		}
+		if name != "Alice" {
+			t.Errorf("User name = %q, want %q", name, "Alice")
+		}
+		if email != "alice@example.org" {
+			t.Errorf("User email = %q, want %q", email, "alice@example.org")
+		}
+	})
+
 	t.Run("does not create duplicate users", func(t *testing.T) {
 		dbConn, err := sql.Open("sqlite3", ":memory:")
 		if err != nil {
@@ -287,8 +379,9 @@ func TestEnsureSeed_NoDuplicateSalaryCategories(t *testing.T) {
 	defer dbConn.Close()
 
 	app := &Application{
-		DB: dbConn,
-		Q:  db.New(dbConn),
+		DB:     dbConn,
+		Q:      db.New(dbConn),
+		Config: Config{LegacySalary: true},
 	}
 
 	// Apply schema (which seeds default categories)
@@ -410,8 +503,9 @@ func TestEnsureSeed_CleansDuplicateSalaryCategories(t *testing.T) {
 	defer dbConn.Close()
 
 	app := &Application{
-		DB: dbConn,
-		Q:  db.New(dbConn),
+		DB:     dbConn,
+		Q:      db.New(dbConn),
+		Config: Config{LegacySalary: true},
 	}
 
 	err = app.ensureSchema()
@@ -453,6 +547,111 @@ func TestEnsureSeed_CleansDuplicateSalaryCategories(t *testing.T) {
 	}
 }
 
+func TestEnsureSeed_LegacySalaryDisabled(t *testing.T) {
+	projectRoot := findProjectRoot(t)
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(projectRoot); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer dbConn.Close()
+
+	app := &Application{
+		DB:     dbConn,
+		Q:      db.New(dbConn),
+		Config: Config{LegacySalary: false},
+		CatConfig: &CategoryConfig{
+			DefaultCategory: "Groceries",
+			Categories: []CategoryEntry{
+				{Name: "Earned Income", Keywords: []string{"paycheck"}},
+				{Name: "Groceries", Keywords: []string{"market"}},
+			},
+		},
+	}
+
+	err = app.ensureSchema()
+	if err != nil {
+		t.Fatalf("ensureSchema() error = %v", err)
+	}
+
+	err = app.ensureSeed()
+	if err != nil {
+		t.Fatalf("ensureSeed() error = %v", err)
+	}
+
+	var count int
+	err = dbConn.QueryRow("SELECT COUNT(*) FROM categories WHERE name = 'Salary'").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count Salary categories: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Found %d Salary categories with legacy-salary off, want 0", count)
+	}
+
+	// Config categories should still be created
+	err = dbConn.QueryRow("SELECT COUNT(*) FROM categories WHERE name = 'Groceries'").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count Groceries categories: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Groceries count = %d, want 1", count)
+	}
+}
+
+func TestEnsureSchema_UpgradesLegacyDatabase(t *testing.T) {
+	dbConn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer dbConn.Close()
+	dbConn.SetMaxOpenConns(1)
+
+	// A database from before migrations, soft delete, receipts or uids existed
+	_, err = dbConn.Exec(`
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, email TEXT NOT NULL UNIQUE, created_at DATETIME DEFAULT CURRENT_TIMESTAMP);
+		CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT NOT NULL, type TEXT NOT NULL, icon TEXT, color TEXT);
+		CREATE TABLE transactions (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL, category_id INTEGER NOT NULL, amount INTEGER NOT NULL, currency TEXT NOT NULL DEFAULT 'USD', description TEXT NOT NULL, date DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, created_at DATETIME DEFAULT CURRENT_TIMESTAMP);
+		INSERT INTO users (name, email) VALUES ('Old', 'old@example.com');
+		INSERT INTO categories (name, type) VALUES ('Food', 'expense');
+		INSERT INTO transactions (user_id, category_id, amount, description) VALUES (1, 1, -100, 'one'), (1, 1, -200, 'two');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to set up legacy database: %v", err)
+	}
+
+	app := &Application{DB: dbConn, Q: db.New(dbConn)}
+	if err := app.ensureSchema(); err != nil {
+		t.Fatalf("ensureSchema() error = %v", err)
+	}
+
+	for _, table := range []string{"budgets", "audit_log", "transaction_history", "transaction_tags", "settings"} {
+		var name string
+		if err := dbConn.QueryRow("SELECT name FROM sqlite_master WHERE type='table' AND name=?", table).Scan(&name); err != nil {
+			t.Errorf("Table %q should exist after upgrading a legacy database", table)
+		}
+	}
+	if _, err := dbConn.Exec("UPDATE transactions SET deleted_at = NULL, receipt_path = NULL"); err != nil {
+		t.Errorf("Legacy transactions should gain deleted_at and receipt_path: %v", err)
+	}
+
+	var missing, distinct int
+	err = dbConn.QueryRow("SELECT COUNT(*) - COUNT(uid), COUNT(DISTINCT uid) FROM transactions").Scan(&missing, &distinct)
+	if err != nil {
+		t.Fatalf("Failed to inspect uids: %v", err)
+	}
+	if missing != 0 || distinct != 2 {
+		t.Errorf("After backfill %d uids missing and %d distinct, want 0 and 2", missing, distinct)
+	}
+}
+
 func TestEnsureSeed_IdempotentOverall(t *testing.T) {
 	projectRoot := findProjectRoot(t)
 	originalWd, err := os.Getwd()
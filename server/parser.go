@@ -2,15 +2,22 @@ package main
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 )
 
 type ParsedTransaction struct {
-	Amount      int64 // Cents
+	Amount      int64 // Minor units of the currency (cents by default)
 	Description string
 	Category    string // Inferred or empty
+	Currency    string // ISO code given after the amount ("1.250 KWD x"), or empty
+	// CategoryOverride is the name given with an explicit "@Category" token,
+	// with underscores standing in for spaces ("@Earned_Income").
+	CategoryOverride string
 }
 
 // ParsedRemoveCommand represents a parsed "remove" command from user input
@@ -19,11 +26,41 @@ type ParsedRemoveCommand struct {
 	Description string // Optional description filter
 }
 
+// amountPattern matches a plain amount ("1250.50") or one with comma
+// thousands separators ("1,250.50"). Comma is always a group separator and
+// dot is always the decimal point, so "1.250,00" is rejected. The number of
+// decimal places is checked against the currency by parseAmountDecimals.
+const amountPattern = `\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:\.\d+)?`
+
+// defaultCurrencyDecimals is the precision for currencies not listed in
+// currencyDecimals.
+const defaultCurrencyDecimals = 2
+
+// currencyDecimals lists the currencies whose minor unit is not a hundredth.
+var currencyDecimals = map[string]int{
+	"JPY": 0, "KRW": 0, "CLP": 0,
+	"KWD": 3, "BHD": 3, "OMR": 3,
+}
+
+// currencyPrecision returns the number of decimal places allowed for code.
+func currencyPrecision(code string) int {
+	if d, ok := currencyDecimals[code]; ok {
+		return d
+	}
+	return defaultCurrencyDecimals
+}
+
 var (
-	// Matches "50 pizza" or "50.50 taxi"
-	reSimple = regexp.MustCompile(`^(\d+(?:\.\d{1,2})?)\s+(.+)$`)
+	// Matches "50 pizza", "50.50 taxi", "1,250 rent" or "1.250 KWD rent"
+	reSimple = regexp.MustCompile(`^(` + amountPattern + `)\s+(?:([A-Z]{3})\s+)?(.+)$`)
 	// Matches "remove 50" or "remove 50.50" or "remove 50 pizza"
-	reRemove = regexp.MustCompile(`(?i)^remove\s+(\d+(?:\.\d{1,2})?)(?:\s+(.+))?$`)
+	reRemove = regexp.MustCompile(`(?i)^remove\s+(` + amountPattern + `)(?:\s+(.+))?$`)
+	// Matches a whole amount string, used to validate comma grouping
+	reAmount = regexp.MustCompile(`^(?:` + amountPattern + `)$`)
+	// Matches an explicit "@Category" override anywhere in the description
+	reOverride = regexp.MustCompile(`(?:^|\s)@(\S+)`)
+	// Matches a "#tag" that starts a word, e.g. "#trip" in "hotel (#trip)"
+	reHashtag = regexp.MustCompile(`(?:^|\W)#(\w+)`)
 )
 
 // IsRemoveCommand checks if the input is a remove command
@@ -56,23 +93,49 @@ func ParseRemoveCommand(input string) (ParsedRemoveCommand, error) {
 	}, nil
 }
 
-func ParseTransaction(input string, catConfig *CategoryConfig) (ParsedTransaction, error) {
+// ParseTransaction parses entry box input such as "50 pizza" or "1.250 KWD
+// taxi". Currency codes and their precision come from currencies, which may
+// be nil to use only the built-in tables.
+func ParseTransaction(input string, catConfig *CategoryConfig, currencies CurrencyTable) (ParsedTransaction, error) {
 	input = strings.TrimSpace(input)
 
 	// Try Regex First
 	if matches := reSimple.FindStringSubmatch(input); matches != nil {
 		amountStr := matches[1]
-		desc := matches[2]
+		currency := matches[2]
+		desc := matches[3]
 
-		amount, err := parseAmount(amountStr)
+		// Only known codes are currencies; "50 BBQ dinner" is a description
+		if currency != "" && !currencies.Known(currency) {
+			desc = currency + " " + desc
+			currency = ""
+		}
+
+		amount, err := parseAmountDecimals(amountStr, currencies.Decimals(currency))
 		if err != nil {
 			return ParsedTransaction{}, err
 		}
 
+		override := ""
+		if m := reOverride.FindStringSubmatch(desc); m != nil {
+			override = strings.ReplaceAll(m[1], "_", " ")
+			desc = strings.Join(strings.Fields(reOverride.ReplaceAllString(desc, " ")), " ")
+			if desc == "" {
+				return ParsedTransaction{}, errors.New("missing description")
+			}
+		}
+
+		category := override
+		if category == "" {
+			category = catConfig.InferCategory(desc)
+		}
+
 		return ParsedTransaction{
-			Amount:      amount,
-			Description: strings.TrimSpace(desc),
-			Category:    catConfig.InferCategory(desc),
+			Amount:           amount,
+			Description:      strings.TrimSpace(desc),
+			Category:         category,
+			Currency:         currency,
+			CategoryOverride: override,
 		}, nil
 	}
 
@@ -80,12 +143,55 @@ func ParseTransaction(input string, catConfig *CategoryConfig) (ParsedTransactio
 	return ParsedTransaction{}, errors.New("could not parse input")
 }
 
+// extractHashtags returns the distinct "#tags" in desc, lowercased and
+// without the leading #, in the order they first appear.
+func extractHashtags(desc string) []string {
+	var tags []string
+	for _, m := range reHashtag.FindAllStringSubmatch(desc, -1) {
+		tag := strings.ToLower(m[1])
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// truncateDescription shortens desc to at most max characters, ending in an
+// ellipsis when cut. A max of zero or less leaves desc unchanged.
+func truncateDescription(desc string, max int) string {
+	runes := []rune(desc)
+	if max <= 0 || len(runes) <= max {
+		return desc
+	}
+	if max == 1 {
+		return "…"
+	}
+	return strings.TrimRight(string(runes[:max-1]), " ") + "…"
+}
+
+// parseAmount converts an amount string to cents. Commas are accepted only
+// as thousands separators ("1,250.50"); dot is the decimal point.
 func parseAmount(s string) (int64, error) {
-	// Simple float parsing to cents
+	return parseAmountDecimals(s, defaultCurrencyDecimals)
+}
+
+// parseAmountDecimals converts an amount string to minor units of a currency
+// with the given number of decimal places, rejecting extra precision.
+func parseAmountDecimals(s string, decimals int) (int64, error) {
+	if strings.Contains(s, ",") {
+		if !reAmount.MatchString(s) {
+			return 0, errors.New("invalid thousands separator in amount")
+		}
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	if _, frac, ok := strings.Cut(s, "."); ok && len(frac) > decimals {
+		return 0, fmt.Errorf("amount has more than %d decimal places", decimals)
+	}
+
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
 		return 0, err
 	}
-	return int64(f * 100), nil
+	return int64(math.Round(f * math.Pow10(decimals))), nil
 }
 
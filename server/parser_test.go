@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -100,7 +101,70 @@ func TestParseTransaction(t *testing.T) {
 			wantCat:    "Food",
 			wantErr:    false,
 		},
+		{
+			name:       "comma thousands separator",
+			input:      "1,250 rent",
+			wantAmount: 125000,
+			wantDesc:   "rent",
+			wantCat:    "Housing",
+			wantErr:    false,
+		},
+		{
+			name:       "comma thousands separator with decimals",
+			input:      "1,250.50 rent",
+			wantAmount: 125050,
+			wantDesc:   "rent",
+			wantCat:    "Housing",
+			wantErr:    false,
+		},
+		{
+			name:       "explicit category override",
+			input:      "50 @Housing pizza",
+			wantAmount: 5000,
+			wantDesc:   "pizza",
+			wantCat:    "Housing",
+			wantErr:    false,
+		},
+		{
+			name:       "override with underscores for spaces",
+			input:      "1000 bonus @Earned_Income",
+			wantAmount: 100000,
+			wantDesc:   "bonus",
+			wantCat:    "Earned Income",
+			wantErr:    false,
+		},
+		{
+			name:       "three decimal currency",
+			input:      "1.250 KWD taxi",
+			wantAmount: 1250,
+			wantDesc:   "taxi",
+			wantCat:    "Transport",
+			wantErr:    false,
+		},
+		{
+			name:       "unknown code stays in description",
+			input:      "12 BBQ pizza",
+			wantAmount: 1200,
+			wantDesc:   "BBQ pizza",
+			wantCat:    "Food",
+			wantErr:    false,
+		},
 		// Error cases
+		{
+			name:    "three decimals in a two decimal currency",
+			input:   "1.250 EUR taxi",
+			wantErr: true,
+		},
+		{
+			name:    "decimals in a zero decimal currency",
+			input:   "500.5 JPY ramen",
+			wantErr: true,
+		},
+		{
+			name:    "override without description",
+			input:   "50 @Food",
+			wantErr: true,
+		},
 		{
 			name:    "missing description",
 			input:   "50",
@@ -131,11 +195,16 @@ func TestParseTransaction(t *testing.T) {
 			input:   "-50 refund",
 			wantErr: true,
 		},
+		{
+			name:    "european decimal comma",
+			input:   "1.250,00 rent",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ParseTransaction(tt.input, catConfig)
+			got, err := ParseTransaction(tt.input, catConfig, nil)
 
 			if tt.wantErr {
 				if err == nil {
@@ -199,6 +268,31 @@ func TestParseAmount(t *testing.T) {
 			input: "0.01",
 			want:  1,
 		},
+		{
+			name:  "thousands separator",
+			input: "1,250",
+			want:  125000,
+		},
+		{
+			name:  "thousands separator with decimals",
+			input: "1,250.50",
+			want:  125050,
+		},
+		{
+			name:  "multiple thousands groups",
+			input: "1,000,000",
+			want:  100000000,
+		},
+		{
+			name:    "misplaced comma",
+			input:   "12,50",
+			wantErr: true,
+		},
+		{
+			name:    "european format",
+			input:   "1.250,00",
+			wantErr: true,
+		},
 		{
 			name:    "invalid string",
 			input:   "abc",
@@ -239,6 +333,39 @@ func TestParseAmount(t *testing.T) {
 	}
 }
 
+func TestParseAmountDecimals(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{input: "1.250", currency: "KWD", want: 1250},
+		{input: "1,000.5", currency: "KWD", want: 1000500},
+		{input: "1.250", currency: "USD", wantErr: true},
+		{input: "0.29", currency: "USD", want: 29},
+		{input: "500", currency: "JPY", want: 500},
+		{input: "500.5", currency: "JPY", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmountDecimals(tt.input, currencyPrecision(tt.currency))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseAmountDecimals(%q, %s) expected error, got %d", tt.input, tt.currency, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseAmountDecimals(%q, %s) unexpected error: %v", tt.input, tt.currency, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmountDecimals(%q, %s) = %d, want %d", tt.input, tt.currency, got, tt.want)
+		}
+	}
+}
+
 func TestInferCategory(t *testing.T) {
 	catConfig := testCategoryConfig()
 
@@ -257,7 +384,8 @@ func TestInferCategory(t *testing.T) {
 		// Transport keywords
 		{name: "taxi keyword", input: "taxi to work", want: "Transport"},
 		{name: "uber keyword", input: "uber ride", want: "Transport"},
-		{name: "uber uppercase", input: "UBER EATS", want: "Transport"}, // Note: uber eats is transport due to keyword order
+		{name: "uber uppercase", input: "UBER RIDE", want: "Transport"},
+		{name: "uber eats is food", input: "UBER EATS", want: "Food"},
 		{name: "bus keyword", input: "bus ticket", want: "Transport"},
 
 		// Default fallback
@@ -331,6 +459,49 @@ func TestFormatMoney(t *testing.T) {
 	}
 }
 
+func TestExtractHashtags(t *testing.T) {
+	tests := []struct {
+		desc string
+		want []string
+	}{
+		{"hotel #Trip #trip (#work)", []string{"trip", "work"}},
+		{"gym #monthly-fee", []string{"monthly"}},
+		{"issue#42 and a#b", nil},
+		{"no tags here", nil},
+	}
+
+	for _, tt := range tests {
+		if got := extractHashtags(tt.desc); !slices.Equal(got, tt.want) {
+			t.Errorf("extractHashtags(%q) = %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		desc string
+		max  int
+		want string
+	}{
+		{name: "unlimited", desc: "a long bank memo", max: 0, want: "a long bank memo"},
+		{name: "within limit", desc: "pizza", max: 10, want: "pizza"},
+		{name: "exactly at limit", desc: "pizza", max: 5, want: "pizza"},
+		{name: "truncated", desc: "grocery store purchase", max: 10, want: "grocery s…"},
+		{name: "trailing space trimmed", desc: "coffee shop visit", max: 8, want: "coffee…"},
+		{name: "counts runes", desc: "café crème brûlée", max: 6, want: "café…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateDescription(tt.desc, tt.max)
+			if got != tt.want {
+				t.Errorf("truncateDescription(%q, %d) = %q, want %q", tt.desc, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsRemoveCommand(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+// recurringTag marks a transaction to be repeated by copy-recurring, either
+// as a tag or as #monthly in its description.
+const recurringTag = "monthly"
+
+// recurringDate moves orig to the same day and time in the given month,
+// clamped to the month's last day (a copy of January 31 lands on February's
+// last day).
+func recurringDate(orig time.Time, year int, month time.Month) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, orig.Location()).Day()
+	return time.Date(year, month, min(orig.Day(), lastDay), orig.Hour(), orig.Minute(), orig.Second(), 0, orig.Location())
+}
+
+// HandleCopyRecurring recreates last month's recurring transactions in the
+// current month and returns the copies. Transactions already copied this
+// month are skipped, so running it twice does not duplicate them.
+func (app *Application) HandleCopyRecurring(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := thisMonth.AddDate(0, -1, 0)
+
+	sources, err := app.Q.ListRecurringTransactionsByMonth(ctx, db.ListRecurringTransactionsByMonthParams{
+		SourceMonth: lastMonth.Format("2006-01"),
+		Tag:         recurringTag,
+		TargetMonth: thisMonth.Format("2006-01"),
+	})
+	if err != nil {
+		http.Error(w, "Failed to load recurring transactions: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	dbTx, err := app.DB.BeginTx(ctx, nil)
+	if err != nil {
+		http.Error(w, "Failed to start copy: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer dbTx.Rollback()
+	qtx := app.Q.WithTx(dbTx)
+
+	created := make([]StorageTransaction, 0, len(sources))
+	for _, src := range sources {
+		tx, err := qtx.CreateTransaction(ctx, db.CreateTransactionParams{
+			UserID:      src.UserID,
+			CategoryID:  src.CategoryID,
+			Amount:      src.Amount,
+			Currency:    src.Currency,
+			Description: src.Description,
+			Date:        recurringDate(src.Date, thisMonth.Year(), thisMonth.Month()),
+		})
+		if err != nil {
+			http.Error(w, "Failed to copy transaction: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if err := qtx.AddTransactionTag(ctx, db.AddTransactionTagParams{TransactionID: tx.ID, Tag: recurringTag}); err != nil {
+			http.Error(w, "Failed to tag copied transaction: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		createdAt := ""
+		if tx.CreatedAt.Valid {
+			createdAt = tx.CreatedAt.Time.UTC().Format(time.RFC3339)
+		}
+		created = append(created, StorageTransaction{
+			ID:           tx.ID,
+			Amount:       tx.Amount,
+			Currency:     tx.Currency,
+			Description:  tx.Description,
+			Date:         tx.Date.UTC().Format(time.RFC3339),
+			CategoryName: src.CategoryName,
+			CategoryType: src.CategoryType,
+			CreatedAt:    createdAt,
+			UID:          tx.Uid.String,
+		})
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		http.Error(w, "Failed to save copies: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for i, src := range sources {
+		app.recordAudit(ctx, "copy_recurring", src.ID, fmt.Sprintf("as #%d", created[i].ID))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(created)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+func TestRecurringDate(t *testing.T) {
+	tests := []struct {
+		name  string
+		orig  time.Time
+		month time.Month
+		want  string
+	}{
+		{"same day", time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC), time.February, "2024-02-15 09:30"},
+		{"clamped to leap february", time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC), time.February, "2024-02-29 09:30"},
+		{"clamped to thirty days", time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC), time.April, "2024-04-30 09:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recurringDate(tt.orig, 2024, tt.month).Format("2006-01-02 15:04"); got != tt.want {
+				t.Errorf("recurringDate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleCopyRecurring(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	now := time.Now().UTC()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	lastMonth := thisMonth.AddDate(0, -1, 0)
+
+	createTestTransaction(t, app, 3, -150000, "rent #monthly", lastMonth.AddDate(0, 0, 4))
+	gym := createTestTransaction(t, app, 1, -3000, "gym", lastMonth.AddDate(0, 0, 9))
+	if err := app.Q.AddTransactionTag(context.Background(), db.AddTransactionTagParams{TransactionID: gym.ID, Tag: recurringTag}); err != nil {
+		t.Fatalf("Failed to tag transaction: %v", err)
+	}
+	// Untagged transactions are not repeated, nor are longer tags that
+	// merely start with #monthly
+	createTestTransaction(t, app, 1, -4500, "one-off dinner", lastMonth.AddDate(0, 0, 2))
+	createTestTransaction(t, app, 1, -1500, "bank #monthlyfee", lastMonth.AddDate(0, 0, 3))
+	createTestTransaction(t, app, 1, -900, "app #monthly2", lastMonth.AddDate(0, 0, 3))
+
+	copyRecurring := func() []StorageTransaction {
+		req := httptest.NewRequest(http.MethodPost, "/api/transactions/copy-recurring", nil)
+		rec := httptest.NewRecorder()
+		app.HandleCopyRecurring(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleCopyRecurring() status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var created []StorageTransaction
+		if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return created
+	}
+
+	created := copyRecurring()
+	if len(created) != 2 {
+		t.Fatalf("HandleCopyRecurring() created %d transactions, want 2: %+v", len(created), created)
+	}
+	wantDates := map[string]string{
+		"rent #monthly": thisMonth.AddDate(0, 0, 4).Format("2006-01-02"),
+		"gym":           thisMonth.AddDate(0, 0, 9).Format("2006-01-02"),
+	}
+	for _, tx := range created {
+		want, ok := wantDates[tx.Description]
+		if !ok {
+			t.Errorf("Unexpected copy %q", tx.Description)
+			continue
+		}
+		if got := tx.Date[:10]; got != want {
+			t.Errorf("%q copied to %s, want %s", tx.Description, got, want)
+		}
+	}
+
+	count, err := app.Q.CountTransactionsByYear(context.Background(), thisMonth.Format("2006"))
+	if err != nil {
+		t.Fatalf("CountTransactionsByYear() error = %v", err)
+	}
+	if count < 2 {
+		t.Errorf("CountTransactionsByYear() = %d, want the copies stored", count)
+	}
+
+	if again := copyRecurring(); len(again) != 0 {
+		t.Errorf("Second copy created %d transactions, want 0", len(again))
+	}
+}
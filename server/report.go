@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+)
+
+const (
+	reportTopCategories   = 5
+	reportTopTransactions = 5
+)
+
+// GenerateMonthlyReport composes a markdown summary of one calendar month:
+// totals, the biggest expense categories and the largest transactions. It
+// only builds the text; delivering it is left to the caller.
+func (app *Application) GenerateMonthlyReport(ctx context.Context, year, month int) (string, error) {
+	if month < 1 || month > 12 {
+		return "", fmt.Errorf("invalid month %d", month)
+	}
+	period := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	key := period.Format("2006-01")
+
+	totals, err := app.Q.GetCategoryTotalsByMonth(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("category totals: %w", err)
+	}
+	largest, err := app.Q.ListLargestTransactionsByMonth(ctx, db.ListLargestTransactionsByMonthParams{
+		Month: key,
+		Limit: reportTopTransactions,
+	})
+	if err != nil {
+		return "", fmt.Errorf("largest transactions: %w", err)
+	}
+
+	var income, expense int64
+	var expenses []db.GetCategoryTotalsByMonthRow
+	for _, row := range totals {
+		if row.CategoryType == "income" {
+			income += row.TotalAmount
+		} else {
+			expense += row.TotalAmount
+			expenses = append(expenses, row)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Monthly report: %s\n\n", period.Format("January 2006"))
+
+	b.WriteString("## Summary\n\n")
+	fmt.Fprintf(&b, "- Income: %s\n", formatMoney(income))
+	fmt.Fprintf(&b, "- Expense: %s\n", formatMoney(expense))
+	net := formatMoney(income - expense)
+	if income < expense {
+		net = "-" + formatMoney(expense-income)
+	}
+	fmt.Fprintf(&b, "- Net: %s\n", net)
+
+	b.WriteString("\n## Top categories\n\n")
+	if len(expenses) == 0 {
+		b.WriteString("No expenses recorded.\n")
+	} else {
+		b.WriteString("| Category | Total | Transactions |\n")
+		b.WriteString("| --- | ---: | ---: |\n")
+		for _, row := range expenses[:min(len(expenses), reportTopCategories)] {
+			fmt.Fprintf(&b, "| %s %s | %s | %d |\n", row.CategoryIcon.String, row.CategoryName, formatMoney(row.TotalAmount), row.TransactionCount)
+		}
+	}
+
+	b.WriteString("\n## Biggest transactions\n\n")
+	if len(largest) == 0 {
+		b.WriteString("No transactions recorded.\n")
+	} else {
+		for _, tx := range largest {
+			amount := tx.Amount
+			if amount < 0 {
+				amount = -amount
+			}
+			fmt.Fprintf(&b, "- %s %s: %s (%s)\n", tx.Date.Format("2006-01-02"), tx.Description, formatMoney(amount), tx.CategoryName)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// HandleMonthlyReport serves the markdown report for ?year=&month=, defaulting
+// to the current month.
+func (app *Application) HandleMonthlyReport(w http.ResponseWriter, r *http.Request) {
+	year, month, err := yearMonthParam(r, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := app.GenerateMonthlyReport(r.Context(), year, month)
+	if err != nil {
+		http.Error(w, "Failed to generate report: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Write([]byte(report))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateMonthlyReport(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	march := func(day int) time.Time { return time.Date(2024, 3, day, 12, 0, 0, 0, time.UTC) }
+	createTestTransaction(t, app, 4, 500000, "salary", march(1))
+	createTestTransaction(t, app, 3, -150000, "rent", march(2))
+	createTestTransaction(t, app, 1, -4550, "groceries", march(10))
+	createTestTransaction(t, app, 2, -2000, "bus pass", march(15))
+	// Outside the month and deleted rows must not count
+	createTestTransaction(t, app, 1, -99900, "april feast", time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC))
+	gone := createTestTransaction(t, app, 1, -88800, "refunded dinner", march(20))
+	if _, err := app.DB.Exec("UPDATE transactions SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", gone.ID); err != nil {
+		t.Fatalf("Failed to delete transaction: %v", err)
+	}
+
+	report, err := app.GenerateMonthlyReport(context.Background(), 2024, 3)
+	if err != nil {
+		t.Fatalf("GenerateMonthlyReport() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# Monthly report: March 2024",
+		"- Income: $5000.00",
+		"- Expense: $1565.50",
+		"- Net: $3434.50",
+		"Housing | $1500.00 | 1 |",
+		"- 2024-03-02 rent: $1500.00 (Housing)",
+		"Food | $45.50 | 1 |",
+		"Transport | $20.00 | 1 |",
+		"- 2024-03-01 salary: $5000.00 (Earned Income)",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report missing %q:\n%s", want, report)
+		}
+	}
+	for _, unwanted := range []string{"april feast", "refunded dinner"} {
+		if strings.Contains(report, unwanted) {
+			t.Errorf("Report should not mention %q", unwanted)
+		}
+	}
+	if strings.Index(report, "Housing") > strings.Index(report, "Food") {
+		t.Error("Top categories should be ordered by total")
+	}
+
+	if _, err := app.GenerateMonthlyReport(context.Background(), 2024, 13); err == nil {
+		t.Error("Expected error for invalid month")
+	}
+}
+
+func TestHandleMonthlyReport(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+
+	createTestTransaction(t, app, 1, -1200, "lunch", time.Date(2024, 2, 5, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantBody   string
+	}{
+		{"crafted month", "?year=2024&month=2", http.StatusOK, "- Expense: $12.00"},
+		{"empty month", "?year=2023&month=2", http.StatusOK, "No expenses recorded."},
+		{"invalid month", "?year=2024&month=0", http.StatusBadRequest, ""},
+		{"invalid year", "?year=abc&month=2", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/report/monthly"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			app.HandleMonthlyReport(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("HandleMonthlyReport() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+				t.Errorf("Content-Type = %q, want text/markdown", ct)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("Body missing %q:\n%s", tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -5,24 +5,82 @@ import (
 )
 
 func (app *Application) setupRoutes(r chi.Router) {
+	// Probes for container orchestration
+	r.Get("/livez", app.HandleLivez)
+	r.Get("/readyz", app.HandleReadyz)
+
 	r.Get("/", app.HandleHome)
 	r.Get("/dashboard", app.HandleDashboard)
 	r.Get("/dashboard/detailed", app.HandleDashboardDetailed)
 	r.Get("/settings", app.HandleSettings)
 	r.Get("/api/transactions", app.HandleTransactionsPage)
+	r.Get("/api/transactions/activity", app.HandleTransactionActivity)
+	r.Get("/api/transactions/trash", app.HandleTransactionTrash)
+	r.Get("/api/transactions/uncategorized", app.HandleUncategorizedTransactions)
+	r.Post("/api/transactions/copy-recurring", app.HandleCopyRecurring)
+	r.Post("/api/transactions/tag", app.HandleBulkTag)
 	r.Post("/api/transaction", app.HandleTransactionCreate)
+	r.Post("/api/transaction/undo", app.HandleTransactionUndo)
+	r.Get("/api/parse-preview", app.HandleParsePreview)
+	r.Get("/api/categorize/matches", app.HandleCategorizeMatches)
 	r.Delete("/api/transaction/{id}", app.HandleTransactionDelete)
 	r.Post("/api/transaction/{id}/remove", app.HandleTransactionSoftDelete)
+	r.Post("/api/transaction/{id}/restore", app.HandleTransactionRestore)
+	r.Post("/api/transaction/{id}/category", app.HandleTransactionRecategorize)
+	r.Post("/api/transaction/{id}/amount", app.HandleTransactionUpdateAmount)
+	r.Post("/api/transaction/{id}/currency", app.HandleTransactionUpdateCurrency)
+	r.Post("/api/transaction/{id}/split", app.HandleTransactionSplit)
+	r.Get("/api/transaction/{id}/history", app.HandleTransactionHistory)
+	r.Post("/api/transaction/{id}/receipt", app.HandleReceiptUpload)
+	r.Get("/api/transaction/{id}/receipt", app.HandleReceiptDownload)
 	r.Get("/api/export/csv", app.HandleExportCSV)
+	r.Get("/api/export/category-summary.csv", app.HandleExportCategorySummaryCSV)
+	r.Get("/api/export/monthly.csv", app.HandleExportMonthlyCSV)
+	r.Get("/api/report/monthly", app.HandleMonthlyReport)
+	r.Put("/api/budgets", app.HandleSetBudget)
+	r.Get("/api/budgets/status", app.HandleBudgetStatus)
+	r.Get("/api/budgets/projected", app.HandleBudgetProjection)
+	r.Get("/api/periods", app.HandlePeriods)
+	r.Get("/api/analytics/breakdown", app.HandleCategoryBreakdown)
+	r.Get("/api/analytics/largest", app.HandleLargestTransactions)
+	r.Get("/api/analytics/networth-series", app.HandleNetWorthSeries)
+	r.Get("/api/analytics/balance-asof", app.HandleBalanceAsOf)
+	r.Get("/api/analytics/velocity", app.HandleSpendingVelocity)
+	r.Get("/api/analytics/projected-savings", app.HandleProjectedSavings)
+	r.Get("/api/analytics/month-to-date", app.HandleMonthToDate)
+	r.Get("/api/analytics/currencies", app.HandleCurrencyBreakdown)
+	r.Get("/api/analytics/category-totals/all", app.HandleCategoryTotalsAllTime)
+	r.Get("/api/analytics/daily", app.HandleDailyTotals)
+	r.Get("/api/analytics/busiest-days", app.HandleBusiestDays)
+	r.Get("/api/analytics/tags", app.HandleTagTotals)
+	r.Get("/api/analytics/avg-by-category", app.HandleAverageByCategory)
+	r.Get("/api/analytics/weekday", app.HandleWeekdayTotals)
+	r.Get("/api/analytics/discretionary", app.HandleDiscretionarySpending)
+	r.Get("/api/categories/by-spend", app.HandleCategoriesBySpend)
+	r.Post("/api/categories/ensure", app.HandleCategoryEnsure)
+	r.Post("/api/categories/{id}/reset", app.HandleCategoryReset)
 	r.Delete("/api/data", app.HandleWipeData)
+	r.Get("/api/settings/{key}", app.HandleGetSetting)
+	r.Put("/api/settings/{key}", app.HandlePutSetting)
+	r.Get("/api/audit", app.HandleAuditLog)
 
 	// Storage endpoints for IndexedDB <-> SQLite synchronization
 	r.Get("/api/storage/status", app.HandleStorageStatus)
 	r.Get("/api/storage/export", app.HandleStorageExport)
 	r.Post("/api/storage/import", app.HandleStorageImport)
+	r.Post("/api/storage/import-url", app.HandleStorageImportURL)
 
 	// Backup endpoints
 	r.Get("/api/backup/download", app.HandleBackupDownload)
+	r.Get("/api/backup/json", app.HandleBackupJSON)
 	r.Post("/api/backup/restore", app.HandleBackupRestore)
 	r.Get("/api/backup/status", app.HandleBackupStatus)
+	r.Get("/api/backup/manifest", app.HandleBackupManifest)
+
+	// Maintenance endpoints
+	r.Post("/api/maintenance/reindex", app.HandleMaintenanceReindex)
+	r.Post("/api/maintenance/seed", app.HandleMaintenanceSeed)
+	r.Get("/api/maintenance/duplicates", app.HandleMaintenanceDuplicates)
+	r.Get("/api/config", app.HandleConfig)
+	r.Get("/api/version", app.HandleVersion)
 }
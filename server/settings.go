@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/calexandrepcjr/cheapskate-finance-tracker/server/db"
+	"github.com/go-chi/chi/v5"
+)
+
+// settingOpeningBalance holds the opening balance in cents.
+const settingOpeningBalance = "opening_balance"
+
+// settingDefinition describes a key /api/settings/{key} accepts: the check a
+// value must pass and the flag value in effect while none is stored.
+type settingDefinition struct {
+	validate func(string) error
+	fallback func(Config) string
+}
+
+// settingDefinitions whitelists the settings that can be changed at runtime.
+var settingDefinitions = map[string]settingDefinition{
+	settingOpeningBalance: {
+		validate: func(v string) error {
+			if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+				return fmt.Errorf("must be a whole number of cents")
+			}
+			return nil
+		},
+		fallback: func(cfg Config) string {
+			return strconv.FormatInt(cfg.OpeningBalance, 10)
+		},
+	},
+}
+
+// openingBalance returns the balance in cents that running-balance analytics
+// start from: the value stored at runtime if there is one, otherwise
+// -opening-balance.
+func (app *Application) openingBalance(ctx context.Context) (int64, error) {
+	v, err := app.Q.GetSetting(ctx, settingOpeningBalance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return app.Config.OpeningBalance, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// Setting is a single settings entry as returned by /api/settings/{key}.
+type Setting struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// HandleGetSetting returns the value in effect for a whitelisted setting:
+// the stored value, or the flag value while none is stored.
+func (app *Application) HandleGetSetting(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	def, ok := settingDefinitions[key]
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown setting %q", key), http.StatusNotFound)
+		return
+	}
+
+	value, err := app.Q.GetSetting(r.Context(), key)
+	if errors.Is(err, sql.ErrNoRows) {
+		value, err = def.fallback(app.Config), nil
+	}
+	if err != nil {
+		http.Error(w, "Failed to load setting", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Setting{Key: key, Value: value})
+}
+
+// HandlePutSetting stores a whitelisted setting from a {"value": "..."}
+// body, replacing any earlier value.
+func (app *Application) HandlePutSetting(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	key := chi.URLParam(r, "key")
+	def, ok := settingDefinitions[key]
+	if !ok {
+		http.Error(w, fmt.Sprintf("Unknown setting %q", key), http.StatusNotFound)
+		return
+	}
+
+	var req Setting
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := def.validate(req.Value); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid %s: %v", key, err), http.StatusBadRequest)
+		return
+	}
+
+	if err := app.Q.SetSetting(ctx, db.SetSettingParams{Key: key, Value: req.Value}); err != nil {
+		http.Error(w, "Failed to save setting", http.StatusInternalServerError)
+		return
+	}
+	app.recordAudit(ctx, "setting", 0, key+"="+req.Value)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(Setting{Key: key, Value: req.Value})
+}
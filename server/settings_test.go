@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// withKeyParam sets the {key} URL parameter chi would extract from the route.
+func withKeyParam(req *http.Request, key string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("key", key)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// getSetting calls GET /api/settings/{key}.
+func getSetting(app *Application, key string) *httptest.ResponseRecorder {
+	req := withKeyParam(httptest.NewRequest(http.MethodGet, "/api/settings/"+key, nil), key)
+	rec := httptest.NewRecorder()
+	app.HandleGetSetting(rec, req)
+	return rec
+}
+
+// putSetting calls PUT /api/settings/{key} with a JSON body.
+func putSetting(app *Application, key, body string) *httptest.ResponseRecorder {
+	req := withKeyParam(httptest.NewRequest(http.MethodPut, "/api/settings/"+key, strings.NewReader(body)), key)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	app.HandlePutSetting(rec, req)
+	return rec
+}
+
+func TestOpeningBalanceSetting(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.OpeningBalance = 10000
+
+	createTestTransaction(t, app, 4, 300000, "salary", time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC))
+	createTestTransaction(t, app, 1, -50000, "groceries", time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+
+	lastBalance := func() int64 {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/api/analytics/networth-series", nil)
+		rec := httptest.NewRecorder()
+		app.HandleNetWorthSeries(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("HandleNetWorthSeries() status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var series []NetWorthPoint
+		if err := json.NewDecoder(rec.Body).Decode(&series); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(series) == 0 {
+			t.Fatal("HandleNetWorthSeries() returned no points")
+		}
+		return series[len(series)-1].BalanceCents
+	}
+
+	// Without a stored value the flag is the opening balance
+	if got, want := lastBalance(), int64(10000+300000-50000); got != want {
+		t.Errorf("balance from flag = %d, want %d", got, want)
+	}
+
+	if rec := putSetting(app, settingOpeningBalance, `{"value": "250000"}`); rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if got, want := lastBalance(), int64(250000+300000-50000); got != want {
+		t.Errorf("balance after setting opening = %d, want %d", got, want)
+	}
+
+	// Setting it again replaces the stored value
+	putSetting(app, settingOpeningBalance, `{"value": "-5000"}`)
+	if got, want := lastBalance(), int64(-5000+300000-50000); got != want {
+		t.Errorf("balance after resetting opening = %d, want %d", got, want)
+	}
+}
+
+func TestHandleGetPutSetting(t *testing.T) {
+	app := setupTestApp(t)
+	defer cleanupTestApp(t, app)
+	app.Config.OpeningBalance = 7500
+
+	decode := func(rec *httptest.ResponseRecorder) Setting {
+		t.Helper()
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		var got Setting
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return got
+	}
+
+	t.Run("unset setting reports the flag value", func(t *testing.T) {
+		got := decode(getSetting(app, settingOpeningBalance))
+		if want := (Setting{Key: settingOpeningBalance, Value: "7500"}); got != want {
+			t.Errorf("GET = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		if rec := putSetting(app, settingOpeningBalance, `{"value": "12345"}`); rec.Code != http.StatusOK {
+			t.Fatalf("PUT status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+		got := decode(getSetting(app, settingOpeningBalance))
+		if want := (Setting{Key: settingOpeningBalance, Value: "12345"}); got != want {
+			t.Errorf("GET = %+v, want %+v", got, want)
+		}
+		opening, err := app.openingBalance(context.Background())
+		if err != nil || opening != 12345 {
+			t.Errorf("openingBalance() = %d, %v, want 12345", opening, err)
+		}
+	})
+
+	t.Run("unknown keys are rejected", func(t *testing.T) {
+		for _, key := range []string{"theme", "default_year", "default_category"} {
+			if rec := putSetting(app, key, `{"value": "x"}`); rec.Code != http.StatusNotFound {
+				t.Errorf("PUT %s status = %d, want %d", key, rec.Code, http.StatusNotFound)
+			}
+			if rec := getSetting(app, key); rec.Code != http.StatusNotFound {
+				t.Errorf("GET %s status = %d, want %d", key, rec.Code, http.StatusNotFound)
+			}
+		}
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		for _, body := range []string{`{"value": "12.50"}`, `{"value": "lots"}`, `{"value": 5}`} {
+			if rec := putSetting(app, settingOpeningBalance, body); rec.Code != http.StatusBadRequest {
+				t.Errorf("PUT %s status = %d, want %d", body, rec.Code, http.StatusBadRequest)
+			}
+		}
+	})
+}